@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net/http"
 	"os"
+	"time"
+	_ "time/tzdata"
 
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/viper"
@@ -12,31 +16,73 @@ import (
 	"github.com/fiap-161/tc-golunch-core-service/database"
 	_ "github.com/fiap-161/tc-golunch-core-service/docs"
 
-	// "github.com/fiap-161/tc-golunch-core-service/internal/http/middleware"
 	admincontroller "github.com/fiap-161/tc-golunch-core-service/internal/admin/controller"
 	adminmodel "github.com/fiap-161/tc-golunch-core-service/internal/admin/dto"
 	admindatasource "github.com/fiap-161/tc-golunch-core-service/internal/admin/external/datasource"
 	adminhandler "github.com/fiap-161/tc-golunch-core-service/internal/admin/handler"
+	categorycontroller "github.com/fiap-161/tc-golunch-core-service/internal/category/controller"
+	categorymodel "github.com/fiap-161/tc-golunch-core-service/internal/category/dto"
+	categorydatasource "github.com/fiap-161/tc-golunch-core-service/internal/category/external/datasource"
+	categorygateway "github.com/fiap-161/tc-golunch-core-service/internal/category/gateway"
+	categoryhandler "github.com/fiap-161/tc-golunch-core-service/internal/category/handler"
+	categoryusecases "github.com/fiap-161/tc-golunch-core-service/internal/category/usecases"
+	combocontroller "github.com/fiap-161/tc-golunch-core-service/internal/combo/controller"
+	combomodel "github.com/fiap-161/tc-golunch-core-service/internal/combo/dto"
+	combodatasource "github.com/fiap-161/tc-golunch-core-service/internal/combo/external/datasource"
+	combogateway "github.com/fiap-161/tc-golunch-core-service/internal/combo/gateway"
+	combohandler "github.com/fiap-161/tc-golunch-core-service/internal/combo/handler"
+	combousecases "github.com/fiap-161/tc-golunch-core-service/internal/combo/usecases"
 	customercontroller "github.com/fiap-161/tc-golunch-core-service/internal/customer/controller"
 	customermodel "github.com/fiap-161/tc-golunch-core-service/internal/customer/dto"
 	customerdatasource "github.com/fiap-161/tc-golunch-core-service/internal/customer/external/datasource"
-	customergateway "github.com/fiap-161/tc-golunch-core-service/internal/customer/gateway"
 	customerhandler "github.com/fiap-161/tc-golunch-core-service/internal/customer/handler"
+	"github.com/fiap-161/tc-golunch-core-service/internal/http/middleware"
+	idempotencycontroller "github.com/fiap-161/tc-golunch-core-service/internal/idempotency/controller"
+	idempotencymodel "github.com/fiap-161/tc-golunch-core-service/internal/idempotency/dto"
+	idempotencydatasource "github.com/fiap-161/tc-golunch-core-service/internal/idempotency/external/datasource"
+	idempotencygateway "github.com/fiap-161/tc-golunch-core-service/internal/idempotency/gateway"
+	idempotencyusecases "github.com/fiap-161/tc-golunch-core-service/internal/idempotency/usecases"
+	idempotencyworker "github.com/fiap-161/tc-golunch-core-service/internal/idempotency/worker"
+	inventorycontroller "github.com/fiap-161/tc-golunch-core-service/internal/inventory/controller"
+	inventorymodel "github.com/fiap-161/tc-golunch-core-service/internal/inventory/dto"
+	inventorydatasource "github.com/fiap-161/tc-golunch-core-service/internal/inventory/external/datasource"
+	inventorygateway "github.com/fiap-161/tc-golunch-core-service/internal/inventory/gateway"
+	inventoryhandler "github.com/fiap-161/tc-golunch-core-service/internal/inventory/handler"
+	inventoryusecases "github.com/fiap-161/tc-golunch-core-service/internal/inventory/usecases"
 	ordercontroller "github.com/fiap-161/tc-golunch-core-service/internal/order/controller"
 	ordermodel "github.com/fiap-161/tc-golunch-core-service/internal/order/dto"
+	orderentity "github.com/fiap-161/tc-golunch-core-service/internal/order/entity"
 	orderdatasource "github.com/fiap-161/tc-golunch-core-service/internal/order/external/datasource"
 	ordergateway "github.com/fiap-161/tc-golunch-core-service/internal/order/gateway"
 	orderhandler "github.com/fiap-161/tc-golunch-core-service/internal/order/handler"
+	orderstream "github.com/fiap-161/tc-golunch-core-service/internal/order/stream"
+	orderworker "github.com/fiap-161/tc-golunch-core-service/internal/order/worker"
+	outboxcontroller "github.com/fiap-161/tc-golunch-core-service/internal/outbox/controller"
+	outboxmodel "github.com/fiap-161/tc-golunch-core-service/internal/outbox/dto"
+	outboxdatasource "github.com/fiap-161/tc-golunch-core-service/internal/outbox/external/datasource"
+	outboxgateway "github.com/fiap-161/tc-golunch-core-service/internal/outbox/gateway"
+	outboxservices "github.com/fiap-161/tc-golunch-core-service/internal/outbox/gateway/services"
+	outboxhandler "github.com/fiap-161/tc-golunch-core-service/internal/outbox/handler"
+	outboxusecases "github.com/fiap-161/tc-golunch-core-service/internal/outbox/usecases"
+	outboxworker "github.com/fiap-161/tc-golunch-core-service/internal/outbox/worker"
 	productcontroller "github.com/fiap-161/tc-golunch-core-service/internal/product/controller"
 	productmodel "github.com/fiap-161/tc-golunch-core-service/internal/product/dto"
 	productdatasource "github.com/fiap-161/tc-golunch-core-service/internal/product/external/datasource"
 	productgateway "github.com/fiap-161/tc-golunch-core-service/internal/product/gateway"
+	productservices "github.com/fiap-161/tc-golunch-core-service/internal/product/gateway/services"
 	producthandler "github.com/fiap-161/tc-golunch-core-service/internal/product/handler"
 	productusecases "github.com/fiap-161/tc-golunch-core-service/internal/product/usecases"
 	productordermodel "github.com/fiap-161/tc-golunch-core-service/internal/productorder/dto"
 	productorderdatasource "github.com/fiap-161/tc-golunch-core-service/internal/productorder/external/datasource"
 	productordergateway "github.com/fiap-161/tc-golunch-core-service/internal/productorder/gateway"
 	productorderusecases "github.com/fiap-161/tc-golunch-core-service/internal/productorder/usecases"
+	promotioncontroller "github.com/fiap-161/tc-golunch-core-service/internal/promotion/controller"
+	promotionmodel "github.com/fiap-161/tc-golunch-core-service/internal/promotion/dto"
+	promotiondatasource "github.com/fiap-161/tc-golunch-core-service/internal/promotion/external/datasource"
+	promotiongateway "github.com/fiap-161/tc-golunch-core-service/internal/promotion/gateway"
+	promotionhandler "github.com/fiap-161/tc-golunch-core-service/internal/promotion/handler"
+	promotionusecases "github.com/fiap-161/tc-golunch-core-service/internal/promotion/usecases"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared"
 	sharedgateway "github.com/fiap-161/tc-golunch-core-service/internal/shared/gateway"
 )
 
@@ -60,15 +106,44 @@ func main() {
 
 	db := database.NewPostgresDatabase().GetDb()
 
+	// Prices used to be decimal columns, they are now kept in cents
+	for _, legacy := range []struct {
+		model  any
+		column string
+	}{
+		{&productmodel.ProductDAO{}, "price"},
+		{&ordermodel.OrderDAO{}, "price"},
+		{&productordermodel.ProductOrderDAO{}, "unit_price"},
+	} {
+		if err := database.MigrateDecimalToMinorUnits(db, legacy.model, legacy.column); err != nil {
+			log.Fatalf("Erro ao migrar o banco: %v", err)
+		}
+	}
+
 	if err := db.AutoMigrate(
 		&customermodel.CustomerDAO{},
+		&categorymodel.CategoryDAO{},
 		&productmodel.ProductDAO{},
+		&productmodel.ProductRevisionDAO{},
 		&ordermodel.OrderDAO{},
+		&ordermodel.OrderStatusHistoryDAO{},
+		&ordermodel.OrderNumberSequenceDAO{},
+		&outboxmodel.OutboxEventDAO{},
+		&idempotencymodel.IdempotencyKeyDAO{},
 		&productordermodel.ProductOrderDAO{},
+		&combomodel.ComboDAO{},
+		&promotionmodel.PromotionDAO{},
+		&promotionmodel.RedemptionDAO{},
+		&inventorymodel.StockDAO{},
+		&inventorymodel.ReservationDAO{},
+		&inventorymodel.AdjustmentDAO{},
 		&adminmodel.AdminDAO{},
 	); err != nil {
 		log.Fatalf("Erro ao migrar o banco: %v", err)
 	}
+	if err := database.MigrateTextSearch(db, &productmodel.ProductDAO{}, "name", "description"); err != nil {
+		log.Fatalf("Erro ao migrar o banco: %v", err)
+	}
 
 	// Serverless Auth Gateway (following tc-golunch-api monolith pattern)
 	serverlessAuth := sharedgateway.NewServerlessAuthGateway(
@@ -86,28 +161,115 @@ func main() {
 	adminController := admincontroller.Build(adminDatasource, serverlessAuth)
 	adminHandler := adminhandler.New(adminController)
 
-	// Product
+	// Order numbers (per store, restarting on the configured schedule) and the store timezone
+	orderNumbering, err := orderentity.NewNumbering(
+		shared.StringFromEnv("STORE_ID", "main"),
+		shared.StringFromEnv("ORDER_NUMBER_RESET", string(orderentity.NumberResetDaily)),
+		shared.IntFromEnv("ORDER_NUMBER_RESET_HOUR", 0),
+		shared.StringFromEnv("ORDER_NUMBER_TIMEZONE", "America/Sao_Paulo"),
+	)
+	if err != nil {
+		log.Fatalf("Erro ao configurar a numeração de pedidos: %v", err)
+	}
+
+	// Product (menu schedules read in the store timezone)
 	productDataSource := productdatasource.New(db)
-	productController := productcontroller.Build(productDataSource)
-	productHandler := producthandler.New(productController)
+	comboDataSource := combodatasource.New(db)
+	promotionDataSource := promotiondatasource.New(db)
+
+	// Category (products, combo slots and promotions refer to them by code)
+	categoryDataSource := categorydatasource.New(db)
+	categoryGateway := categorygateway.Build(categoryDataSource)
+	categoryUseCase := categoryusecases.Build(
+		*categoryGateway,
+		productgateway.Build(productDataSource),
+		combogateway.Build(comboDataSource),
+		promotiongateway.Build(promotionDataSource),
+	)
+	if err := categoryUseCase.SeedDefaults(context.Background()); err != nil {
+		log.Fatalf("Erro ao migrar o banco: %v", err)
+	}
+	categoryController := categorycontroller.Build(categoryUseCase)
+	categoryHandler := categoryhandler.New(categoryController)
+
+	// Product images (local disk by default, IMAGE_STORAGE=s3 for a bucket shared by the replicas)
+	imageStorage, err := productservices.NewImageStorage()
+	if err != nil {
+		log.Fatalf("Erro ao configurar o armazenamento de imagens: %v", err)
+	}
 
 	// Product Order
 	productOrderDataSource := productorderdatasource.New(db)
 	productOrderGateway := productordergateway.Build(productOrderDataSource)
 	productOrderUseCase := productorderusecases.Build(*productOrderGateway)
 
+	productController := productcontroller.Build(productDataSource, orderNumbering.Location, database.NewUnitOfWork(db), categoryUseCase, productOrderUseCase, imageStorage)
+	productHandler := producthandler.New(productController)
+
 	// Common Gateways
 	productGateway := productgateway.Build(productDataSource)
-	productUseCase := productusecases.Build(*productGateway)
+	productUseCase := productusecases.Build(*productGateway, orderNumbering.Location, database.NewUnitOfWork(db), categoryUseCase, productOrderUseCase, imageStorage)
+
+	// Combo (bundles of products sold at one price)
+	comboGateway := combogateway.Build(comboDataSource)
+	comboUseCase := combousecases.Build(*comboGateway, productUseCase, categoryUseCase)
+	comboController := combocontroller.Build(comboUseCase)
+	comboHandler := combohandler.New(comboController)
+
+	// Outbox (payment and operation notifications delivered by the relay)
+	outboxDataSource := outboxdatasource.New(db)
+	outboxGateway := outboxgateway.Build(outboxDataSource)
+	outboxUseCase := outboxusecases.Build(*outboxGateway, outboxservices.NewHTTPDispatcher())
+	outboxController := outboxcontroller.Build(outboxUseCase)
+	outboxHandler := outboxhandler.New(outboxController)
+
+	outboxRelay := outboxworker.NewRelay(outboxController, shared.DurationFromEnv("OUTBOX_RELAY_INTERVAL", 2*time.Second))
+	go outboxRelay.Start(context.Background())
+
+	// Idempotency keys (retried order creation and payment webhooks replay the first response)
+	idempotencyDataSource := idempotencydatasource.New(db)
+	idempotencyGateway := idempotencygateway.Build(idempotencyDataSource)
+	idempotencyUseCase := idempotencyusecases.Build(
+		*idempotencyGateway,
+		shared.DurationFromEnv("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+		shared.DurationFromEnv("IDEMPOTENCY_LOCK_TTL", time.Minute),
+	)
+	idempotencyController := idempotencycontroller.Build(idempotencyUseCase)
+	idempotent := middleware.IdempotencyMiddleware(idempotencyController)
+
+	idempotencyPurger := idempotencyworker.NewPurger(idempotencyController, shared.DurationFromEnv("IDEMPOTENCY_PURGE_INTERVAL", time.Hour))
+	go idempotencyPurger.Start(context.Background())
 
 	// Order Data Source and Gateway
 	orderDataSource := orderdatasource.New(db)
 	orderGateway := ordergateway.Build(orderDataSource)
 
 	// Order Controller and Handler
-	orderController := ordercontroller.Build(orderGateway, productUseCase, productOrderUseCase)
+	// Promotions (coupons and automatic discounts, happy hours read in the store timezone)
+	promotionGateway := promotiongateway.Build(promotionDataSource)
+	promotionUseCase := promotionusecases.Build(*promotionGateway, categoryUseCase, orderNumbering.Location)
+	promotionController := promotioncontroller.Build(promotionUseCase)
+	promotionHandler := promotionhandler.New(promotionController)
+
+	// Inventory (products without a stock are not tracked)
+	inventoryDataSource := inventorydatasource.New(db)
+	inventoryGateway := inventorygateway.Build(inventoryDataSource)
+	inventoryUseCase := inventoryusecases.Build(*inventoryGateway, productUseCase, database.NewUnitOfWork(db))
+	inventoryController := inventorycontroller.Build(inventoryUseCase)
+	inventoryHandler := inventoryhandler.New(inventoryController)
+
+	panelStream := orderstream.NewBroadcaster(orderstream.DefaultHistorySize)
+	orderController := ordercontroller.Build(orderGateway, productUseCase, comboUseCase, promotionUseCase, inventoryUseCase, productOrderUseCase, database.NewUnitOfWork(db), outboxUseCase, panelStream, orderNumbering)
 	orderHandler := orderhandler.New(orderController)
 
+	// Order expiry sweeper (orders awaiting payment longer than the window are expired)
+	expirySweeper := orderworker.NewExpirySweeper(
+		orderController,
+		shared.DurationFromEnv("ORDER_PAYMENT_EXPIRATION", 15*time.Minute),
+		shared.DurationFromEnv("ORDER_EXPIRATION_SWEEP_INTERVAL", time.Minute),
+	)
+	go expirySweeper.Start(context.Background())
+
 	// Default Routes
 	r.GET("/ping", ping)
 	r.GET("/swagger/*any", ginswagger.WrapHandler(swaggerfiles.Handler))
@@ -122,9 +284,21 @@ func main() {
 	r.POST("/admin/login", adminHandler.Login)
 	// r.GET("/admin/validate", adminHandler.ValidateToken) // TODO: Implement validation
 
+	// Images kept on the local disk are served, and uploaded through presigned URLs, by the storage itself
+	if uploads, ok := imageStorage.(http.Handler); ok {
+		served := gin.WrapH(http.StripPrefix("/uploads", uploads))
+		r.GET("/uploads/*key", served)
+		r.HEAD("/uploads/*key", served)
+		r.PUT("/uploads/*key", served)
+	}
+
 	// Product Routes (read-only for core service)
+	r.GET("/category", categoryHandler.ListActive)
 	r.GET("/product/categories", productHandler.ListCategories)
 	r.GET("/product", productHandler.GetAllByCategory)
+	r.GET("/product/search", productHandler.Search)
+	r.GET("/combo", comboHandler.ListActive)
+	r.GET("/combo/:id", comboHandler.GetByID)
 
 	// Admin Product Routes (protected by JWT token from admin login)
 	adminRoutes := r.Group("/admin")
@@ -133,17 +307,57 @@ func main() {
 	adminRoutes.PUT("/product/:id", productHandler.Update)
 	adminRoutes.DELETE("/product/:id", productHandler.Delete)
 	adminRoutes.POST("/product/upload", productHandler.UploadImage)
-	adminRoutes.GET("/product", productHandler.GetAllByCategory) // Lista todos os produtos por categoria
+	adminRoutes.POST("/product/upload/presigned", productHandler.PresignImageUpload)
+	adminRoutes.PUT("/product/:id/availability", productHandler.SetAvailability)
+	adminRoutes.GET("/product/archived", productHandler.ListArchived)
+	adminRoutes.POST("/product/:id/restore", productHandler.Restore)
+	adminRoutes.POST("/product/:id/purge", productHandler.Purge)
+	adminRoutes.GET("/product/:id/history", productHandler.History)
+	adminRoutes.GET("/product", productHandler.AdminGetAllByCategory) // Lista todos os produtos por categoria, inclusive os indisponíveis
+	adminRoutes.POST("/category", categoryHandler.Create)
+	adminRoutes.GET("/category", categoryHandler.List)
+	adminRoutes.PUT("/category/:id", categoryHandler.Update)
+	adminRoutes.DELETE("/category/:id", categoryHandler.Delete)
+	adminRoutes.POST("/combo", comboHandler.Create)
+	adminRoutes.GET("/combo", comboHandler.List)
+	adminRoutes.PUT("/combo/:id", comboHandler.Update)
+	adminRoutes.DELETE("/combo/:id", comboHandler.Delete)
+	adminRoutes.POST("/promotion", promotionHandler.Create)
+	adminRoutes.GET("/promotion", promotionHandler.List)
+	adminRoutes.GET("/promotion/:id", promotionHandler.GetByID)
+	adminRoutes.PUT("/promotion/:id", promotionHandler.Update)
+	adminRoutes.DELETE("/promotion/:id", promotionHandler.Delete)
+	adminRoutes.GET("/stock", inventoryHandler.List)
+	adminRoutes.GET("/stock/low", inventoryHandler.ListLow)
+	adminRoutes.PUT("/stock/:product_id", inventoryHandler.Set)
+	adminRoutes.POST("/stock/:product_id/adjustments", inventoryHandler.Adjust)
+	adminRoutes.GET("/outbox", outboxHandler.List)
+	adminRoutes.POST("/outbox/:id/replay", outboxHandler.Replay)
 
 	// Order Routes
-	r.POST("/order", orderHandler.Create)
+	r.POST("/order", idempotent, orderHandler.Create)
+	r.POST("/order/quote", orderHandler.Quote)
 	r.GET("/order", orderHandler.GetAll)
 	r.PUT("/order/:id", orderHandler.Update)
+	r.GET("/order/:id/history", orderHandler.GetStatusHistory)
 	r.GET("/order/panel", orderHandler.GetPanel)
+	r.GET("/order/panel/stream", orderHandler.StreamPanel)
+	r.GET("/order/panel/ws", orderHandler.StreamPanelWebSocket)
+	r.GET("/order/:id", orderHandler.GetByID)
+
+	// Customer Routes (the customer is the one of the token, set as user_id by the middleware)
+	customerRoutes := r.Group("/", middleware.ServerlessAuthMiddleware(*serverlessAuth))
+	customerRoutes.GET("/customer/me/orders", orderHandler.ListMine)
+	customerRoutes.POST("/order/:id/reorder", orderHandler.Reorder)
+	customerRoutes.POST("/order/:id/cancel", orderHandler.Cancel)
+
+	// Service Routes (other services, identified by their X-Service-Name and X-Service-Key headers)
+	serviceRoutes := r.Group("/service", middleware.ServiceAuthMiddleware())
+	serviceRoutes.POST("/order/:id/cancel", orderHandler.Cancel)
 
 	// Webhook Routes for inter-service communication
 	webhookHandler := orderhandler.NewWebhookHandler(orderController)
-	r.POST("/webhook/payment", webhookHandler.PaymentWebhook)
+	r.POST("/webhook/payment", idempotent, webhookHandler.PaymentWebhook)
 
 	r.Run(":8081")
 }
@@ -0,0 +1,99 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MigrateDecimalToMinorUnits converts a decimal money column created before amounts were kept in
+// cents into the <column>_amount bigint column of a money.Money field. It has to run before
+// AutoMigrate, which then adds <column>_currency with the default currency for existing rows.
+// Tables that are new or already converted are left alone.
+func MigrateDecimalToMinorUnits(db *gorm.DB, model any, column string) error {
+	amountColumn := column + "_amount"
+
+	migrator := db.Migrator()
+	if !migrator.HasColumn(model, column) || migrator.HasColumn(model, amountColumn) {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Migrator().RenameColumn(model, column, amountColumn); err != nil {
+			return err
+		}
+
+		stmt := &gorm.Statement{DB: tx}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+
+		return tx.Exec(
+			"ALTER TABLE ? ALTER COLUMN ? TYPE bigint USING COALESCE(ROUND(? * 100), 0)",
+			clause.Table{Name: stmt.Schema.Table},
+			clause.Column{Name: amountColumn},
+			clause.Column{Name: amountColumn},
+		).Error
+	})
+}
+
+// SearchConfiguration is the text search configuration of the catalog: Portuguese stemming that
+// also ignores accents, so "pao" matches "pão"
+const SearchConfiguration = "portuguese_unaccent"
+
+// SearchVectorColumn is the generated tsvector column added by MigrateTextSearch
+const SearchVectorColumn = "search_vector"
+
+var searchWeights = []string{"A", "B", "C", "D"}
+
+// MigrateTextSearch creates SearchConfiguration and adds to the table of model a SearchVectorColumn
+// generated from columns with a GIN index. Earlier columns weigh more when ranking matches, up to
+// four of them. It runs after AutoMigrate and leaves an existing column alone.
+func MigrateTextSearch(db *gorm.DB, model any, columns ...string) error {
+	if len(columns) == 0 || len(columns) > len(searchWeights) {
+		return fmt.Errorf("text search takes 1 to %d columns, got %d", len(searchWeights), len(columns))
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+	table := stmt.Schema.Table
+
+	parts := make([]string, 0, len(columns))
+	for i, column := range columns {
+		parts = append(parts, fmt.Sprintf("setweight(to_tsvector('%s', coalesce(%s, '')), '%s')", SearchConfiguration, column, searchWeights[i]))
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("CREATE EXTENSION IF NOT EXISTS unaccent").Error; err != nil {
+			return err
+		}
+		// Text search configurations have no IF NOT EXISTS
+		if err := tx.Exec(fmt.Sprintf(`
+			DO $$
+			BEGIN
+				IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = '%[1]s') THEN
+					CREATE TEXT SEARCH CONFIGURATION %[1]s (COPY = portuguese);
+					ALTER TEXT SEARCH CONFIGURATION %[1]s
+						ALTER MAPPING FOR hword, hword_part, word WITH unaccent, portuguese_stem;
+				END IF;
+			END $$`, SearchConfiguration)).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(fmt.Sprintf(
+			"ALTER TABLE ? ADD COLUMN IF NOT EXISTS %s tsvector GENERATED ALWAYS AS (%s) STORED",
+			SearchVectorColumn, strings.Join(parts, " || "),
+		), clause.Table{Name: table}).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(
+			fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_%s ON ? USING GIN (%s)", table, SearchVectorColumn, SearchVectorColumn),
+			clause.Table{Name: table},
+		).Error
+	})
+}
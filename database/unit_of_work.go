@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// UnitOfWork runs a function inside a single database transaction.
+// Datasources called with the context received by fn take part in that transaction.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type gormUnitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) UnitOfWork {
+	return &gormUnitOfWork{db: db}
+}
+
+// Do commits when fn returns nil and rolls back otherwise.
+// A call nested in a running unit of work joins the outer transaction.
+func (u *gormUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// TxFromContext returns the transaction started by a unit of work, if any
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return tx, ok
+}
+
+// Conn returns the transaction of the running unit of work, or db outside of one. db is the
+// connection a datasource was built with, seen through its own DB interface.
+func Conn[DB any](ctx context.Context, db DB) DB {
+	if tx, ok := TxFromContext(ctx); ok {
+		if conn, ok := any(tx).(DB); ok {
+			return conn
+		}
+	}
+	return db
+}
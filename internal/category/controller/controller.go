@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/presenter"
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/usecases"
+)
+
+type Controller struct {
+	categoryUseCase *usecases.UseCases
+}
+
+func Build(categoryUseCase *usecases.UseCases) *Controller {
+	return &Controller{
+		categoryUseCase: categoryUseCase,
+	}
+}
+
+func (c *Controller) Create(ctx context.Context, request dto.CategoryRequestDTO) (dto.CategoryResponseDTO, error) {
+	presenter := presenter.Build()
+
+	category, err := c.categoryUseCase.Create(ctx, dto.FromRequestDTO(request))
+	if err != nil {
+		return dto.CategoryResponseDTO{}, err
+	}
+
+	return presenter.FromEntityToResponseDTO(category), nil
+}
+
+func (c *Controller) List(ctx context.Context, activeOnly bool) (dto.CategoryListResponseDTO, error) {
+	presenter := presenter.Build()
+
+	categories, err := c.categoryUseCase.List(ctx, activeOnly)
+	if err != nil {
+		return dto.CategoryListResponseDTO{}, err
+	}
+
+	return presenter.FromEntityListToListResponseDTO(categories), nil
+}
+
+func (c *Controller) Update(ctx context.Context, id string, request dto.CategoryRequestDTO) (dto.CategoryResponseDTO, error) {
+	presenter := presenter.Build()
+
+	category, err := c.categoryUseCase.Update(ctx, id, dto.FromRequestDTO(request))
+	if err != nil {
+		return dto.CategoryResponseDTO{}, err
+	}
+
+	return presenter.FromEntityToResponseDTO(category), nil
+}
+
+func (c *Controller) Delete(ctx context.Context, id string) error {
+	return c.categoryUseCase.Delete(ctx, id)
+}
@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/entity"
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	coreentity "github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+)
+
+// CategoryRequestDTO creates or replaces a category. The code of an existing category cannot
+// change and may be left out on updates.
+type CategoryRequestDTO struct {
+	Code         string `json:"code" example:"BREAKFAST"`
+	Name         string `json:"name" binding:"required" example:"Café da manhã"`
+	DisplayOrder int    `json:"display_order"`
+	Icon         string `json:"icon" example:"coffee"`
+	// Active defaults to true
+	Active *bool `json:"active"`
+}
+
+type CategoryResponseDTO struct {
+	ID           string `json:"id"`
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	DisplayOrder int    `json:"display_order"`
+	Icon         string `json:"icon"`
+	Active       bool   `json:"active"`
+}
+
+type CategoryListResponseDTO struct {
+	Total uint                  `json:"total"`
+	List  []CategoryResponseDTO `json:"list"`
+}
+
+type CategoryDAO struct {
+	coreentity.Entity
+	Code         string `json:"code" gorm:"type:varchar(20);uniqueIndex"`
+	Name         string `json:"name" gorm:"type:varchar(100)"`
+	DisplayOrder int    `json:"display_order" gorm:"not null"`
+	Icon         string `json:"icon" gorm:"type:varchar(255)"`
+	Active       bool   `json:"active" gorm:"not null;index"`
+}
+
+func (CategoryDAO) TableName() string {
+	return "categories"
+}
+
+func ToCategoryDAO(category entity.Category) CategoryDAO {
+	return CategoryDAO{
+		Entity:       category.Entity,
+		Code:         string(category.Code),
+		Name:         category.Name,
+		DisplayOrder: category.DisplayOrder,
+		Icon:         category.Icon,
+		Active:       category.Active,
+	}
+}
+
+func FromCategoryDAO(dao CategoryDAO) entity.Category {
+	return entity.Category{
+		Entity:       dao.Entity,
+		Code:         productenum.Category(dao.Code),
+		Name:         dao.Name,
+		DisplayOrder: dao.DisplayOrder,
+		Icon:         dao.Icon,
+		Active:       dao.Active,
+	}
+}
+
+func EntityListFromDAOList(daoList []CategoryDAO) []entity.Category {
+	categories := make([]entity.Category, 0, len(daoList))
+	for _, dao := range daoList {
+		categories = append(categories, FromCategoryDAO(dao))
+	}
+	return categories
+}
+
+func FromRequestDTO(request CategoryRequestDTO) entity.Category {
+	return entity.Category{
+		Code:         entity.NormalizeCode(request.Code),
+		Name:         request.Name,
+		DisplayOrder: request.DisplayOrder,
+		Icon:         request.Icon,
+		Active:       request.Active == nil || *request.Active,
+	}
+}
+
+func ToResponseDTO(category entity.Category) CategoryResponseDTO {
+	return CategoryResponseDTO{
+		ID:           category.ID,
+		Code:         string(category.Code),
+		Name:         category.Name,
+		DisplayOrder: category.DisplayOrder,
+		Icon:         category.Icon,
+		Active:       category.Active,
+	}
+}
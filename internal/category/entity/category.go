@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	coreentity "github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/google/uuid"
+)
+
+// Category groups products on the menu. Products, combo slots and promotions refer to it by
+// Code, which therefore cannot change once created. Inactive categories and their products are
+// hidden from customers.
+type Category struct {
+	coreentity.Entity
+	Code         productenum.Category
+	Name         string
+	DisplayOrder int
+	Icon         string
+	Active       bool
+}
+
+var codePattern = regexp.MustCompile(`^[A-Z0-9_]{1,20}$`)
+
+func (c Category) Build() Category {
+	now := time.Now()
+	c.Entity = coreentity.Entity{
+		ID:        uuid.NewString(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	c.Code = NormalizeCode(string(c.Code))
+	return c
+}
+
+// NormalizeCode makes category codes case insensitive, as they always were in the product API
+func NormalizeCode(code string) productenum.Category {
+	return productenum.Category(strings.ToUpper(strings.TrimSpace(code)))
+}
+
+func (c Category) Validate() error {
+	if !codePattern.MatchString(string(c.Code)) {
+		return &apperror.ValidationError{Msg: fmt.Sprintf("Invalid category code %q, use up to 20 letters, digits and underscores", c.Code)}
+	}
+	if c.Name == "" {
+		return &apperror.ValidationError{Msg: "Name is required"}
+	}
+	return nil
+}
+
+// Defaults are the categories the menu had before they could be managed, seeded into an empty table
+func Defaults() []Category {
+	return []Category{
+		{Code: productenum.Meal, Name: "Lanche", DisplayOrder: 1, Active: true},
+		{Code: productenum.Side, Name: "Acompanhamento", DisplayOrder: 2, Active: true},
+		{Code: productenum.Drink, Name: "Bebida", DisplayOrder: 3, Active: true},
+		{Code: productenum.Dessert, Name: "Sobremesa", DisplayOrder: 4, Active: true},
+	}
+}
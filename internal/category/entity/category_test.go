@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"testing"
+
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoryValidate(t *testing.T) {
+	t.Run("Given a code typed in lower case, when the category is built, then the code is stored in upper case", func(t *testing.T) {
+		category := Category{Code: " breakfast ", Name: "Café da manhã"}.Build()
+
+		assert.Equal(t, productenum.Category("BREAKFAST"), category.Code)
+		assert.NoError(t, category.Validate())
+	})
+
+	tests := []struct {
+		name     string
+		category Category
+	}{
+		{name: "Given a code with spaces, when the category is validated, then it is rejected", category: Category{Code: "KIDS MENU", Name: "Kids"}},
+		{name: "Given an empty code, when the category is validated, then it is rejected", category: Category{Name: "Kids"}},
+		{name: "Given no name, when the category is validated, then it is rejected", category: Category{Code: "KIDS"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErr *apperror.ValidationError
+			assert.ErrorAs(t, tt.category.Validate(), &validationErr)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package datasource
+
+import (
+	"context"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/dto"
+)
+
+type DataSource interface {
+	Create(ctx context.Context, categories ...dto.CategoryDAO) error
+	List(ctx context.Context, activeOnly bool) ([]dto.CategoryDAO, error)
+	Count(ctx context.Context) (int64, error)
+	FindByID(ctx context.Context, id string) (dto.CategoryDAO, error)
+	FindByCodes(ctx context.Context, codes []string) ([]dto.CategoryDAO, error)
+	Update(ctx context.Context, category dto.CategoryDAO) (dto.CategoryDAO, error)
+	Delete(ctx context.Context, id string) error
+}
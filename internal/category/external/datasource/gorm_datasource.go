@@ -0,0 +1,98 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/dto"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"gorm.io/gorm"
+)
+
+// DB interface defines the database operations needed
+type DB interface {
+	Create(value any) *gorm.DB
+	Where(query any, args ...any) *gorm.DB
+	First(dest any, conds ...any) *gorm.DB
+	Find(dest any, conds ...any) *gorm.DB
+	Save(value any) *gorm.DB
+	Delete(value any, conds ...any) *gorm.DB
+	Model(value any) *gorm.DB
+	Order(value any) *gorm.DB
+}
+
+type GormDataSource struct {
+	db DB
+}
+
+func New(db DB) DataSource {
+	return &GormDataSource{
+		db: db,
+	}
+}
+
+func (g *GormDataSource) Create(_ context.Context, categories ...dto.CategoryDAO) error {
+	return g.db.Create(&categories).Error
+}
+
+// List returns the categories in the order the menu shows them
+func (g *GormDataSource) List(_ context.Context, activeOnly bool) ([]dto.CategoryDAO, error) {
+	var categories []dto.CategoryDAO
+
+	query := g.db.Order("display_order, name")
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	if err := query.Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (g *GormDataSource) Count(_ context.Context) (int64, error) {
+	var count int64
+	err := g.db.Model(&dto.CategoryDAO{}).Count(&count).Error
+	return count, err
+}
+
+func (g *GormDataSource) FindByID(_ context.Context, id string) (dto.CategoryDAO, error) {
+	var category dto.CategoryDAO
+
+	if err := g.db.First(&category, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.CategoryDAO{}, &apperror.NotFoundError{Msg: "Category not found"}
+		}
+		return dto.CategoryDAO{}, err
+	}
+
+	return category, nil
+}
+
+func (g *GormDataSource) FindByCodes(_ context.Context, codes []string) ([]dto.CategoryDAO, error) {
+	var categories []dto.CategoryDAO
+
+	if err := g.db.Where("code IN ?", codes).Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (g *GormDataSource) Update(_ context.Context, category dto.CategoryDAO) (dto.CategoryDAO, error) {
+	if err := g.db.Save(&category).Error; err != nil {
+		return dto.CategoryDAO{}, err
+	}
+	return category, nil
+}
+
+func (g *GormDataSource) Delete(_ context.Context, id string) error {
+	result := g.db.Delete(&dto.CategoryDAO{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return &apperror.NotFoundError{Msg: "Category not found"}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/external/datasource"
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+)
+
+type Gateway struct {
+	datasource datasource.DataSource
+}
+
+func Build(datasource datasource.DataSource) *Gateway {
+	return &Gateway{
+		datasource: datasource,
+	}
+}
+
+func (g *Gateway) Create(ctx context.Context, categories ...entity.Category) error {
+	daoList := make([]dto.CategoryDAO, 0, len(categories))
+	for _, category := range categories {
+		daoList = append(daoList, dto.ToCategoryDAO(category))
+	}
+	if err := g.datasource.Create(ctx, daoList...); err != nil {
+		return &apperror.InternalError{Msg: err.Error()}
+	}
+	return nil
+}
+
+func (g *Gateway) List(ctx context.Context, activeOnly bool) ([]entity.Category, error) {
+	found, err := g.datasource.List(ctx, activeOnly)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.EntityListFromDAOList(found), nil
+}
+
+func (g *Gateway) Count(ctx context.Context) (int64, error) {
+	count, err := g.datasource.Count(ctx)
+	if err != nil {
+		return 0, &apperror.InternalError{Msg: err.Error()}
+	}
+	return count, nil
+}
+
+func (g *Gateway) FindByID(ctx context.Context, id string) (entity.Category, error) {
+	found, err := g.datasource.FindByID(ctx, id)
+	if err != nil {
+		return entity.Category{}, passNotFound(err)
+	}
+	return dto.FromCategoryDAO(found), nil
+}
+
+func (g *Gateway) FindByCodes(ctx context.Context, codes []productenum.Category) ([]entity.Category, error) {
+	values := make([]string, 0, len(codes))
+	for _, code := range codes {
+		values = append(values, string(code))
+	}
+	found, err := g.datasource.FindByCodes(ctx, values)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.EntityListFromDAOList(found), nil
+}
+
+func (g *Gateway) Update(ctx context.Context, category entity.Category) (entity.Category, error) {
+	updated, err := g.datasource.Update(ctx, dto.ToCategoryDAO(category))
+	if err != nil {
+		return entity.Category{}, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.FromCategoryDAO(updated), nil
+}
+
+func (g *Gateway) Delete(ctx context.Context, id string) error {
+	if err := g.datasource.Delete(ctx, id); err != nil {
+		return passNotFound(err)
+	}
+	return nil
+}
+
+func passNotFound(err error) error {
+	var notFoundErr *apperror.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return notFoundErr
+	}
+	return &apperror.InternalError{Msg: err.Error()}
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/controller"
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/dto"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/helper"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	controller *controller.Controller
+}
+
+func New(controller *controller.Controller) *Handler {
+	return &Handler{controller: controller}
+}
+
+// Create Category godoc
+// @Summary      Create Category
+// @Description  Create a menu category. Its code is stored in upper case and cannot change afterwards.
+// @Tags         Category Domain
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CategoryRequestDTO true "Category to create"
+// @Success      201  {object}  dto.CategoryResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Failure      409  {object}  errors.ErrorDTO
+// @Router       /admin/category [post]
+func (h *Handler) Create(c *gin.Context) {
+	var request dto.CategoryRequestDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "Invalid request body",
+			MessageError: err.Error(),
+		})
+		return
+	}
+
+	created, err := h.controller.Create(context.Background(), request)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// List Categories godoc
+// @Summary      List Categories
+// @Description  List every category in display order, active or not
+// @Tags         Category Domain
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  dto.CategoryListResponseDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/category [get]
+func (h *Handler) List(c *gin.Context) {
+	categories, err := h.controller.List(context.Background(), false)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+// ListActive List Active Categories godoc
+// @Summary      List Active Categories
+// @Description  List the categories shown on the menu, in display order
+// @Tags         Category Domain
+// @Produce      json
+// @Success      200  {object}  dto.CategoryListResponseDTO
+// @Router       /category [get]
+func (h *Handler) ListActive(c *gin.Context) {
+	categories, err := h.controller.List(context.Background(), true)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+// Update Category godoc
+// @Summary      Update Category
+// @Description  Replace the name, display order, icon and active flag of a category. Deactivating a category hides its products from customers.
+// @Tags         Category Domain
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                  true  "Category ID"
+// @Param        request  body      dto.CategoryRequestDTO  true  "Category data"
+// @Success      200  {object}  dto.CategoryResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/category/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	var request dto.CategoryRequestDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "Invalid request body",
+			MessageError: err.Error(),
+		})
+		return
+	}
+
+	updated, err := h.controller.Update(context.Background(), c.Param("id"), request)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// Delete Category godoc
+// @Summary      Delete Category
+// @Description  Delete a category nothing refers to: no product (archived ones included), combo slot or promotion. Deactivate a category that is still in use instead.
+// @Tags         Category Domain
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Category ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Failure      409  {object}  errors.ErrorDTO
+// @Router       /admin/category/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	if err := h.controller.Delete(context.Background(), c.Param("id")); err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
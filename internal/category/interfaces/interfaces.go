@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+)
+
+// ProductService counts the products of a category, archived ones included
+type ProductService interface {
+	CountByCategory(ctx context.Context, category productenum.Category) (int64, error)
+}
+
+// ComboService counts the combos with a slot offering a category
+type ComboService interface {
+	CountByCategory(ctx context.Context, category productenum.Category) (int64, error)
+}
+
+// PromotionService counts the promotions narrowed to a category
+type PromotionService interface {
+	CountByCategory(ctx context.Context, category productenum.Category) (int64, error)
+}
@@ -0,0 +1,29 @@
+package presenter
+
+import (
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/entity"
+)
+
+type Presenter struct {
+}
+
+func Build() *Presenter {
+	return &Presenter{}
+}
+
+func (p *Presenter) FromEntityToResponseDTO(category entity.Category) dto.CategoryResponseDTO {
+	return dto.ToResponseDTO(category)
+}
+
+func (p *Presenter) FromEntityListToListResponseDTO(categories []entity.Category) dto.CategoryListResponseDTO {
+	list := make([]dto.CategoryResponseDTO, 0, len(categories))
+	for _, category := range categories {
+		list = append(list, p.FromEntityToResponseDTO(category))
+	}
+
+	return dto.CategoryListResponseDTO{
+		Total: uint(len(list)),
+		List:  list,
+	}
+}
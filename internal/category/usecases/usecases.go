@@ -0,0 +1,154 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/gateway"
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/interfaces"
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+)
+
+type UseCases struct {
+	categoryGateway  gateway.Gateway
+	productService   interfaces.ProductService
+	comboService     interfaces.ComboService
+	promotionService interfaces.PromotionService
+}
+
+func Build(
+	categoryGateway gateway.Gateway,
+	productService interfaces.ProductService,
+	comboService interfaces.ComboService,
+	promotionService interfaces.PromotionService,
+) *UseCases {
+	return &UseCases{
+		categoryGateway:  categoryGateway,
+		productService:   productService,
+		comboService:     comboService,
+		promotionService: promotionService,
+	}
+}
+
+func (u *UseCases) Create(ctx context.Context, category entity.Category) (entity.Category, error) {
+	category = category.Build()
+	if err := category.Validate(); err != nil {
+		return entity.Category{}, err
+	}
+
+	existing, err := u.categoryGateway.FindByCodes(ctx, []productenum.Category{category.Code})
+	if err != nil {
+		return entity.Category{}, err
+	}
+	if len(existing) > 0 {
+		return entity.Category{}, &apperror.ConflictError{Msg: fmt.Sprintf("Category %s already exists", category.Code)}
+	}
+
+	if err := u.categoryGateway.Create(ctx, category); err != nil {
+		return entity.Category{}, err
+	}
+	return category, nil
+}
+
+// List returns the categories in display order, only the active ones when activeOnly is set
+func (u *UseCases) List(ctx context.Context, activeOnly bool) ([]entity.Category, error) {
+	return u.categoryGateway.List(ctx, activeOnly)
+}
+
+func (u *UseCases) FindByID(ctx context.Context, id string) (entity.Category, error) {
+	return u.categoryGateway.FindByID(ctx, id)
+}
+
+// Update replaces the category with id. Products refer to the code, so it cannot change.
+func (u *UseCases) Update(ctx context.Context, id string, changes entity.Category) (entity.Category, error) {
+	category, err := u.categoryGateway.FindByID(ctx, id)
+	if err != nil {
+		return entity.Category{}, err
+	}
+	if changes.Code != "" && changes.Code != category.Code {
+		return entity.Category{}, &apperror.ValidationError{Msg: "The code of a category cannot change"}
+	}
+
+	category.Name = changes.Name
+	category.DisplayOrder = changes.DisplayOrder
+	category.Icon = changes.Icon
+	category.Active = changes.Active
+	if err := category.Validate(); err != nil {
+		return entity.Category{}, err
+	}
+
+	return u.categoryGateway.Update(ctx, category)
+}
+
+// Delete removes a category nothing refers to: no product, archived ones included since they
+// can be restored, no combo slot and no promotion. Deactivating hides a category still in use.
+func (u *UseCases) Delete(ctx context.Context, id string) error {
+	category, err := u.categoryGateway.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	count, err := u.productService.CountByCategory(ctx, category.Code)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return &apperror.ConflictError{Msg: fmt.Sprintf("Category %s has %d products, archived ones included, move them first", category.Code, count)}
+	}
+
+	count, err = u.comboService.CountByCategory(ctx, category.Code)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return &apperror.ConflictError{Msg: fmt.Sprintf("Category %s is offered by %d combos, change their slots first", category.Code, count)}
+	}
+
+	count, err = u.promotionService.CountByCategory(ctx, category.Code)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return &apperror.ConflictError{Msg: fmt.Sprintf("Category %s narrows %d promotions, change or delete them first", category.Code, count)}
+	}
+
+	return u.categoryGateway.Delete(ctx, id)
+}
+
+// Check fails with a validation error naming the first of codes that is not a stored category
+func (u *UseCases) Check(ctx context.Context, codes []productenum.Category) error {
+	if len(codes) == 0 {
+		return nil
+	}
+
+	found, err := u.categoryGateway.FindByCodes(ctx, codes)
+	if err != nil {
+		return err
+	}
+	stored := make(map[productenum.Category]bool, len(found))
+	for _, category := range found {
+		stored[category.Code] = true
+	}
+	for _, code := range codes {
+		if !stored[code] {
+			return &apperror.ValidationError{Msg: fmt.Sprintf("Invalid category %s", code)}
+		}
+	}
+	return nil
+}
+
+// SeedDefaults stores the default categories when there are none yet
+func (u *UseCases) SeedDefaults(ctx context.Context) error {
+	count, err := u.categoryGateway.Count(ctx)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	defaults := entity.Defaults()
+	for i := range defaults {
+		defaults[i] = defaults[i].Build()
+	}
+	return u.categoryGateway.Create(ctx, defaults...)
+}
@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/external/datasource"
+	"github.com/fiap-161/tc-golunch-core-service/internal/category/gateway"
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	coreentity "github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryCategoryDataSource holds a single category; the methods Delete does not reach are left
+// to the embedded interface
+type memoryCategoryDataSource struct {
+	datasource.DataSource
+	category dto.CategoryDAO
+	deleted  bool
+}
+
+func (d *memoryCategoryDataSource) FindByID(_ context.Context, id string) (dto.CategoryDAO, error) {
+	if id != d.category.ID || d.deleted {
+		return dto.CategoryDAO{}, &apperror.NotFoundError{Msg: "Category not found"}
+	}
+	return d.category, nil
+}
+
+func (d *memoryCategoryDataSource) Delete(_ context.Context, _ string) error {
+	d.deleted = true
+	return nil
+}
+
+// countByCategory stands for the product, combo and promotion services
+type countByCategory map[productenum.Category]int64
+
+func (c countByCategory) CountByCategory(_ context.Context, category productenum.Category) (int64, error) {
+	return c[category], nil
+}
+
+func TestUseCases_Delete(t *testing.T) {
+	setup := func(products, combos, promotions countByCategory) (*UseCases, *memoryCategoryDataSource) {
+		categoryDataSource := &memoryCategoryDataSource{category: dto.CategoryDAO{
+			Entity: coreentity.Entity{ID: "category-1"},
+			Code:   "DRINK",
+			Name:   "Drinks",
+			Active: true,
+		}}
+		return Build(*gateway.Build(categoryDataSource), products, combos, promotions), categoryDataSource
+	}
+	none := countByCategory{}
+	referenced := countByCategory{"DRINK": 1}
+
+	t.Run("Given a category nothing refers to, when deleting it, then it is removed", func(t *testing.T) {
+		u, categoryDataSource := setup(none, none, none)
+
+		err := u.Delete(context.Background(), "category-1")
+
+		assert.NoError(t, err)
+		assert.True(t, categoryDataSource.deleted)
+	})
+
+	cases := []struct {
+		name                         string
+		products, combos, promotions countByCategory
+	}{
+		{"products, archived ones included,", referenced, none, none},
+		{"combo slots", none, referenced, none},
+		{"promotions", none, none, referenced},
+	}
+	for _, tc := range cases {
+		t.Run("Given "+tc.name+" referring to the category, when deleting it, then it is a conflict", func(t *testing.T) {
+			u, categoryDataSource := setup(tc.products, tc.combos, tc.promotions)
+
+			err := u.Delete(context.Background(), "category-1")
+
+			var conflictErr *apperror.ConflictError
+			assert.True(t, errors.As(err, &conflictErr))
+			assert.False(t, categoryDataSource.deleted)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/combo/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/combo/presenter"
+	"github.com/fiap-161/tc-golunch-core-service/internal/combo/usecases"
+)
+
+type Controller struct {
+	comboUseCase *usecases.UseCases
+}
+
+func Build(comboUseCase *usecases.UseCases) *Controller {
+	return &Controller{
+		comboUseCase: comboUseCase,
+	}
+}
+
+func (c *Controller) Create(ctx context.Context, request dto.ComboRequestDTO) (dto.ComboResponseDTO, error) {
+	presenter := presenter.Build()
+
+	created, err := c.comboUseCase.Create(ctx, dto.FromRequestDTO(request))
+	if err != nil {
+		return dto.ComboResponseDTO{}, err
+	}
+
+	return presenter.FromEntityToResponseDTO(created), nil
+}
+
+func (c *Controller) List(ctx context.Context, activeOnly bool) (dto.ComboListResponseDTO, error) {
+	presenter := presenter.Build()
+
+	combos, err := c.comboUseCase.List(ctx, activeOnly)
+	if err != nil {
+		return dto.ComboListResponseDTO{}, err
+	}
+
+	return presenter.FromEntityListToListResponseDTO(combos), nil
+}
+
+func (c *Controller) FindByID(ctx context.Context, id string) (dto.ComboResponseDTO, error) {
+	presenter := presenter.Build()
+
+	combo, err := c.comboUseCase.FindByID(ctx, id)
+	if err != nil {
+		return dto.ComboResponseDTO{}, err
+	}
+
+	return presenter.FromEntityToResponseDTO(combo), nil
+}
+
+func (c *Controller) Update(ctx context.Context, id string, request dto.ComboRequestDTO) (dto.ComboResponseDTO, error) {
+	presenter := presenter.Build()
+
+	updated, err := c.comboUseCase.Update(ctx, id, dto.FromRequestDTO(request))
+	if err != nil {
+		return dto.ComboResponseDTO{}, err
+	}
+
+	return presenter.FromEntityToResponseDTO(updated), nil
+}
+
+func (c *Controller) Delete(ctx context.Context, id string) error {
+	return c.comboUseCase.Delete(ctx, id)
+}
@@ -0,0 +1,152 @@
+package dto
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/combo/entity"
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	coreentity "github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+)
+
+type ComboRequestDTO struct {
+	Name        string         `json:"name" binding:"required"`
+	Description string         `json:"description"`
+	Price       money.Money    `json:"price"`
+	Active      *bool          `json:"active"`
+	Slots       []ComboSlotDTO `json:"slots"`
+}
+
+// ComboSlotDTO is also the JSON stored in the slots column of combos. Slots sent without an
+// ID get one when the combo is saved.
+type ComboSlotDTO struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Categories []string `json:"categories"`
+	ProductIDs []string `json:"product_ids"`
+}
+
+type ComboResponseDTO struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Price       money.Money    `json:"price"`
+	Active      bool           `json:"active"`
+	Slots       []ComboSlotDTO `json:"slots"`
+}
+
+type ComboListResponseDTO struct {
+	Total uint               `json:"total"`
+	List  []ComboResponseDTO `json:"list"`
+}
+
+type ComboDAO struct {
+	coreentity.Entity
+	Name        string      `json:"name" gorm:"type:varchar(100)"`
+	Description string      `json:"description" gorm:"type:text"`
+	Price       money.Money `json:"price" gorm:"embedded;embeddedPrefix:price_"`
+	Active      bool        `json:"active" gorm:"index"`
+	Slots       string      `json:"slots" gorm:"type:jsonb;not null;default:'[]'"`
+}
+
+func (ComboDAO) TableName() string {
+	return "combos"
+}
+
+func ToComboDAO(combo entity.Combo) ComboDAO {
+	return ComboDAO{
+		Entity:      combo.Entity,
+		Name:        combo.Name,
+		Description: combo.Description,
+		Price:       combo.Price,
+		Active:      combo.Active,
+		Slots:       encodeSlots(combo.Slots),
+	}
+}
+
+func FromComboDAO(dao ComboDAO) entity.Combo {
+	return entity.Combo{
+		Entity:      dao.Entity,
+		Name:        dao.Name,
+		Description: dao.Description,
+		Price:       dao.Price,
+		Active:      dao.Active,
+		Slots:       decodeSlots(dao.Slots),
+	}
+}
+
+func EntityListFromDAOList(daoList []ComboDAO) []entity.Combo {
+	combos := make([]entity.Combo, 0, len(daoList))
+	for _, dao := range daoList {
+		combos = append(combos, FromComboDAO(dao))
+	}
+	return combos
+}
+
+// FromRequestDTO builds the combo of a request. A combo is active unless told otherwise.
+func FromRequestDTO(request ComboRequestDTO) entity.Combo {
+	active := true
+	if request.Active != nil {
+		active = *request.Active
+	}
+
+	return entity.Combo{
+		Name:        request.Name,
+		Description: request.Description,
+		Price:       request.Price,
+		Active:      active,
+		Slots:       FromSlotDTOList(request.Slots),
+	}
+}
+
+func ToSlotDTOList(slots []entity.Slot) []ComboSlotDTO {
+	result := make([]ComboSlotDTO, 0, len(slots))
+	for _, slot := range slots {
+		categories := make([]string, 0, len(slot.Categories))
+		for _, category := range slot.Categories {
+			categories = append(categories, string(category))
+		}
+		productIDs := slot.ProductIDs
+		if productIDs == nil {
+			productIDs = []string{}
+		}
+		result = append(result, ComboSlotDTO{
+			ID:         slot.ID,
+			Name:       slot.Name,
+			Categories: categories,
+			ProductIDs: productIDs,
+		})
+	}
+	return result
+}
+
+func FromSlotDTOList(slots []ComboSlotDTO) []entity.Slot {
+	result := make([]entity.Slot, 0, len(slots))
+	for _, slot := range slots {
+		var categories []productenum.Category
+		for _, category := range slot.Categories {
+			categories = append(categories, productenum.Category(strings.ToUpper(category)))
+		}
+		result = append(result, entity.Slot{
+			ID:         slot.ID,
+			Name:       slot.Name,
+			Categories: categories,
+			ProductIDs: slot.ProductIDs,
+		})
+	}
+	return result
+}
+
+func encodeSlots(slots []entity.Slot) string {
+	raw, _ := json.Marshal(ToSlotDTOList(slots))
+	return string(raw)
+}
+
+func decodeSlots(raw string) []entity.Slot {
+	var slots []ComboSlotDTO
+	if json.Unmarshal([]byte(raw), &slots) != nil {
+		return nil
+	}
+	return FromSlotDTOList(slots)
+}
@@ -0,0 +1,221 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	productentity "github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	coreentity "github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+	"github.com/google/uuid"
+)
+
+// Combo sells one product per slot at a bundle price, such as burger + fries + drink
+type Combo struct {
+	coreentity.Entity
+	Name        string
+	Description string
+	Price       money.Money
+	Active      bool
+	Slots       []Slot
+}
+
+// Slot is filled by any product of its categories or by one of its products
+type Slot struct {
+	ID         string
+	Name       string
+	Categories []productenum.Category
+	ProductIDs []string
+}
+
+// Selection is the product a customer picked for a slot of a combo
+type Selection struct {
+	SlotID    string
+	ProductID string
+}
+
+func (c Combo) Build() Combo {
+	now := time.Now()
+	c.Entity = coreentity.Entity{
+		ID:        uuid.NewString(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	c.Slots = WithSlotIDs(c.Slots)
+	return c
+}
+
+// WithSlotIDs gives an ID to the slots created without one
+func WithSlotIDs(slots []Slot) []Slot {
+	if slots == nil {
+		return nil
+	}
+	result := make([]Slot, 0, len(slots))
+	for _, slot := range slots {
+		if slot.ID == "" {
+			slot.ID = uuid.NewString()
+		}
+		result = append(result, slot)
+	}
+	return result
+}
+
+func (c Combo) Validate() error {
+	if c.Name == "" {
+		return &apperror.ValidationError{Msg: "Name is required"}
+	}
+	if !c.Price.IsPositive() {
+		return &apperror.ValidationError{Msg: "Price must be positive"}
+	}
+	if len(c.Slots) == 0 {
+		return &apperror.ValidationError{Msg: "A combo needs at least one slot"}
+	}
+	for _, slot := range c.Slots {
+		if err := slot.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s Slot) Validate() error {
+	if s.Name == "" {
+		return &apperror.ValidationError{Msg: "Slot name is required"}
+	}
+	if len(s.Categories) == 0 && len(s.ProductIDs) == 0 {
+		return &apperror.ValidationError{Msg: fmt.Sprintf("Slot %s needs a category or a product", s.Name)}
+	}
+	return nil
+}
+
+func (s Slot) Accepts(product productentity.Product) bool {
+	return slices.Contains(s.ProductIDs, product.Id) || slices.Contains(s.Categories, product.Category)
+}
+
+// Categories lists the categories referenced by the slots of the combo
+func (c Combo) Categories() []productenum.Category {
+	var categories []productenum.Category
+	for _, slot := range c.Slots {
+		for _, category := range slot.Categories {
+			if !slices.Contains(categories, category) {
+				categories = append(categories, category)
+			}
+		}
+	}
+	return categories
+}
+
+// ProductIDs lists the products referenced directly by the slots of the combo
+func (c Combo) ProductIDs() []string {
+	var ids []string
+	for _, slot := range c.Slots {
+		for _, id := range slot.ProductIDs {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// Fill checks that every slot gets exactly one selection with a product it accepts, and returns
+// the selected products in slot order
+func (c Combo) Fill(selections []Selection, products []productentity.Product) ([]productentity.Product, error) {
+	if !c.Active {
+		return nil, &apperror.ValidationError{Msg: fmt.Sprintf("Combo %s is not available", c.Name)}
+	}
+
+	productsByID := make(map[string]productentity.Product, len(products))
+	for _, product := range products {
+		productsByID[product.Id] = product
+	}
+
+	bySlot := make(map[string]Selection, len(selections))
+	for _, selection := range selections {
+		if _, ok := bySlot[selection.SlotID]; ok {
+			return nil, &apperror.ValidationError{Msg: fmt.Sprintf("Combo %s has more than one selection for slot %s", c.Name, selection.SlotID)}
+		}
+		bySlot[selection.SlotID] = selection
+	}
+
+	filled := make([]productentity.Product, 0, len(c.Slots))
+	for _, slot := range c.Slots {
+		selection, ok := bySlot[slot.ID]
+		if !ok {
+			return nil, &apperror.ValidationError{Msg: fmt.Sprintf("Combo %s is missing a selection for %s", c.Name, slot.Name)}
+		}
+		delete(bySlot, slot.ID)
+
+		product, ok := productsByID[selection.ProductID]
+		if !ok {
+			return nil, &apperror.NotFoundError{Msg: fmt.Sprintf("Product %s not found", selection.ProductID)}
+		}
+		if !slot.Accepts(product) {
+			return nil, &apperror.ValidationError{Msg: fmt.Sprintf("%s cannot fill %s of combo %s", product.Name, slot.Name, c.Name)}
+		}
+		filled = append(filled, product)
+	}
+
+	for slotID := range bySlot {
+		return nil, &apperror.ValidationError{Msg: fmt.Sprintf("Combo %s has no slot %s", c.Name, slotID)}
+	}
+	return filled, nil
+}
+
+// Allocate splits the combo price across the products filling its slots in proportion to their
+// list prices, so revenue can still be attributed per product. Cents left over by the division go
+// to the largest remainders, and the shares always add up to the combo price exactly.
+func (c Combo) Allocate(filled []productentity.Product) ([]money.Money, error) {
+	if len(filled) == 0 {
+		return nil, nil
+	}
+
+	weights := make([]int64, len(filled))
+	var totalWeight int64
+	for i, product := range filled {
+		if product.Price.Currency != "" && product.Price.Currency != c.Price.Currency {
+			return nil, &apperror.ValidationError{Msg: fmt.Sprintf("%s is not priced in %s like combo %s", product.Name, c.Price.Currency, c.Name)}
+		}
+		weights[i] = max(product.Price.Amount, 0)
+		totalWeight += weights[i]
+	}
+	// Free products alone share the price equally
+	if totalWeight == 0 {
+		for i := range weights {
+			weights[i] = 1
+		}
+		totalWeight = int64(len(weights))
+	}
+
+	shares := make([]money.Money, len(filled))
+	remainders := make([]int64, len(filled))
+	allocated := int64(0)
+	for i, weight := range weights {
+		amount := c.Price.Amount * weight / totalWeight
+		remainders[i] = c.Price.Amount * weight % totalWeight
+		shares[i] = money.New(amount, c.Price.Currency)
+		allocated += amount
+	}
+
+	order := make([]int, len(filled))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		switch {
+		case remainders[a] > remainders[b]:
+			return -1
+		case remainders[a] < remainders[b]:
+			return 1
+		}
+		return 0
+	})
+	for _, i := range order[:c.Price.Amount-allocated] {
+		shares[i].Amount++
+	}
+
+	return shares, nil
+}
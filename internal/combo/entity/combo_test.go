@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"testing"
+
+	productentity "github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func lunchCombo() Combo {
+	return Combo{
+		Name:   "Lunch",
+		Price:  money.FromCents(3000),
+		Active: true,
+		Slots: []Slot{
+			{ID: "main", Name: "Burger", ProductIDs: []string{"burger"}},
+			{ID: "side", Name: "Side", Categories: []productenum.Category{productenum.Side}},
+			{ID: "drink", Name: "Drink", Categories: []productenum.Category{productenum.Drink}},
+		},
+	}
+}
+
+var comboProducts = []productentity.Product{
+	{Id: "burger", Name: "X-Burger", Category: productenum.Meal, Price: money.FromCents(2500)},
+	{Id: "fries", Name: "Fries", Category: productenum.Side, Price: money.FromCents(1000)},
+	{Id: "soda", Name: "Soda", Category: productenum.Drink, Price: money.FromCents(700)},
+	{Id: "pie", Name: "Pie", Category: productenum.Dessert, Price: money.FromCents(900)},
+}
+
+func TestComboFill(t *testing.T) {
+	combo := lunchCombo()
+
+	t.Run("Given a product for every slot, when the combo is filled, then the products come in slot order", func(t *testing.T) {
+		filled, err := combo.Fill([]Selection{
+			{SlotID: "drink", ProductID: "soda"},
+			{SlotID: "main", ProductID: "burger"},
+			{SlotID: "side", ProductID: "fries"},
+		}, comboProducts)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"burger", "fries", "soda"}, []string{filled[0].Id, filled[1].Id, filled[2].Id})
+	})
+
+	tests := []struct {
+		name       string
+		selections []Selection
+	}{
+		{name: "Given a missing slot, when the combo is filled, then it is rejected", selections: []Selection{{SlotID: "main", ProductID: "burger"}, {SlotID: "side", ProductID: "fries"}}},
+		{name: "Given a product of another category, when the combo is filled, then it is rejected", selections: []Selection{{SlotID: "main", ProductID: "burger"}, {SlotID: "side", ProductID: "pie"}, {SlotID: "drink", ProductID: "soda"}}},
+		{name: "Given an unknown slot, when the combo is filled, then it is rejected", selections: []Selection{{SlotID: "main", ProductID: "burger"}, {SlotID: "side", ProductID: "fries"}, {SlotID: "drink", ProductID: "soda"}, {SlotID: "dessert", ProductID: "pie"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := combo.Fill(tt.selections, comboProducts)
+
+			var validationErr *apperror.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+		})
+	}
+
+	t.Run("Given an inactive combo, when it is filled, then it is rejected", func(t *testing.T) {
+		inactive := lunchCombo()
+		inactive.Active = false
+
+		_, err := inactive.Fill(nil, comboProducts)
+
+		var validationErr *apperror.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
+
+func TestComboAllocate(t *testing.T) {
+	t.Run("Given products with list prices, when the price is allocated, then shares follow the list prices and add up to the combo price", func(t *testing.T) {
+		shares, err := lunchCombo().Allocate(comboProducts[:3])
+
+		assert.NoError(t, err)
+		// 3000 * 2500/4200 = 1785.71, 3000 * 1000/4200 = 714.28, 3000 * 700/4200 = 500
+		assert.Equal(t, []money.Money{money.FromCents(1786), money.FromCents(714), money.FromCents(500)}, shares)
+	})
+
+	t.Run("Given free products, when the price is allocated, then it is split equally to the cent", func(t *testing.T) {
+		combo := lunchCombo()
+		combo.Price = money.FromCents(1000)
+		free := []productentity.Product{{Id: "a"}, {Id: "b"}, {Id: "c"}}
+
+		shares, err := combo.Allocate(free)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []money.Money{money.FromCents(334), money.FromCents(333), money.FromCents(333)}, shares)
+	})
+}
@@ -0,0 +1,17 @@
+package datasource
+
+import (
+	"context"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/combo/dto"
+)
+
+type DataSource interface {
+	Create(ctx context.Context, combo dto.ComboDAO) (dto.ComboDAO, error)
+	List(ctx context.Context, activeOnly bool) ([]dto.ComboDAO, error)
+	FindByID(ctx context.Context, id string) (dto.ComboDAO, error)
+	FindByIDs(ctx context.Context, ids []string) ([]dto.ComboDAO, error)
+	Update(ctx context.Context, combo dto.ComboDAO) (dto.ComboDAO, error)
+	Delete(ctx context.Context, id string) error
+	CountByCategory(ctx context.Context, category string) (int64, error)
+}
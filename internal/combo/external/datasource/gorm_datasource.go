@@ -0,0 +1,108 @@
+package datasource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/combo/dto"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"gorm.io/gorm"
+)
+
+// DB interface defines the database operations needed
+type DB interface {
+	Create(value any) *gorm.DB
+	Where(query any, args ...any) *gorm.DB
+	First(dest any, conds ...any) *gorm.DB
+	Find(dest any, conds ...any) *gorm.DB
+	Save(value any) *gorm.DB
+	Delete(value any, conds ...any) *gorm.DB
+	Order(value any) *gorm.DB
+	Model(value any) *gorm.DB
+}
+
+type GormDataSource struct {
+	db DB
+}
+
+func New(db DB) DataSource {
+	return &GormDataSource{
+		db: db,
+	}
+}
+
+func (g *GormDataSource) Create(_ context.Context, combo dto.ComboDAO) (dto.ComboDAO, error) {
+	if err := g.db.Create(&combo).Error; err != nil {
+		return dto.ComboDAO{}, err
+	}
+	return combo, nil
+}
+
+func (g *GormDataSource) List(_ context.Context, activeOnly bool) ([]dto.ComboDAO, error) {
+	var combos []dto.ComboDAO
+
+	query := g.db.Order("name")
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	if err := query.Find(&combos).Error; err != nil {
+		return nil, err
+	}
+
+	return combos, nil
+}
+
+func (g *GormDataSource) FindByID(_ context.Context, id string) (dto.ComboDAO, error) {
+	var combo dto.ComboDAO
+
+	if err := g.db.First(&combo, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.ComboDAO{}, &apperror.NotFoundError{Msg: "Combo not found"}
+		}
+		return dto.ComboDAO{}, err
+	}
+
+	return combo, nil
+}
+
+func (g *GormDataSource) FindByIDs(_ context.Context, ids []string) ([]dto.ComboDAO, error) {
+	var combos []dto.ComboDAO
+
+	if err := g.db.Where("id IN ?", ids).Find(&combos).Error; err != nil {
+		return nil, err
+	}
+
+	return combos, nil
+}
+
+func (g *GormDataSource) Update(_ context.Context, combo dto.ComboDAO) (dto.ComboDAO, error) {
+	if err := g.db.Save(&combo).Error; err != nil {
+		return dto.ComboDAO{}, err
+	}
+	return combo, nil
+}
+
+func (g *GormDataSource) Delete(_ context.Context, id string) error {
+	tx := g.db.Delete(&dto.ComboDAO{}, "id = ?", id)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return &apperror.NotFoundError{Msg: "Combo not found"}
+	}
+	return nil
+}
+
+// CountByCategory counts the combos with a slot offering category
+func (g *GormDataSource) CountByCategory(_ context.Context, category string) (int64, error) {
+	// Only the categories key, so that any slot listing category contains it
+	slots, err := json.Marshal([]map[string][]string{{"categories": {category}}})
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err = g.db.Model(&dto.ComboDAO{}).Where("slots @> ?::jsonb", string(slots)).Count(&count).Error
+	return count, err
+}
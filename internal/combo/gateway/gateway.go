@@ -0,0 +1,85 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/combo/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/combo/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/combo/external/datasource"
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+)
+
+type Gateway struct {
+	datasource datasource.DataSource
+}
+
+func Build(datasource datasource.DataSource) *Gateway {
+	return &Gateway{
+		datasource: datasource,
+	}
+}
+
+func (g *Gateway) Create(ctx context.Context, combo entity.Combo) (entity.Combo, error) {
+	created, err := g.datasource.Create(ctx, dto.ToComboDAO(combo))
+	if err != nil {
+		return entity.Combo{}, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.FromComboDAO(created), nil
+}
+
+func (g *Gateway) List(ctx context.Context, activeOnly bool) ([]entity.Combo, error) {
+	found, err := g.datasource.List(ctx, activeOnly)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.EntityListFromDAOList(found), nil
+}
+
+func (g *Gateway) FindByID(ctx context.Context, id string) (entity.Combo, error) {
+	found, err := g.datasource.FindByID(ctx, id)
+	if err != nil {
+		var notFoundErr *apperror.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return entity.Combo{}, notFoundErr
+		}
+		return entity.Combo{}, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.FromComboDAO(found), nil
+}
+
+func (g *Gateway) FindByIDs(ctx context.Context, ids []string) ([]entity.Combo, error) {
+	found, err := g.datasource.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.EntityListFromDAOList(found), nil
+}
+
+func (g *Gateway) Update(ctx context.Context, combo entity.Combo) (entity.Combo, error) {
+	updated, err := g.datasource.Update(ctx, dto.ToComboDAO(combo))
+	if err != nil {
+		return entity.Combo{}, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.FromComboDAO(updated), nil
+}
+
+func (g *Gateway) Delete(ctx context.Context, id string) error {
+	if err := g.datasource.Delete(ctx, id); err != nil {
+		var notFoundErr *apperror.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return notFoundErr
+		}
+		return &apperror.InternalError{Msg: err.Error()}
+	}
+	return nil
+}
+
+func (g *Gateway) CountByCategory(ctx context.Context, category productenum.Category) (int64, error) {
+	count, err := g.datasource.CountByCategory(ctx, string(category))
+	if err != nil {
+		return 0, &apperror.InternalError{Msg: err.Error()}
+	}
+	return count, nil
+}
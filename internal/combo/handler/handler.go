@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/combo/controller"
+	"github.com/fiap-161/tc-golunch-core-service/internal/combo/dto"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/helper"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	controller *controller.Controller
+}
+
+func New(controller *controller.Controller) *Handler {
+	return &Handler{controller: controller}
+}
+
+// Create Combo godoc
+// @Summary      Create Combo
+// @Description  Create a combo sold at a bundle price. Each slot is filled by a product of one of its categories or by one of its products.
+// @Tags         Combo Domain
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ComboRequestDTO true "Combo to create"
+// @Success      201  {object}  dto.ComboResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/combo [post]
+func (h *Handler) Create(c *gin.Context) {
+	var request dto.ComboRequestDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "Invalid request body",
+			MessageError: err.Error(),
+		})
+		return
+	}
+
+	created, err := h.controller.Create(context.Background(), request)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// List Combos godoc
+// @Summary      List Combos
+// @Description  List every combo, active or not
+// @Tags         Combo Domain
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  dto.ComboListResponseDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/combo [get]
+func (h *Handler) List(c *gin.Context) {
+	combos, err := h.controller.List(context.Background(), false)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, combos)
+}
+
+// ListActive List Active Combos godoc
+// @Summary      List Active Combos
+// @Description  List the combos customers can order
+// @Tags         Combo Domain
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  dto.ComboListResponseDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /combo [get]
+func (h *Handler) ListActive(c *gin.Context) {
+	combos, err := h.controller.List(context.Background(), true)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, combos)
+}
+
+// GetByID Get Combo godoc
+// @Summary      Get Combo
+// @Description  Get a combo by ID
+// @Tags         Combo Domain
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "Combo ID"
+// @Success      200  {object}  dto.ComboResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/combo/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	combo, err := h.controller.FindByID(context.Background(), c.Param("id"))
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, combo)
+}
+
+// Update Combo godoc
+// @Summary      Update Combo
+// @Description  Replace a combo. Send the slot IDs to keep them; slots without ID are created.
+// @Tags         Combo Domain
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string               true  "Combo ID"
+// @Param        request  body      dto.ComboRequestDTO  true  "Combo data"
+// @Success      200  {object}  dto.ComboResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/combo/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	var request dto.ComboRequestDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "Invalid request body",
+			MessageError: err.Error(),
+		})
+		return
+	}
+
+	updated, err := h.controller.Update(context.Background(), c.Param("id"), request)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// Delete Combo godoc
+// @Summary      Delete Combo
+// @Description  Delete a combo. Orders already placed keep their lines.
+// @Tags         Combo Domain
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Combo ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/combo/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	if err := h.controller.Delete(context.Background(), c.Param("id")); err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
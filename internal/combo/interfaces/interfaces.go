@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	productentity "github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+)
+
+type ProductService interface {
+	FindByIDs(ctx context.Context, productIDs []string) ([]productentity.Product, error)
+}
+
+type CategoryService interface {
+	Check(ctx context.Context, codes []productenum.Category) error
+}
@@ -0,0 +1,36 @@
+package presenter
+
+import (
+	"github.com/fiap-161/tc-golunch-core-service/internal/combo/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/combo/entity"
+)
+
+type Presenter struct {
+}
+
+func Build() *Presenter {
+	return &Presenter{}
+}
+
+func (p *Presenter) FromEntityToResponseDTO(combo entity.Combo) dto.ComboResponseDTO {
+	return dto.ComboResponseDTO{
+		ID:          combo.ID,
+		Name:        combo.Name,
+		Description: combo.Description,
+		Price:       combo.Price,
+		Active:      combo.Active,
+		Slots:       dto.ToSlotDTOList(combo.Slots),
+	}
+}
+
+func (p *Presenter) FromEntityListToListResponseDTO(combos []entity.Combo) dto.ComboListResponseDTO {
+	list := make([]dto.ComboResponseDTO, 0, len(combos))
+	for _, combo := range combos {
+		list = append(list, p.FromEntityToResponseDTO(combo))
+	}
+
+	return dto.ComboListResponseDTO{
+		Total: uint(len(list)),
+		List:  list,
+	}
+}
@@ -0,0 +1,109 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/combo/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/combo/gateway"
+	"github.com/fiap-161/tc-golunch-core-service/internal/combo/interfaces"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/google/uuid"
+)
+
+type UseCases struct {
+	comboGateway    gateway.Gateway
+	productService  interfaces.ProductService
+	categoryService interfaces.CategoryService
+}
+
+func Build(comboGateway gateway.Gateway, productService interfaces.ProductService, categoryService interfaces.CategoryService) *UseCases {
+	return &UseCases{
+		comboGateway:    comboGateway,
+		productService:  productService,
+		categoryService: categoryService,
+	}
+}
+
+func (u *UseCases) Create(ctx context.Context, combo entity.Combo) (entity.Combo, error) {
+	combo = combo.Build()
+	if err := u.validate(ctx, combo); err != nil {
+		return entity.Combo{}, err
+	}
+
+	return u.comboGateway.Create(ctx, combo)
+}
+
+// List returns every combo for the admin, or only those customers can order
+func (u *UseCases) List(ctx context.Context, activeOnly bool) ([]entity.Combo, error) {
+	return u.comboGateway.List(ctx, activeOnly)
+}
+
+func (u *UseCases) FindByID(ctx context.Context, id string) (entity.Combo, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return entity.Combo{}, &apperror.ValidationError{Msg: "Invalid UUID format for combo ID"}
+	}
+
+	return u.comboGateway.FindByID(ctx, id)
+}
+
+// FindByIDs returns the combos found among ids, missing ones are left for the caller to report
+func (u *UseCases) FindByIDs(ctx context.Context, ids []string) ([]entity.Combo, error) {
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, &apperror.ValidationError{Msg: fmt.Sprintf("Invalid UUID format for combo ID: %s", id)}
+		}
+	}
+
+	return u.comboGateway.FindByIDs(ctx, ids)
+}
+
+// Update replaces the combo. Slots keep their IDs when sent with them, so orders in progress
+// can still refer to them.
+func (u *UseCases) Update(ctx context.Context, id string, combo entity.Combo) (entity.Combo, error) {
+	existing, err := u.FindByID(ctx, id)
+	if err != nil {
+		return entity.Combo{}, err
+	}
+
+	combo.Entity = existing.Entity
+	combo.UpdatedAt = time.Now()
+	combo.Slots = entity.WithSlotIDs(combo.Slots)
+	if err := u.validate(ctx, combo); err != nil {
+		return entity.Combo{}, err
+	}
+
+	return u.comboGateway.Update(ctx, combo)
+}
+
+func (u *UseCases) Delete(ctx context.Context, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return &apperror.ValidationError{Msg: "Invalid UUID format for combo ID"}
+	}
+
+	return u.comboGateway.Delete(ctx, id)
+}
+
+// validate checks the combo itself and that the categories and products its slots refer to exist
+func (u *UseCases) validate(ctx context.Context, combo entity.Combo) error {
+	if err := combo.Validate(); err != nil {
+		return err
+	}
+	if err := u.categoryService.Check(ctx, combo.Categories()); err != nil {
+		return err
+	}
+
+	productIDs := combo.ProductIDs()
+	if len(productIDs) == 0 {
+		return nil
+	}
+	products, err := u.productService.FindByIDs(ctx, productIDs)
+	if err != nil {
+		return err
+	}
+	if len(products) != len(productIDs) {
+		return &apperror.NotFoundError{Msg: "some products of the combo slots not found"}
+	}
+	return nil
+}
@@ -1,8 +1,10 @@
 package gateway
 
 import (
+	"errors"
 	"time"
 
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
 	"github.com/golang-jwt/jwt/v5"
 )
 
@@ -41,3 +43,46 @@ func (a *AuthGatewayImpl) GenerateToken(userID string, userType string, addition
 
 	return tokenString, nil
 }
+
+func (a *AuthGatewayImpl) ValidateToken(tokenString string) (*entity.CustomClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(a.secretKey), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	customClaims := &entity.CustomClaims{Custom: map[string]any{}}
+	for key, value := range claims {
+		switch key {
+		case "user_id":
+			customClaims.UserID, _ = value.(string)
+		case "user_type":
+			customClaims.UserType, _ = value.(string)
+		case "exp":
+			if exp, ok := value.(float64); ok {
+				customClaims.ExpiresAt = int64(exp)
+			}
+		case "iat":
+			if iat, ok := value.(float64); ok {
+				customClaims.IssuedAt = int64(iat)
+			}
+		case "nbf":
+			if nbf, ok := value.(float64); ok {
+				customClaims.NotBefore = int64(nbf)
+			}
+		default:
+			customClaims.Custom[key] = value
+		}
+	}
+
+	return customClaims, nil
+}
@@ -52,35 +52,4 @@ func AdminAuthMiddleware() gin.HandlerFunc {
 		
 		c.Next()
 	}
-}
-	}
-
-	req.Header.Set("Authorization", "Bearer "+token)
-
-	resp, err := client.Do(req)
-	if err != nil {
-		fmt.Printf("Error calling operation service: %v\n", err)
-		return false
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return false
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		fmt.Printf("Error reading response: %v\n", err)
-		return false
-	}
-
-	var response map[string]interface{}
-	if err := json.Unmarshal(body, &response); err != nil {
-		fmt.Printf("Error parsing response: %v\n", err)
-		return false
-	}
-
-	// Check if the response indicates valid admin
-	valid, exists := response["valid"].(bool)
-	return exists && valid
 }
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+
+	idempotencyentity "github.com/fiap-161/tc-golunch-core-service/internal/idempotency/entity"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/helper"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	anonymousIdempotencyScope = "anonymous"
+)
+
+type IdempotencyStore interface {
+	Begin(ctx context.Context, scope, key, requestHash string) (idempotencyentity.Record, bool, error)
+	Complete(ctx context.Context, record idempotencyentity.Record, status int, body []byte) error
+	Release(ctx context.Context, record idempotencyentity.Record) error
+}
+
+// IdempotencyMiddleware makes retries of a request carrying an Idempotency-Key header safe.
+// The first request runs and its response is stored; retries with the same key and body get
+// that response back without running the handler again. Reusing a key with another body is
+// rejected, as is a retry arriving while the first request is still running. A request that
+// fails with a server error or panics frees its key so it can be retried. Requests without the
+// header are not affected.
+func IdempotencyMiddleware(store IdempotencyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, apperror.ErrorDTO{
+				Message:      "invalid idempotency key",
+				MessageError: "idempotency key must be at most 255 characters",
+			})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, apperror.ErrorDTO{
+				Message:      "invalid request body",
+				MessageError: err.Error(),
+			})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		requestHash := idempotencyentity.HashRequest(c.Request.Method, c.Request.URL.Path, body)
+		record, claimed, err := store.Begin(ctx, idempotencyScope(c), key, requestHash)
+		if err != nil {
+			helper.HandleError(c, err)
+			c.Abort()
+			return
+		}
+
+		if !claimed {
+			switch {
+			case !record.Matches(requestHash):
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, apperror.ErrorDTO{
+					Message:      "idempotency key reused",
+					MessageError: "this idempotency key was already used with a different request",
+				})
+			case !record.IsCompleted():
+				c.AbortWithStatusJSON(http.StatusConflict, apperror.ErrorDTO{
+					Message:      "request in progress",
+					MessageError: "a request with this idempotency key is still being processed",
+				})
+			default:
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(record.ResponseStatus, "application/json; charset=utf-8", record.ResponseBody)
+				c.Abort()
+			}
+			return
+		}
+
+		// The outcome is saved even when the client went away while the handler ran
+		storeCtx := context.WithoutCancel(ctx)
+		release := func() {
+			if err := store.Release(storeCtx, record); err != nil {
+				log.Printf("Failed to release idempotency key %s: %v", key, err)
+			}
+		}
+		// A panic unwinds past this middleware to the recovery above it, so the key is freed here
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				release()
+				panic(recovered)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			release()
+			return
+		}
+		if err := store.Complete(storeCtx, record, recorder.Status(), recorder.body.Bytes()); err != nil {
+			log.Printf("Failed to store response for idempotency key %s: %v", key, err)
+		}
+	}
+}
+
+// idempotencyScope keeps keys of different endpoints and callers apart
+func idempotencyScope(c *gin.Context) string {
+	caller := anonymousIdempotencyScope
+	for _, contextKey := range []string{"authenticated_service", "admin_id", "user_id"} {
+		if value := c.GetString(contextKey); value != "" {
+			caller = value
+			break
+		}
+	}
+	return c.Request.Method + " " + c.FullPath() + " " + caller
+}
+
+// responseRecorder keeps a copy of the response body written by the handler
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	idempotencyentity "github.com/fiap-161/tc-golunch-core-service/internal/idempotency/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryIdempotencyStore mirrors the persisted store closely enough for the middleware
+type memoryIdempotencyStore struct {
+	records map[string]idempotencyentity.Record
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: map[string]idempotencyentity.Record{}}
+}
+
+func (s *memoryIdempotencyStore) Begin(_ context.Context, scope, key, requestHash string) (idempotencyentity.Record, bool, error) {
+	if existing, ok := s.records[scope+key]; ok {
+		return existing, false, nil
+	}
+	record := idempotencyentity.NewRecord(scope, key, requestHash, time.Now(), time.Hour, time.Minute)
+	s.records[scope+key] = record
+	return record, true, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, record idempotencyentity.Record, status int, body []byte) error {
+	s.records[record.Scope+record.Key] = record.Complete(status, body)
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, record idempotencyentity.Record) error {
+	delete(s.records, record.Scope+record.Key)
+	return nil
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := func(status int) (*gin.Engine, *int) {
+		calls := 0
+		router := gin.New()
+		router.POST("/order", IdempotencyMiddleware(newMemoryIdempotencyStore()), func(c *gin.Context) {
+			calls++
+			c.JSON(status, gin.H{"call": calls})
+		})
+		return router, &calls
+	}
+	send := func(router *gin.Engine, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/order", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("Given no idempotency key, when the request is repeated, then the handler runs every time", func(t *testing.T) {
+		router, calls := setup(http.StatusOK)
+
+		send(router, "", `{"a":1}`)
+		send(router, "", `{"a":1}`)
+
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("Given a completed request, when it is retried with the same key, then the stored response is replayed", func(t *testing.T) {
+		router, calls := setup(http.StatusOK)
+
+		first := send(router, "key-1", `{"a":1}`)
+		retry := send(router, "key-1", `{"a":1}`)
+
+		assert.Equal(t, 1, *calls)
+		assert.Equal(t, http.StatusOK, retry.Code)
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+		assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+	})
+
+	t.Run("Given a used key, when it is sent with another body, then the request is rejected", func(t *testing.T) {
+		router, calls := setup(http.StatusOK)
+
+		send(router, "key-1", `{"a":1}`)
+		resp := send(router, "key-1", `{"a":2}`)
+
+		assert.Equal(t, 1, *calls)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	})
+
+	t.Run("Given a request that failed on the server, when it is retried, then the handler runs again", func(t *testing.T) {
+		router, calls := setup(http.StatusInternalServerError)
+
+		send(router, "key-1", `{"a":1}`)
+		send(router, "key-1", `{"a":1}`)
+
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("Given a request whose handler panicked, when it is retried, then the handler runs again", func(t *testing.T) {
+		calls := 0
+		router := gin.New()
+		router.Use(gin.Recovery())
+		router.POST("/order", IdempotencyMiddleware(newMemoryIdempotencyStore()), func(c *gin.Context) {
+			calls++
+			if calls == 1 {
+				panic("handler failed")
+			}
+			c.JSON(http.StatusOK, gin.H{"call": calls})
+		})
+
+		first := send(router, "key-1", `{"a":1}`)
+		retry := send(router, "key-1", `{"a":1}`)
+
+		assert.Equal(t, http.StatusInternalServerError, first.Code)
+		assert.Equal(t, http.StatusOK, retry.Code)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Given a request still running, when it is retried, then the retry is told to wait", func(t *testing.T) {
+		store := newMemoryIdempotencyStore()
+		scope := "POST /order " + anonymousIdempotencyScope
+		_, _, _ = store.Begin(context.Background(), scope, "key-1", idempotencyentity.HashRequest(http.MethodPost, "/order", []byte(`{"a":1}`)))
+
+		router := gin.New()
+		router.POST("/order", IdempotencyMiddleware(store), func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{})
+		})
+
+		resp := send(router, "key-1", `{"a":1}`)
+
+		assert.Equal(t, http.StatusConflict, resp.Code)
+	})
+}
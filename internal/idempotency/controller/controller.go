@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/usecases"
+)
+
+type Controller struct {
+	idempotencyUseCase *usecases.UseCases
+}
+
+func Build(idempotencyUseCase *usecases.UseCases) *Controller {
+	return &Controller{
+		idempotencyUseCase: idempotencyUseCase,
+	}
+}
+
+func (c *Controller) Begin(ctx context.Context, scope, key, requestHash string) (entity.Record, bool, error) {
+	return c.idempotencyUseCase.Begin(ctx, scope, key, requestHash)
+}
+
+func (c *Controller) Complete(ctx context.Context, record entity.Record, status int, body []byte) error {
+	return c.idempotencyUseCase.Complete(ctx, record, status, body)
+}
+
+func (c *Controller) Release(ctx context.Context, record entity.Record) error {
+	return c.idempotencyUseCase.Release(ctx, record)
+}
+
+func (c *Controller) PurgeExpired(ctx context.Context) (int64, error) {
+	return c.idempotencyUseCase.PurgeExpired(ctx)
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/entity/enum"
+)
+
+type IdempotencyKeyDAO struct {
+	Scope          string            `gorm:"type:varchar(200);primaryKey"`
+	Key            string            `gorm:"type:varchar(255);primaryKey"`
+	RequestHash    string            `gorm:"type:varchar(64)"`
+	Status         enum.RecordStatus `gorm:"type:varchar(20)"`
+	ResponseStatus int
+	ResponseBody   []byte `gorm:"type:bytea"`
+	CreatedAt      time.Time
+	LockedUntil    time.Time
+	ExpiresAt      time.Time `gorm:"index"`
+}
+
+func (IdempotencyKeyDAO) TableName() string {
+	return "idempotency_keys"
+}
+
+func ToIdempotencyKeyDAO(r entity.Record) IdempotencyKeyDAO {
+	return IdempotencyKeyDAO{
+		Scope:          r.Scope,
+		Key:            r.Key,
+		RequestHash:    r.RequestHash,
+		Status:         r.Status,
+		ResponseStatus: r.ResponseStatus,
+		ResponseBody:   r.ResponseBody,
+		CreatedAt:      r.CreatedAt,
+		LockedUntil:    r.LockedUntil,
+		ExpiresAt:      r.ExpiresAt,
+	}
+}
+
+func FromIdempotencyKeyDAO(dao IdempotencyKeyDAO) entity.Record {
+	return entity.Record{
+		Scope:          dao.Scope,
+		Key:            dao.Key,
+		RequestHash:    dao.RequestHash,
+		Status:         dao.Status,
+		ResponseStatus: dao.ResponseStatus,
+		ResponseBody:   dao.ResponseBody,
+		CreatedAt:      dao.CreatedAt,
+		LockedUntil:    dao.LockedUntil,
+		ExpiresAt:      dao.ExpiresAt,
+	}
+}
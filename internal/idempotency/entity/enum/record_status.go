@@ -0,0 +1,12 @@
+package enum
+
+type RecordStatus string
+
+const (
+	RecordStatusInProgress RecordStatus = "in_progress"
+	RecordStatusCompleted  RecordStatus = "completed"
+)
+
+func (r RecordStatus) String() string {
+	return string(r)
+}
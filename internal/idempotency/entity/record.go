@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/entity/enum"
+)
+
+// Record remembers the first request made with an idempotency key and, once it finished,
+// the response to replay for every retry. Keys are scoped to an endpoint and a caller.
+// While the request runs the key is held until LockedUntil; a request that never finished,
+// e.g. because the replica crashed, no longer blocks retries once that lease is over.
+type Record struct {
+	Scope          string
+	Key            string
+	RequestHash    string
+	Status         enum.RecordStatus
+	ResponseStatus int
+	ResponseBody   []byte
+	CreatedAt      time.Time
+	LockedUntil    time.Time
+	ExpiresAt      time.Time
+}
+
+func NewRecord(scope, key, requestHash string, now time.Time, ttl, lease time.Duration) Record {
+	// Postgres keeps microseconds, so the lease read back compares equal to this one
+	now = now.Truncate(time.Microsecond)
+	return Record{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		Status:      enum.RecordStatusInProgress,
+		CreatedAt:   now,
+		LockedUntil: now.Add(lease),
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
+// HashRequest fingerprints a request so a key reused with another payload can be told apart
+func HashRequest(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (r Record) Matches(requestHash string) bool {
+	return r.RequestHash == requestHash
+}
+
+func (r Record) IsCompleted() bool {
+	return r.Status == enum.RecordStatusCompleted
+}
+
+func (r Record) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// IsAbandoned tells a request that is still marked as running but outlived its lease
+func (r Record) IsAbandoned(now time.Time) bool {
+	return !r.IsCompleted() && !now.Before(r.LockedUntil)
+}
+
+func (r Record) Complete(status int, body []byte) Record {
+	r.Status = enum.RecordStatusCompleted
+	r.ResponseStatus = status
+	r.ResponseBody = body
+	return r
+}
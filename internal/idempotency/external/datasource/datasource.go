@@ -0,0 +1,17 @@
+package datasource
+
+import (
+	"context"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/dto"
+)
+
+type DataSource interface {
+	Claim(ctx context.Context, record dto.IdempotencyKeyDAO) (bool, error)
+	TakeOver(ctx context.Context, record dto.IdempotencyKeyDAO, lockedUntil time.Time) (bool, error)
+	Find(ctx context.Context, scope, key string) (dto.IdempotencyKeyDAO, error)
+	Update(ctx context.Context, record dto.IdempotencyKeyDAO) error
+	Release(ctx context.Context, record dto.IdempotencyKeyDAO) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,90 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/database"
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/entity/enum"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DB interface defines the database operations needed
+type DB interface {
+	Where(query any, args ...any) *gorm.DB
+	First(dest any, conds ...any) *gorm.DB
+	Model(value any) *gorm.DB
+	Save(value any) *gorm.DB
+	Delete(value any, conds ...any) *gorm.DB
+	Clauses(conds ...clause.Expression) *gorm.DB
+}
+
+type GormDataSource struct {
+	db DB
+}
+
+func New(db DB) DataSource {
+	return &GormDataSource{
+		db: db,
+	}
+}
+
+// Claim inserts the record unless its key is already taken and reports whether it did.
+// The primary key makes concurrent claims of the same key race safely across replicas.
+func (g *GormDataSource) Claim(ctx context.Context, record dto.IdempotencyKeyDAO) (bool, error) {
+	tx := database.Conn(ctx, g.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	return tx.RowsAffected == 1, nil
+}
+
+// TakeOver hands the key of a request that outlived its lease to record. Only the caller that
+// still sees the lease ending at lockedUntil wins, so two retries cannot take it over together.
+func (g *GormDataSource) TakeOver(ctx context.Context, record dto.IdempotencyKeyDAO, lockedUntil time.Time) (bool, error) {
+	tx := database.Conn(ctx, g.db).Model(&dto.IdempotencyKeyDAO{}).
+		Where("scope = ? AND key = ? AND status = ? AND locked_until = ?", record.Scope, record.Key, enum.RecordStatusInProgress, lockedUntil).
+		Updates(map[string]any{
+			"request_hash": record.RequestHash,
+			"created_at":   record.CreatedAt,
+			"locked_until": record.LockedUntil,
+			"expires_at":   record.ExpiresAt,
+		})
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	return tx.RowsAffected > 0, nil
+}
+
+func (g *GormDataSource) Find(ctx context.Context, scope, key string) (dto.IdempotencyKeyDAO, error) {
+	var record dto.IdempotencyKeyDAO
+
+	if err := database.Conn(ctx, g.db).First(&record, "scope = ? AND key = ?", scope, key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.IdempotencyKeyDAO{}, &apperror.NotFoundError{Msg: "Idempotency key not found"}
+		}
+		return dto.IdempotencyKeyDAO{}, err
+	}
+
+	return record, nil
+}
+
+func (g *GormDataSource) Update(ctx context.Context, record dto.IdempotencyKeyDAO) error {
+	return database.Conn(ctx, g.db).Save(&record).Error
+}
+
+// Release deletes the key while record still holds it, leaving alone a retry that took it over
+func (g *GormDataSource) Release(ctx context.Context, record dto.IdempotencyKeyDAO) error {
+	return database.Conn(ctx, g.db).
+		Where("scope = ? AND key = ? AND status = ? AND locked_until = ?", record.Scope, record.Key, enum.RecordStatusInProgress, record.LockedUntil).
+		Delete(&dto.IdempotencyKeyDAO{}).Error
+}
+
+func (g *GormDataSource) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	tx := database.Conn(ctx, g.db).Where("expires_at <= ?", now).Delete(&dto.IdempotencyKeyDAO{})
+	return tx.RowsAffected, tx.Error
+}
@@ -0,0 +1,72 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/external/datasource"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+)
+
+type Gateway struct {
+	datasource datasource.DataSource
+}
+
+func Build(datasource datasource.DataSource) *Gateway {
+	return &Gateway{
+		datasource: datasource,
+	}
+}
+
+func (g *Gateway) Claim(ctx context.Context, record entity.Record) (bool, error) {
+	claimed, err := g.datasource.Claim(ctx, dto.ToIdempotencyKeyDAO(record))
+	if err != nil {
+		return false, &apperror.InternalError{Msg: err.Error()}
+	}
+	return claimed, nil
+}
+
+func (g *Gateway) TakeOver(ctx context.Context, record entity.Record, lockedUntil time.Time) (bool, error) {
+	takenOver, err := g.datasource.TakeOver(ctx, dto.ToIdempotencyKeyDAO(record), lockedUntil)
+	if err != nil {
+		return false, &apperror.InternalError{Msg: err.Error()}
+	}
+	return takenOver, nil
+}
+
+func (g *Gateway) Find(ctx context.Context, scope, key string) (entity.Record, error) {
+	found, err := g.datasource.Find(ctx, scope, key)
+	if err != nil {
+		var notFoundErr *apperror.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return entity.Record{}, notFoundErr
+		}
+		return entity.Record{}, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.FromIdempotencyKeyDAO(found), nil
+}
+
+func (g *Gateway) Update(ctx context.Context, record entity.Record) error {
+	if err := g.datasource.Update(ctx, dto.ToIdempotencyKeyDAO(record)); err != nil {
+		return &apperror.InternalError{Msg: err.Error()}
+	}
+	return nil
+}
+
+func (g *Gateway) Release(ctx context.Context, record entity.Record) error {
+	if err := g.datasource.Release(ctx, dto.ToIdempotencyKeyDAO(record)); err != nil {
+		return &apperror.InternalError{Msg: err.Error()}
+	}
+	return nil
+}
+
+func (g *Gateway) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	deleted, err := g.datasource.DeleteExpired(ctx, now)
+	if err != nil {
+		return 0, &apperror.InternalError{Msg: err.Error()}
+	}
+	return deleted, nil
+}
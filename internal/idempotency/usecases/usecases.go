@@ -0,0 +1,89 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/gateway"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+)
+
+type UseCases struct {
+	idempotencyGateway gateway.Gateway
+	ttl                time.Duration
+	lease              time.Duration
+}
+
+// Build keeps finished responses for ttl; lease bounds how long a request still running holds its key
+func Build(idempotencyGateway gateway.Gateway, ttl, lease time.Duration) *UseCases {
+	return &UseCases{
+		idempotencyGateway: idempotencyGateway,
+		ttl:                ttl,
+		lease:              lease,
+	}
+}
+
+// Begin claims key for a new request and returns its record with claimed set. When the key was
+// already used the existing record is returned instead, and the caller decides whether to replay
+// its response, report it as still running or reject a different payload. Expired keys are free
+// again, and so are the keys of requests that outlived their lease without finishing.
+func (u *UseCases) Begin(ctx context.Context, scope, key, requestHash string) (entity.Record, bool, error) {
+	now := time.Now()
+	record := entity.NewRecord(scope, key, requestHash, now, u.ttl, u.lease)
+
+	// A second round only happens when the key disappeared between the claim and the lookup, or
+	// another retry took over an abandoned request first
+	for range 2 {
+		claimed, err := u.idempotencyGateway.Claim(ctx, record)
+		if err != nil {
+			return entity.Record{}, false, err
+		}
+		if claimed {
+			return record, true, nil
+		}
+
+		existing, err := u.idempotencyGateway.Find(ctx, scope, key)
+		var notFoundErr *apperror.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			continue
+		}
+		if err != nil {
+			return entity.Record{}, false, err
+		}
+
+		if existing.IsAbandoned(now) {
+			takenOver, err := u.idempotencyGateway.TakeOver(ctx, record, existing.LockedUntil)
+			if err != nil {
+				return entity.Record{}, false, err
+			}
+			if takenOver {
+				return record, true, nil
+			}
+			continue
+		}
+		if !existing.IsExpired(now) {
+			return existing, false, nil
+		}
+		if _, err := u.idempotencyGateway.DeleteExpired(ctx, now); err != nil {
+			return entity.Record{}, false, err
+		}
+	}
+
+	return entity.Record{}, false, &apperror.InternalError{Msg: "could not claim idempotency key"}
+}
+
+// Complete stores the response to replay for retries of the request
+func (u *UseCases) Complete(ctx context.Context, record entity.Record, status int, body []byte) error {
+	return u.idempotencyGateway.Update(ctx, record.Complete(status, body))
+}
+
+// Release frees the key of a request that failed on our side, so a retry runs it again
+func (u *UseCases) Release(ctx context.Context, record entity.Record) error {
+	return u.idempotencyGateway.Release(ctx, record)
+}
+
+func (u *UseCases) PurgeExpired(ctx context.Context) (int64, error) {
+	return u.idempotencyGateway.DeleteExpired(ctx, time.Now())
+}
@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/external/datasource"
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/gateway"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryIdempotencyDataSource claims and takes over keys the way the persisted datasource does;
+// the methods the tests do not reach are left to the embedded interface
+type memoryIdempotencyDataSource struct {
+	datasource.DataSource
+	records map[string]dto.IdempotencyKeyDAO
+}
+
+func (d *memoryIdempotencyDataSource) Claim(_ context.Context, record dto.IdempotencyKeyDAO) (bool, error) {
+	if _, ok := d.records[record.Scope+record.Key]; ok {
+		return false, nil
+	}
+	d.records[record.Scope+record.Key] = record
+	return true, nil
+}
+
+func (d *memoryIdempotencyDataSource) TakeOver(_ context.Context, record dto.IdempotencyKeyDAO, lockedUntil time.Time) (bool, error) {
+	current, ok := d.records[record.Scope+record.Key]
+	if !ok || current.Status != enum.RecordStatusInProgress || !current.LockedUntil.Equal(lockedUntil) {
+		return false, nil
+	}
+	d.records[record.Scope+record.Key] = record
+	return true, nil
+}
+
+func (d *memoryIdempotencyDataSource) Find(_ context.Context, scope, key string) (dto.IdempotencyKeyDAO, error) {
+	record, ok := d.records[scope+key]
+	if !ok {
+		return dto.IdempotencyKeyDAO{}, &apperror.NotFoundError{Msg: "Idempotency key not found"}
+	}
+	return record, nil
+}
+
+func TestUseCases_Begin(t *testing.T) {
+	running := func(lockedUntil time.Time) dto.IdempotencyKeyDAO {
+		return dto.IdempotencyKeyDAO{
+			Scope:       "POST /order customer-1",
+			Key:         "key-1",
+			RequestHash: "hash-1",
+			Status:      enum.RecordStatusInProgress,
+			CreatedAt:   time.Now().Add(-time.Hour),
+			LockedUntil: lockedUntil,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}
+	}
+	setup := func(existing dto.IdempotencyKeyDAO) (*UseCases, *memoryIdempotencyDataSource) {
+		idempotencyDataSource := &memoryIdempotencyDataSource{records: map[string]dto.IdempotencyKeyDAO{existing.Scope + existing.Key: existing}}
+		return Build(*gateway.Build(idempotencyDataSource), 24*time.Hour, time.Minute), idempotencyDataSource
+	}
+
+	t.Run("Given a request still running within its lease, when it is retried, then the key stays with it", func(t *testing.T) {
+		u, _ := setup(running(time.Now().Add(time.Minute)))
+
+		record, claimed, err := u.Begin(context.Background(), "POST /order customer-1", "key-1", "hash-1")
+
+		assert.NoError(t, err)
+		assert.False(t, claimed)
+		assert.False(t, record.IsCompleted())
+	})
+
+	t.Run("Given a request that outlived its lease without finishing, when it is retried, then the retry takes the key over", func(t *testing.T) {
+		u, idempotencyDataSource := setup(running(time.Now().Add(-time.Minute)))
+
+		record, claimed, err := u.Begin(context.Background(), "POST /order customer-1", "key-1", "hash-1")
+
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+		assert.True(t, record.LockedUntil.After(time.Now()))
+		assert.True(t, idempotencyDataSource.records["POST /order customer-1key-1"].LockedUntil.Equal(record.LockedUntil))
+	})
+}
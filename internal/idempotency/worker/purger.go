@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/idempotency/controller"
+)
+
+// Purger periodically deletes the idempotency keys past their retention
+type Purger struct {
+	controller *controller.Controller
+	interval   time.Duration
+}
+
+func NewPurger(controller *controller.Controller, interval time.Duration) *Purger {
+	return &Purger{
+		controller: controller,
+		interval:   interval,
+	}
+}
+
+// Start purges on every tick until ctx is cancelled
+func (p *Purger) Start(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	log.Printf("Idempotency key purger started (interval %s)", p.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := p.controller.PurgeExpired(ctx)
+			if err != nil {
+				log.Printf("Failed to purge idempotency keys: %v", err)
+				continue
+			}
+			if deleted > 0 {
+				log.Printf("Purged %d expired idempotency keys", deleted)
+			}
+		}
+	}
+}
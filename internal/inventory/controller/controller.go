@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/presenter"
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/usecases"
+)
+
+type Controller struct {
+	inventoryUseCase *usecases.UseCases
+}
+
+func Build(inventoryUseCase *usecases.UseCases) *Controller {
+	return &Controller{
+		inventoryUseCase: inventoryUseCase,
+	}
+}
+
+func (c *Controller) List(ctx context.Context, lowOnly bool) (dto.StockListResponseDTO, error) {
+	presenter := presenter.Build()
+
+	stocks, err := c.inventoryUseCase.List(ctx, lowOnly)
+	if err != nil {
+		return dto.StockListResponseDTO{}, err
+	}
+
+	return presenter.FromEntityListToListResponseDTO(stocks), nil
+}
+
+func (c *Controller) Set(ctx context.Context, productID string, request dto.SetStockRequestDTO) (dto.StockResponseDTO, error) {
+	presenter := presenter.Build()
+
+	stock, err := c.inventoryUseCase.Set(ctx, productID, *request.OnHand, request.LowStockThreshold)
+	if err != nil {
+		return dto.StockResponseDTO{}, err
+	}
+
+	return presenter.FromEntityToResponseDTO(stock), nil
+}
+
+func (c *Controller) Adjust(ctx context.Context, productID string, request dto.AdjustStockRequestDTO) (dto.StockResponseDTO, error) {
+	presenter := presenter.Build()
+
+	stock, err := c.inventoryUseCase.Adjust(ctx, productID, request.Delta, request.Reason)
+	if err != nil {
+		return dto.StockResponseDTO{}, err
+	}
+
+	return presenter.FromEntityToResponseDTO(stock), nil
+}
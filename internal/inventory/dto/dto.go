@@ -0,0 +1,147 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/entity/enum"
+)
+
+// SetStockRequestDTO sets the stock of a product after a count, and starts tracking it
+type SetStockRequestDTO struct {
+	OnHand            *int `json:"on_hand" binding:"required"`
+	LowStockThreshold int  `json:"low_stock_threshold"`
+}
+
+// AdjustStockRequestDTO adds units on hand, or removes them with a negative delta
+type AdjustStockRequestDTO struct {
+	Delta  int    `json:"delta" binding:"required"`
+	Reason string `json:"reason" binding:"required" example:"delivery"`
+}
+
+type StockResponseDTO struct {
+	ProductID         string    `json:"product_id"`
+	OnHand            int       `json:"on_hand"`
+	Reserved          int       `json:"reserved"`
+	Available         int       `json:"available"`
+	LowStockThreshold int       `json:"low_stock_threshold"`
+	Low               bool      `json:"low"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type StockListResponseDTO struct {
+	Total uint               `json:"total"`
+	List  []StockResponseDTO `json:"list"`
+}
+
+type StockDAO struct {
+	ProductID         string `gorm:"type:uuid;primaryKey"`
+	OnHand            int    `gorm:"not null;default:0"`
+	Reserved          int    `gorm:"not null;default:0"`
+	LowStockThreshold int    `gorm:"not null;default:0"`
+	UpdatedAt         time.Time
+}
+
+func (StockDAO) TableName() string {
+	return "product_stocks"
+}
+
+type ReservationDAO struct {
+	ID        string                 `gorm:"type:uuid;primaryKey"`
+	OrderID   string                 `gorm:"type:uuid;index"`
+	ProductID string                 `gorm:"type:uuid;index"`
+	Quantity  int                    `gorm:"not null"`
+	Status    enum.ReservationStatus `gorm:"type:varchar(20);index"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (ReservationDAO) TableName() string {
+	return "stock_reservations"
+}
+
+type AdjustmentDAO struct {
+	ID        string `gorm:"type:uuid;primaryKey"`
+	ProductID string `gorm:"type:uuid;index"`
+	Delta     int    `gorm:"not null"`
+	Reason    string `gorm:"type:varchar(255)"`
+	CreatedAt time.Time
+}
+
+func (AdjustmentDAO) TableName() string {
+	return "stock_adjustments"
+}
+
+func ToStockDAO(stock entity.Stock) StockDAO {
+	return StockDAO{
+		ProductID:         stock.ProductID,
+		OnHand:            stock.OnHand,
+		Reserved:          stock.Reserved,
+		LowStockThreshold: stock.LowStockThreshold,
+		UpdatedAt:         stock.UpdatedAt,
+	}
+}
+
+func FromStockDAO(dao StockDAO) entity.Stock {
+	return entity.Stock{
+		ProductID:         dao.ProductID,
+		OnHand:            dao.OnHand,
+		Reserved:          dao.Reserved,
+		LowStockThreshold: dao.LowStockThreshold,
+		UpdatedAt:         dao.UpdatedAt,
+	}
+}
+
+func StockListFromDAOList(daoList []StockDAO) []entity.Stock {
+	stocks := make([]entity.Stock, 0, len(daoList))
+	for _, dao := range daoList {
+		stocks = append(stocks, FromStockDAO(dao))
+	}
+	return stocks
+}
+
+func ToReservationDAO(reservation entity.Reservation) ReservationDAO {
+	return ReservationDAO{
+		ID:        reservation.ID,
+		OrderID:   reservation.OrderID,
+		ProductID: reservation.ProductID,
+		Quantity:  reservation.Quantity,
+		Status:    reservation.Status,
+		CreatedAt: reservation.CreatedAt,
+		UpdatedAt: reservation.UpdatedAt,
+	}
+}
+
+func FromReservationDAO(dao ReservationDAO) entity.Reservation {
+	return entity.Reservation{
+		ID:        dao.ID,
+		OrderID:   dao.OrderID,
+		ProductID: dao.ProductID,
+		Quantity:  dao.Quantity,
+		Status:    dao.Status,
+		CreatedAt: dao.CreatedAt,
+		UpdatedAt: dao.UpdatedAt,
+	}
+}
+
+func ToAdjustmentDAO(adjustment entity.Adjustment) AdjustmentDAO {
+	return AdjustmentDAO{
+		ID:        adjustment.ID,
+		ProductID: adjustment.ProductID,
+		Delta:     adjustment.Delta,
+		Reason:    adjustment.Reason,
+		CreatedAt: adjustment.CreatedAt,
+	}
+}
+
+func ToStockResponseDTO(stock entity.Stock) StockResponseDTO {
+	return StockResponseDTO{
+		ProductID:         stock.ProductID,
+		OnHand:            stock.OnHand,
+		Reserved:          stock.Reserved,
+		Available:         stock.Available(),
+		LowStockThreshold: stock.LowStockThreshold,
+		Low:               stock.IsLow(),
+		UpdatedAt:         stock.UpdatedAt,
+	}
+}
@@ -0,0 +1,16 @@
+package enum
+
+type ReservationStatus string
+
+const (
+	// ReservationStatusReserved holds stock for an open order
+	ReservationStatusReserved ReservationStatus = "reserved"
+	// ReservationStatusReleased gave the stock back when the order was cancelled or expired
+	ReservationStatusReleased ReservationStatus = "released"
+	// ReservationStatusConsumed took the stock out of the shelf when the order was completed
+	ReservationStatusConsumed ReservationStatus = "consumed"
+)
+
+func (r ReservationStatus) String() string {
+	return string(r)
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/entity/enum"
+	"github.com/google/uuid"
+)
+
+// Reservation is the stock of a product held by an order
+type Reservation struct {
+	ID        string
+	OrderID   string
+	ProductID string
+	Quantity  int
+	Status    enum.ReservationStatus
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Adjustment is a change of the stock on hand made by hand, such as a delivery, waste or a count
+type Adjustment struct {
+	ID        string
+	ProductID string
+	Delta     int
+	Reason    string
+	CreatedAt time.Time
+}
+
+func NewReservation(orderID string, request Request) Reservation {
+	now := time.Now()
+	return Reservation{
+		ID:        uuid.NewString(),
+		OrderID:   orderID,
+		ProductID: request.ProductID,
+		Quantity:  request.Quantity,
+		Status:    enum.ReservationStatusReserved,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func NewAdjustment(productID string, delta int, reason string) Adjustment {
+	return Adjustment{
+		ID:        uuid.NewString(),
+		ProductID: productID,
+		Delta:     delta,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+)
+
+// Stock is the inventory of a product. Units reserved by open orders are still on hand until
+// the order is completed, so what can be ordered is what is on hand and not reserved. Products
+// without a stock are not tracked and can always be ordered.
+type Stock struct {
+	ProductID         string
+	OnHand            int
+	Reserved          int
+	LowStockThreshold int
+	UpdatedAt         time.Time
+}
+
+// Request is how much of a product an order needs. Name is only used in error messages.
+type Request struct {
+	ProductID string
+	Name      string
+	Quantity  int
+}
+
+func (s Stock) Available() int {
+	return s.OnHand - s.Reserved
+}
+
+// IsLow reports whether the stock reached its alert threshold
+func (s Stock) IsLow() bool {
+	return s.Available() <= s.LowStockThreshold
+}
+
+func (s Stock) Validate() error {
+	if s.OnHand < 0 {
+		return &apperror.ValidationError{Msg: "Stock on hand cannot be negative"}
+	}
+	if s.LowStockThreshold < 0 {
+		return &apperror.ValidationError{Msg: "Low stock threshold cannot be negative"}
+	}
+	if s.OnHand < s.Reserved {
+		return &apperror.ValidationError{Msg: fmt.Sprintf("Stock on hand cannot be below the %d units reserved by open orders", s.Reserved)}
+	}
+	return nil
+}
+
+func (s Stock) Reserve(quantity int) Stock {
+	s.Reserved += quantity
+	s.UpdatedAt = time.Now()
+	return s
+}
+
+func (s Stock) Release(quantity int) Stock {
+	s.Reserved = max(s.Reserved-quantity, 0)
+	s.UpdatedAt = time.Now()
+	return s
+}
+
+// Consume takes reserved units off the shelf
+func (s Stock) Consume(quantity int) Stock {
+	s.OnHand = max(s.OnHand-quantity, 0)
+	s.Reserved = max(s.Reserved-quantity, 0)
+	s.UpdatedAt = time.Now()
+	return s
+}
+
+// Adjust adds delta units on hand, or removes them when negative
+func (s Stock) Adjust(delta int) (Stock, error) {
+	s.OnHand += delta
+	s.UpdatedAt = time.Now()
+	if err := s.Validate(); err != nil {
+		return Stock{}, err
+	}
+	return s, nil
+}
+
+// MergeRequests adds up the requests for the same product, keeping the order they first appear in
+func MergeRequests(requests []Request) []Request {
+	var merged []Request
+	positions := make(map[string]int, len(requests))
+	for _, request := range requests {
+		if i, ok := positions[request.ProductID]; ok {
+			merged[i].Quantity += request.Quantity
+			continue
+		}
+		positions[request.ProductID] = len(merged)
+		merged = append(merged, request)
+	}
+	return merged
+}
+
+// CheckAvailability tells which request cannot be served by the stocks. Requests for products
+// without a stock are always served.
+func CheckAvailability(stocks []Stock, requests []Request) error {
+	byProduct := make(map[string]Stock, len(stocks))
+	for _, stock := range stocks {
+		byProduct[stock.ProductID] = stock
+	}
+
+	for _, request := range MergeRequests(requests) {
+		stock, tracked := byProduct[request.ProductID]
+		if !tracked || request.Quantity <= stock.Available() {
+			continue
+		}
+		if stock.Available() <= 0 {
+			return &apperror.ValidationError{Msg: fmt.Sprintf("%s is out of stock", request.Name)}
+		}
+		return &apperror.ValidationError{Msg: fmt.Sprintf("Only %d of %s left in stock", stock.Available(), request.Name)}
+	}
+	return nil
+}
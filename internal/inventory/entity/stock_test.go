@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"testing"
+
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAvailability(t *testing.T) {
+	stocks := []Stock{
+		{ProductID: "burger", OnHand: 10, Reserved: 7},
+		{ProductID: "pie", OnHand: 2, Reserved: 2},
+	}
+
+	t.Run("Given requests within the available stock, when availability is checked, then they are served", func(t *testing.T) {
+		err := CheckAvailability(stocks, []Request{
+			{ProductID: "burger", Name: "X-Burger", Quantity: 2},
+			{ProductID: "burger", Name: "X-Burger", Quantity: 1},
+			{ProductID: "soda", Name: "Soda", Quantity: 50},
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Given requests that add up to more than available, when availability is checked, then they are rejected", func(t *testing.T) {
+		err := CheckAvailability(stocks, []Request{
+			{ProductID: "burger", Name: "X-Burger", Quantity: 2},
+			{ProductID: "burger", Name: "X-Burger", Quantity: 2},
+		})
+
+		var validationErr *apperror.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "Only 3 of X-Burger left in stock", err.Error())
+	})
+
+	t.Run("Given a product fully reserved, when availability is checked, then it is out of stock", func(t *testing.T) {
+		err := CheckAvailability(stocks, []Request{{ProductID: "pie", Name: "Pie", Quantity: 1}})
+
+		assert.EqualError(t, err, "Pie is out of stock")
+	})
+}
+
+func TestStockMovements(t *testing.T) {
+	t.Run("Given a reservation, when the order is completed, then the units leave the shelf", func(t *testing.T) {
+		stock := Stock{ProductID: "burger", OnHand: 10, LowStockThreshold: 8}.Reserve(3)
+		assert.Equal(t, 7, stock.Available())
+		assert.True(t, stock.IsLow())
+
+		stock = stock.Consume(3)
+		assert.Equal(t, 7, stock.OnHand)
+		assert.Equal(t, 0, stock.Reserved)
+	})
+
+	t.Run("Given a reservation, when the order is cancelled, then the units are available again", func(t *testing.T) {
+		stock := Stock{ProductID: "burger", OnHand: 10}.Reserve(3).Release(3)
+
+		assert.Equal(t, 10, stock.Available())
+	})
+
+	t.Run("Given reserved units, when an adjustment takes the stock below them, then it is rejected", func(t *testing.T) {
+		_, err := Stock{ProductID: "burger", OnHand: 10, Reserved: 6}.Adjust(-5)
+
+		var validationErr *apperror.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
@@ -0,0 +1,19 @@
+package datasource
+
+import (
+	"context"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/entity/enum"
+)
+
+type DataSource interface {
+	List(ctx context.Context, lowOnly bool) ([]dto.StockDAO, error)
+	FindByProductIDs(ctx context.Context, productIDs []string) ([]dto.StockDAO, error)
+	LockByProductIDs(ctx context.Context, productIDs []string) ([]dto.StockDAO, error)
+	Save(ctx context.Context, stock dto.StockDAO) error
+	CreateReservations(ctx context.Context, reservations []dto.ReservationDAO) error
+	FindReservations(ctx context.Context, orderID string, status enum.ReservationStatus) ([]dto.ReservationDAO, error)
+	UpdateReservationStatus(ctx context.Context, orderID string, from, to enum.ReservationStatus) error
+	CreateAdjustment(ctx context.Context, adjustment dto.AdjustmentDAO) error
+}
@@ -0,0 +1,95 @@
+package datasource
+
+import (
+	"context"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/database"
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/entity/enum"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DB interface defines the database operations needed
+type DB interface {
+	Create(value any) *gorm.DB
+	Where(query any, args ...any) *gorm.DB
+	Find(dest any, conds ...any) *gorm.DB
+	Save(value any) *gorm.DB
+	Model(value any) *gorm.DB
+	Order(value any) *gorm.DB
+	Clauses(conds ...clause.Expression) *gorm.DB
+}
+
+type GormDataSource struct {
+	db DB
+}
+
+func New(db DB) DataSource {
+	return &GormDataSource{
+		db: db,
+	}
+}
+
+func (g *GormDataSource) List(ctx context.Context, lowOnly bool) ([]dto.StockDAO, error) {
+	var stocks []dto.StockDAO
+
+	query := database.Conn(ctx, g.db).Order("product_id")
+	if lowOnly {
+		query = query.Where("on_hand - reserved <= low_stock_threshold")
+	}
+	if err := query.Find(&stocks).Error; err != nil {
+		return nil, err
+	}
+	return stocks, nil
+}
+
+func (g *GormDataSource) FindByProductIDs(ctx context.Context, productIDs []string) ([]dto.StockDAO, error) {
+	var stocks []dto.StockDAO
+	if err := database.Conn(ctx, g.db).Where("product_id IN ?", productIDs).Find(&stocks).Error; err != nil {
+		return nil, err
+	}
+	return stocks, nil
+}
+
+// LockByProductIDs reads the stocks locking their rows until the transaction ends. Rows are
+// locked in product order so concurrent orders for the same products cannot deadlock.
+func (g *GormDataSource) LockByProductIDs(ctx context.Context, productIDs []string) ([]dto.StockDAO, error) {
+	var stocks []dto.StockDAO
+	err := database.Conn(ctx, g.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id IN ?", productIDs).
+		Order("product_id").
+		Find(&stocks).Error
+	if err != nil {
+		return nil, err
+	}
+	return stocks, nil
+}
+
+func (g *GormDataSource) Save(ctx context.Context, stock dto.StockDAO) error {
+	return database.Conn(ctx, g.db).Save(&stock).Error
+}
+
+func (g *GormDataSource) CreateReservations(ctx context.Context, reservations []dto.ReservationDAO) error {
+	return database.Conn(ctx, g.db).Create(&reservations).Error
+}
+
+func (g *GormDataSource) FindReservations(ctx context.Context, orderID string, status enum.ReservationStatus) ([]dto.ReservationDAO, error) {
+	var reservations []dto.ReservationDAO
+	if err := database.Conn(ctx, g.db).Where("order_id = ? AND status = ?", orderID, status).Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+func (g *GormDataSource) UpdateReservationStatus(ctx context.Context, orderID string, from, to enum.ReservationStatus) error {
+	return database.Conn(ctx, g.db).Model(&dto.ReservationDAO{}).
+		Where("order_id = ? AND status = ?", orderID, from).
+		Updates(map[string]any{"status": to, "updated_at": time.Now()}).Error
+}
+
+func (g *GormDataSource) CreateAdjustment(ctx context.Context, adjustment dto.AdjustmentDAO) error {
+	return database.Conn(ctx, g.db).Create(&adjustment).Error
+}
@@ -0,0 +1,98 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/external/datasource"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+)
+
+type Gateway struct {
+	datasource datasource.DataSource
+}
+
+func Build(datasource datasource.DataSource) *Gateway {
+	return &Gateway{
+		datasource: datasource,
+	}
+}
+
+func (g *Gateway) List(ctx context.Context, lowOnly bool) ([]entity.Stock, error) {
+	found, err := g.datasource.List(ctx, lowOnly)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.StockListFromDAOList(found), nil
+}
+
+func (g *Gateway) FindByProductIDs(ctx context.Context, productIDs []string) ([]entity.Stock, error) {
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+	found, err := g.datasource.FindByProductIDs(ctx, productIDs)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.StockListFromDAOList(found), nil
+}
+
+func (g *Gateway) LockByProductIDs(ctx context.Context, productIDs []string) ([]entity.Stock, error) {
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+	found, err := g.datasource.LockByProductIDs(ctx, productIDs)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.StockListFromDAOList(found), nil
+}
+
+func (g *Gateway) Save(ctx context.Context, stock entity.Stock) error {
+	if err := g.datasource.Save(ctx, dto.ToStockDAO(stock)); err != nil {
+		return &apperror.InternalError{Msg: err.Error()}
+	}
+	return nil
+}
+
+func (g *Gateway) CreateReservations(ctx context.Context, reservations []entity.Reservation) error {
+	if len(reservations) == 0 {
+		return nil
+	}
+	daoList := make([]dto.ReservationDAO, 0, len(reservations))
+	for _, reservation := range reservations {
+		daoList = append(daoList, dto.ToReservationDAO(reservation))
+	}
+	if err := g.datasource.CreateReservations(ctx, daoList); err != nil {
+		return &apperror.InternalError{Msg: err.Error()}
+	}
+	return nil
+}
+
+func (g *Gateway) FindReservations(ctx context.Context, orderID string, status enum.ReservationStatus) ([]entity.Reservation, error) {
+	found, err := g.datasource.FindReservations(ctx, orderID, status)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	reservations := make([]entity.Reservation, 0, len(found))
+	for _, dao := range found {
+		reservations = append(reservations, dto.FromReservationDAO(dao))
+	}
+	return reservations, nil
+}
+
+func (g *Gateway) UpdateReservationStatus(ctx context.Context, orderID string, from, to enum.ReservationStatus) error {
+	if err := g.datasource.UpdateReservationStatus(ctx, orderID, from, to); err != nil {
+		return &apperror.InternalError{Msg: err.Error()}
+	}
+	return nil
+}
+
+func (g *Gateway) CreateAdjustment(ctx context.Context, adjustment entity.Adjustment) error {
+	if err := g.datasource.CreateAdjustment(ctx, dto.ToAdjustmentDAO(adjustment)); err != nil {
+		return &apperror.InternalError{Msg: err.Error()}
+	}
+	return nil
+}
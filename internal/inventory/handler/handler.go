@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/controller"
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/dto"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/helper"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	controller *controller.Controller
+}
+
+func New(controller *controller.Controller) *Handler {
+	return &Handler{controller: controller}
+}
+
+// List Stocks godoc
+// @Summary      List Stocks
+// @Description  List the stock of every tracked product. Products without a stock are not tracked and can always be ordered.
+// @Tags         Inventory Domain
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  dto.StockListResponseDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/stock [get]
+func (h *Handler) List(c *gin.Context) {
+	stocks, err := h.controller.List(context.Background(), false)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stocks)
+}
+
+// ListLow Low Stocks godoc
+// @Summary      List Low Stocks
+// @Description  List the stocks whose available units are at or below their low stock threshold
+// @Tags         Inventory Domain
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  dto.StockListResponseDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/stock/low [get]
+func (h *Handler) ListLow(c *gin.Context) {
+	stocks, err := h.controller.List(context.Background(), true)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stocks)
+}
+
+// Set Stock godoc
+// @Summary      Set Stock
+// @Description  Set the units on hand of a product after a count, starting to track its stock. The difference to the previous count is recorded as an adjustment.
+// @Tags         Inventory Domain
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        product_id  path      string                  true  "Product ID"
+// @Param        request     body      dto.SetStockRequestDTO  true  "Stock count"
+// @Success      200  {object}  dto.StockResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/stock/{product_id} [put]
+func (h *Handler) Set(c *gin.Context) {
+	var request dto.SetStockRequestDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "Invalid request body",
+			MessageError: err.Error(),
+		})
+		return
+	}
+
+	stock, err := h.controller.Set(context.Background(), c.Param("product_id"), request)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stock)
+}
+
+// Adjust Stock godoc
+// @Summary      Adjust Stock
+// @Description  Add units on hand of a tracked product, or remove them with a negative delta, such as for a delivery or waste
+// @Tags         Inventory Domain
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        product_id  path      string                     true  "Product ID"
+// @Param        request     body      dto.AdjustStockRequestDTO  true  "Adjustment"
+// @Success      200  {object}  dto.StockResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/stock/{product_id}/adjustments [post]
+func (h *Handler) Adjust(c *gin.Context) {
+	var request dto.AdjustStockRequestDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "Invalid request body",
+			MessageError: err.Error(),
+		})
+		return
+	}
+
+	stock, err := h.controller.Adjust(context.Background(), c.Param("product_id"), request)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stock)
+}
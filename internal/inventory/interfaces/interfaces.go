@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	productentity "github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+)
+
+type ProductService interface {
+	FindByIDs(ctx context.Context, productIDs []string) ([]productentity.Product, error)
+}
+
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,29 @@
+package presenter
+
+import (
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/entity"
+)
+
+type Presenter struct {
+}
+
+func Build() *Presenter {
+	return &Presenter{}
+}
+
+func (p *Presenter) FromEntityToResponseDTO(stock entity.Stock) dto.StockResponseDTO {
+	return dto.ToStockResponseDTO(stock)
+}
+
+func (p *Presenter) FromEntityListToListResponseDTO(stocks []entity.Stock) dto.StockListResponseDTO {
+	list := make([]dto.StockResponseDTO, 0, len(stocks))
+	for _, stock := range stocks {
+		list = append(list, p.FromEntityToResponseDTO(stock))
+	}
+
+	return dto.StockListResponseDTO{
+		Total: uint(len(list)),
+		List:  list,
+	}
+}
@@ -0,0 +1,239 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/gateway"
+	"github.com/fiap-161/tc-golunch-core-service/internal/inventory/interfaces"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/google/uuid"
+)
+
+// countReason is recorded on the adjustment made when a stock is set after a count
+const countReason = "count"
+
+type UseCases struct {
+	inventoryGateway gateway.Gateway
+	productService   interfaces.ProductService
+	unitOfWork       interfaces.UnitOfWork
+}
+
+func Build(inventoryGateway gateway.Gateway, productService interfaces.ProductService, unitOfWork interfaces.UnitOfWork) *UseCases {
+	return &UseCases{
+		inventoryGateway: inventoryGateway,
+		productService:   productService,
+		unitOfWork:       unitOfWork,
+	}
+}
+
+// List returns the tracked stocks, only those at or below their threshold when lowOnly is set
+func (u *UseCases) List(ctx context.Context, lowOnly bool) ([]entity.Stock, error) {
+	return u.inventoryGateway.List(ctx, lowOnly)
+}
+
+// Check tells whether the requests can be served right now without reserving anything, so a
+// quote fails on the same missing products as the order would
+func (u *UseCases) Check(ctx context.Context, requests []entity.Request) error {
+	stocks, err := u.inventoryGateway.FindByProductIDs(ctx, productIDs(requests))
+	if err != nil {
+		return err
+	}
+	return entity.CheckAvailability(stocks, requests)
+}
+
+// Reserve holds the units an order needs. It runs in the transaction creating the order with
+// the stocks locked, so two orders cannot both take the last unit.
+func (u *UseCases) Reserve(ctx context.Context, orderID string, requests []entity.Request) error {
+	requests = entity.MergeRequests(requests)
+	stocks, err := u.inventoryGateway.LockByProductIDs(ctx, productIDs(requests))
+	if err != nil {
+		return err
+	}
+	if err := entity.CheckAvailability(stocks, requests); err != nil {
+		return err
+	}
+
+	byProduct := make(map[string]entity.Stock, len(stocks))
+	for _, stock := range stocks {
+		byProduct[stock.ProductID] = stock
+	}
+
+	var reservations []entity.Reservation
+	for _, request := range requests {
+		stock, tracked := byProduct[request.ProductID]
+		if !tracked {
+			continue
+		}
+		if err := u.save(ctx, stock, stock.Reserve(request.Quantity)); err != nil {
+			return err
+		}
+		reservations = append(reservations, entity.NewReservation(orderID, request))
+	}
+
+	return u.inventoryGateway.CreateReservations(ctx, reservations)
+}
+
+// Release gives back the units held by an order that was cancelled or expired
+func (u *UseCases) Release(ctx context.Context, orderID string) error {
+	return u.settle(ctx, orderID, enum.ReservationStatusReleased, entity.Stock.Release)
+}
+
+// Consume takes the units held by a completed order off the shelf
+func (u *UseCases) Consume(ctx context.Context, orderID string) error {
+	return u.settle(ctx, orderID, enum.ReservationStatusConsumed, entity.Stock.Consume)
+}
+
+// Set starts tracking the stock of a product, or replaces it after a count. The difference to
+// the previous count is recorded as an adjustment.
+func (u *UseCases) Set(ctx context.Context, productID string, onHand, lowStockThreshold int) (entity.Stock, error) {
+	if err := u.checkProduct(ctx, productID); err != nil {
+		return entity.Stock{}, err
+	}
+
+	var result entity.Stock
+	err := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		previous, err := u.lock(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		stock := previous
+		stock.ProductID = productID
+		stock.OnHand = onHand
+		stock.LowStockThreshold = lowStockThreshold
+		stock, err = stock.Adjust(0)
+		if err != nil {
+			return err
+		}
+		if err := u.save(ctx, previous, stock); err != nil {
+			return err
+		}
+
+		result = stock
+		if delta := onHand - previous.OnHand; delta != 0 {
+			return u.inventoryGateway.CreateAdjustment(ctx, entity.NewAdjustment(productID, delta, countReason))
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.Stock{}, err
+	}
+	return result, nil
+}
+
+// Adjust adds or removes units on hand of a tracked product, such as a delivery or waste
+func (u *UseCases) Adjust(ctx context.Context, productID string, delta int, reason string) (entity.Stock, error) {
+	if _, err := uuid.Parse(productID); err != nil {
+		return entity.Stock{}, &apperror.ValidationError{Msg: "Invalid UUID format for product ID"}
+	}
+	if delta == 0 {
+		return entity.Stock{}, &apperror.ValidationError{Msg: "Delta must not be zero"}
+	}
+	if reason == "" {
+		return entity.Stock{}, &apperror.ValidationError{Msg: "Reason is required"}
+	}
+
+	var result entity.Stock
+	err := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		previous, err := u.lock(ctx, productID)
+		if err != nil {
+			return err
+		}
+		if previous.ProductID == "" {
+			return &apperror.NotFoundError{Msg: fmt.Sprintf("Stock of product %s is not tracked", productID)}
+		}
+
+		stock, err := previous.Adjust(delta)
+		if err != nil {
+			return err
+		}
+		if err := u.save(ctx, previous, stock); err != nil {
+			return err
+		}
+
+		result = stock
+		return u.inventoryGateway.CreateAdjustment(ctx, entity.NewAdjustment(productID, delta, reason))
+	})
+	if err != nil {
+		return entity.Stock{}, err
+	}
+	return result, nil
+}
+
+// settle moves the reservations an order still holds to status, applying move to their stocks.
+// Reservations already settled are left alone, so settling twice does nothing.
+func (u *UseCases) settle(ctx context.Context, orderID string, status enum.ReservationStatus, move func(entity.Stock, int) entity.Stock) error {
+	reservations, err := u.inventoryGateway.FindReservations(ctx, orderID, enum.ReservationStatusReserved)
+	if err != nil || len(reservations) == 0 {
+		return err
+	}
+
+	ids := make([]string, 0, len(reservations))
+	for _, reservation := range reservations {
+		ids = append(ids, reservation.ProductID)
+	}
+	stocks, err := u.inventoryGateway.LockByProductIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, reservation := range reservations {
+		for _, stock := range stocks {
+			if stock.ProductID != reservation.ProductID {
+				continue
+			}
+			if err := u.save(ctx, stock, move(stock, reservation.Quantity)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return u.inventoryGateway.UpdateReservationStatus(ctx, orderID, enum.ReservationStatusReserved, status)
+}
+
+// lock reads the stock of a product for update, the zero Stock when it is not tracked
+func (u *UseCases) lock(ctx context.Context, productID string) (entity.Stock, error) {
+	stocks, err := u.inventoryGateway.LockByProductIDs(ctx, []string{productID})
+	if err != nil || len(stocks) == 0 {
+		return entity.Stock{}, err
+	}
+	return stocks[0], nil
+}
+
+// save stores the stock and raises the low stock alert when it has just dropped to its threshold
+func (u *UseCases) save(ctx context.Context, previous, stock entity.Stock) error {
+	if err := u.inventoryGateway.Save(ctx, stock); err != nil {
+		return err
+	}
+	if stock.IsLow() && (previous.ProductID == "" || !previous.IsLow()) {
+		log.Printf("Low stock for product %s: %d available, threshold %d", stock.ProductID, stock.Available(), stock.LowStockThreshold)
+	}
+	return nil
+}
+
+func (u *UseCases) checkProduct(ctx context.Context, productID string) error {
+	if _, err := uuid.Parse(productID); err != nil {
+		return &apperror.ValidationError{Msg: "Invalid UUID format for product ID"}
+	}
+
+	products, err := u.productService.FindByIDs(ctx, []string{productID})
+	if err != nil {
+		return err
+	}
+	if len(products) == 0 {
+		return &apperror.NotFoundError{Msg: fmt.Sprintf("Product %s not found", productID)}
+	}
+	return nil
+}
+
+func productIDs(requests []entity.Request) []string {
+	ids := make([]string, 0, len(requests))
+	for _, request := range requests {
+		ids = append(ids, request.ProductID)
+	}
+	return ids
+}
@@ -2,29 +2,61 @@ package controller
 
 import (
 	"context"
-	"log"
+	"sync"
+	"time"
 
-	"github.com/fiap-161/tc-golunch-core-service/interna		if err := c.httpGateway.NotifyOperationService(context.Background(), updated.Entity.ID, newStatus); err != nil {
-			log.Printf("Failed to notify operation service for order %s status change: %v", updated.Entity.ID, err)order/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/gateway"
-	"github.com/fiap-161/tc-golunch-core-service/internal/order/gateway/services"
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/interfaces"
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/presenter"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/stream"
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/usecases"
 )
 
 type Controller struct {
 	orderUseCase *usecases.UseCases
-	httpGateway  *services.OrderServiceHTTPGateway
+	panelStream  *stream.Broadcaster
 }
 
-func Build(orderGateway *gateway.Gateway, productService interfaces.ProductService, productOrderService interfaces.ProductOrderService) *Controller {
-	orderUseCase := usecases.Build(orderGateway, productService, productOrderService)
-	httpGateway := services.NewOrderServiceHTTPGateway(orderUseCase)
+// PanelFeed is a live view of the order panel. Initial holds what has to be sent before
+// Events: a snapshot of the panel and/or the events missed since the last connection.
+// Events is closed when the feed falls behind; the client then reconnects with the last ID it saw.
+type PanelFeed struct {
+	Initial []dto.OrderPanelEventDTO
+	Events  <-chan dto.OrderPanelEventDTO
+	Close   func()
+}
+
+func Build(
+	orderGateway *gateway.Gateway,
+	productService interfaces.ProductService,
+	comboService interfaces.ComboService,
+	promotionService interfaces.PromotionService,
+	inventoryService interfaces.InventoryService,
+	productOrderService interfaces.ProductOrderService,
+	unitOfWork interfaces.UnitOfWork,
+	outboxService interfaces.OutboxService,
+	panelStream *stream.Broadcaster,
+	numbering entity.Numbering,
+) *Controller {
+	orderUseCase := usecases.Build(
+		orderGateway,
+		productService,
+		comboService,
+		promotionService,
+		inventoryService,
+		productOrderService,
+		unitOfWork,
+		outboxService,
+		panelStream,
+		numbering,
+	)
 
 	return &Controller{
 		orderUseCase: orderUseCase,
-		httpGateway:  httpGateway,
+		panelStream:  panelStream,
 	}
 }
 
@@ -34,44 +66,103 @@ func (c *Controller) Create(ctx context.Context, orderDTO dto.CreateOrderDTO) (d
 		return dto.OrderDAO{}, err
 	}
 
-	// After order is created, trigger payment creation asynchronously
-	go func() {
-		if err := c.httpGateway.CreatePaymentForOrder(context.Background(), order.Entity.ID); err != nil {
-			log.Printf("Failed to create payment for order %s: %v", order.Entity.ID, err)
-		}
-	}()
+	presenter := presenter.Build()
+	return presenter.FromEntityToDAO(order), nil
+}
 
-	// Notify operation service about new order
-	go func() {
-		if err := c.httpGateway.NotifyOperationService(context.Background(), order.Entity.ID, string(order.Status)); err != nil {
-			log.Printf("Failed to notify operation service for order %s: %v", order.Entity.ID, err)
-		}
-	}()
+func (c *Controller) Quote(ctx context.Context, orderDTO dto.CreateOrderDTO) (dto.QuoteResponseDTO, error) {
+	presenter := presenter.Build()
+
+	order, err := c.orderUseCase.Quote(ctx, orderDTO)
+	if err != nil {
+		return dto.QuoteResponseDTO{}, err
+	}
+
+	return presenter.FromEntityToQuoteDTO(order), nil
+}
 
+func (c *Controller) List(ctx context.Context, queryDTO dto.ListOrdersQueryDTO) (dto.OrderResponseListDTO, error) {
 	presenter := presenter.Build()
-	return presenter.FromEntityToDAO(order), nil
+
+	page, err := c.orderUseCase.List(ctx, dto.ToListQuery(queryDTO))
+	if err != nil {
+		return dto.OrderResponseListDTO{}, err
+	}
+
+	return presenter.FromPageToResponseListDTO(page), nil
 }
 
-func (c *Controller) GetAll(ctx context.Context, id string) ([]dto.OrderDAO, error) {
+func (c *Controller) GetByID(ctx context.Context, id string) (dto.OrderResponseDTO, error) {
 	presenter := presenter.Build()
 
-	orders, err := c.orderUseCase.GetAllOrById(ctx, id)
+	order, err := c.orderUseCase.GetWithItems(ctx, id)
 	if err != nil {
-		return nil, err
+		return dto.OrderResponseDTO{}, err
 	}
 
-	return presenter.FromEntityListToDAOList(orders), nil
+	return presenter.FromEntityToResponseDTO(order), nil
 }
 
-func (c *Controller) GetPanel(ctx context.Context) ([]dto.OrderDAO, error) {
+func (c *Controller) GetPanel(ctx context.Context) (dto.OrderPanelDTO, error) {
 	presenter := presenter.Build()
 
 	orders, err := c.orderUseCase.GetPanel(ctx)
 	if err != nil {
-		return nil, err
+		return dto.OrderPanelDTO{}, err
+	}
+
+	return presenter.FromEntityListToPanelDTO(orders), nil
+}
+
+// SubscribePanel opens a panel feed resuming after lastEventID, or starting from a snapshot when nil
+func (c *Controller) SubscribePanel(ctx context.Context, lastEventID *uint64) (*PanelFeed, error) {
+	presenter := presenter.Build()
+
+	// Subscribing before reading the panel guarantees no change falls between the snapshot and the feed
+	subscription := c.panelStream.Subscribe(lastEventID)
+
+	var initial []dto.OrderPanelEventDTO
+	if subscription.NeedsSnapshot {
+		orders, err := c.orderUseCase.GetPanel(ctx)
+		if err != nil {
+			c.panelStream.Unsubscribe(subscription)
+			return nil, err
+		}
+		panel := presenter.FromEntityListToPanelDTO(orders)
+		initial = append(initial, dto.OrderPanelEventDTO{
+			ID:    subscription.LastID,
+			Type:  dto.PanelEventSnapshot,
+			Panel: &panel,
+		})
 	}
+	for _, event := range subscription.Replay {
+		initial = append(initial, presenter.FromPanelEventToDTO(event))
+	}
+
+	events := make(chan dto.OrderPanelEventDTO)
+	done := make(chan struct{})
+	go func() {
+		defer close(events)
+		for event := range subscription.Events {
+			select {
+			case events <- presenter.FromPanelEventToDTO(event):
+			case <-done:
+				return
+			}
+		}
+	}()
 
-	return presenter.FromEntityListToDAOList(orders), nil
+	var once sync.Once
+	return &PanelFeed{
+		Initial: initial,
+		Events:  events,
+		Close: func() {
+			once.Do(func() {
+				close(done)
+				c.panelStream.Unsubscribe(subscription)
+			})
+		},
+	}, nil
 }
 
 func (c *Controller) FindByID(ctx context.Context, id string) (dto.OrderDAO, error) {
@@ -85,26 +176,57 @@ func (c *Controller) FindByID(ctx context.Context, id string) (dto.OrderDAO, err
 	return presenter.FromEntityToDAO(order), nil
 }
 
-func (c *Controller) Update(ctx context.Context, orderDTO dto.OrderDAO) (dto.OrderDAO, error) {
+func (c *Controller) Update(ctx context.Context, orderDTO dto.OrderDAO, actor entity.Actor) (dto.OrderDAO, error) {
 	presenter := presenter.Build()
 
 	order := dto.FromOrderDAO(orderDTO)
-	oldStatus := string(order.Status)
 
-	updated, err := c.orderUseCase.Update(ctx, order)
+	updated, err := c.orderUseCase.Update(ctx, order, actor)
 	if err != nil {
 		return dto.OrderDAO{}, err
 	}
 
-	// Notify other services about status change if status changed
-	newStatus := string(updated.Status)
-	if oldStatus != newStatus {
-		go func() {
-			if err := c.httpGateway.NotifyOperationService(context.Background(), updated.Entity.ID, newStatus); err != nil {
-				log.Printf("Failed to notify operation service for order %s status change: %v", updated.Entity.ID, err)
-			}
-		}()
+	return presenter.FromEntityToDAO(updated), nil
+}
+
+func (c *Controller) Cancel(ctx context.Context, id string, reason enum.CancellationReason, actor entity.Actor) (dto.OrderDAO, error) {
+	presenter := presenter.Build()
+
+	cancelled, err := c.orderUseCase.Cancel(ctx, id, reason, actor)
+	if err != nil {
+		return dto.OrderDAO{}, err
 	}
 
-	return presenter.FromEntityToDAO(updated), nil
+	return presenter.FromEntityToDAO(cancelled), nil
+}
+
+func (c *Controller) Reorder(ctx context.Context, id string, customerID string) (dto.ReorderResponseDTO, error) {
+	presenter := presenter.Build()
+
+	order, plan, err := c.orderUseCase.Reorder(ctx, id, customerID)
+	if err != nil {
+		return dto.ReorderResponseDTO{}, err
+	}
+
+	return presenter.FromReorderToResponseDTO(order, plan), nil
+}
+
+func (c *Controller) ExpireStaleOrders(ctx context.Context, window time.Duration) (int, error) {
+	expired, err := c.orderUseCase.ExpireStaleOrders(ctx, window)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(expired), nil
+}
+
+func (c *Controller) GetStatusHistory(ctx context.Context, id string) (dto.OrderHistoryResponseDTO, error) {
+	presenter := presenter.Build()
+
+	order, history, err := c.orderUseCase.GetStatusHistory(ctx, id)
+	if err != nil {
+		return dto.OrderHistoryResponseDTO{}, err
+	}
+
+	return presenter.FromHistoryToResponseDTO(order, history), nil
 }
@@ -2,25 +2,52 @@ package dto
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	orderentity "github.com/fiap-161/tc-golunch-core-service/internal/order/entity"
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
+	promotionentity "github.com/fiap-161/tc-golunch-core-service/internal/promotion/entity"
 	"github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
 )
 
 type CreateOrderDTO struct {
 	CustomerID string             `json:"customer_id"`
 	Products   []OrderProductInfo `json:"products"`
+	Combos     []OrderComboInfo   `json:"combos"`
+	Coupons    []string           `json:"coupons"`
 }
 
 type UpdateOrderDTO struct {
 	Status string `json:"status" binding:"required"`
 }
 
+// OrderProductInfo is a line of a new order. Modifiers are the IDs of the options chosen
+// among the modifier groups of the product; notes are free text for the kitchen.
 type OrderProductInfo struct {
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
+	ProductID string   `json:"product_id"`
+	Quantity  int      `json:"quantity"`
+	Modifiers []string `json:"modifiers"`
+	Notes     string   `json:"notes"`
+}
+
+const MaxLineNotesLength = 255
+
+// OrderComboInfo is a combo of a new order, with the product picked for each slot of the combo
+type OrderComboInfo struct {
+	ComboID    string               `json:"combo_id"`
+	Quantity   int                  `json:"quantity"`
+	Selections []ComboSelectionInfo `json:"selections"`
+}
+
+type ComboSelectionInfo struct {
+	SlotID    string   `json:"slot_id"`
+	ProductID string   `json:"product_id"`
+	Modifiers []string `json:"modifiers"`
+	Notes     string   `json:"notes"`
 }
 
 type OrderPanelDTO struct {
@@ -34,21 +61,196 @@ type OrderPanelItemDTO struct {
 	CreatedAt     time.Time `json:"created_at"`
 }
 
+const (
+	PanelEventSnapshot      = "snapshot"
+	PanelEventStatusChanged = "status_changed"
+)
+
+// OrderPanelEventDTO is a message of the panel stream. A snapshot carries the whole panel,
+// a status change carries the order that moved and the status it left.
+type OrderPanelEventDTO struct {
+	ID     uint64               `json:"id"`
+	Type   string               `json:"type"`
+	Panel  *OrderPanelDTO       `json:"panel,omitempty"`
+	Change *OrderPanelChangeDTO `json:"change,omitempty"`
+}
+
+type OrderPanelChangeDTO struct {
+	OrderID    string            `json:"order_id"`
+	FromStatus string            `json:"from_status,omitempty"`
+	Order      OrderPanelItemDTO `json:"order"`
+}
+
+type CancelOrderDTO struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
 type OrderDAO struct {
 	entity.Entity
-	CustomerID    string           `json:"customer_id" gorm:"index"`
-	Status        enum.OrderStatus `json:"status" gorm:"type:varchar(20)"`
-	Price         float64          `json:"price" gorm:"type:decimal(10,2)"`
-	PreparingTime uint             `json:"preparing_time" gorm:"type:integer"`
+	CustomerID         string                  `json:"customer_id" gorm:"index"`
+	StoreID            string                  `json:"store_id" gorm:"type:varchar(50);index"`
+	Number             uint                    `json:"order_number" gorm:"type:integer"`
+	Status             enum.OrderStatus        `json:"status" gorm:"type:varchar(20);index"`
+	Subtotal           money.Money             `json:"subtotal" gorm:"embedded;embeddedPrefix:subtotal_"`
+	Discount           money.Money             `json:"discount" gorm:"embedded;embeddedPrefix:discount_"`
+	Discounts          []DiscountDTO           `json:"discounts" gorm:"type:jsonb;serializer:json;not null;default:'[]'"`
+	Price              money.Money             `json:"price" gorm:"embedded;embeddedPrefix:price_"`
+	PreparingTime      uint                    `json:"preparing_time" gorm:"type:integer"`
+	CancellationReason enum.CancellationReason `json:"cancellation_reason,omitempty" gorm:"type:varchar(30)"`
+	Version            uint                    `json:"version" gorm:"not null;default:1"`
+}
+
+// DiscountDTO is a promotion applied to an order, stored as JSON in the discounts column of orders
+type DiscountDTO struct {
+	PromotionID string      `json:"promotion_id"`
+	Code        string      `json:"code,omitempty"`
+	Name        string      `json:"name"`
+	Amount      money.Money `json:"amount"`
+}
+
+// QuoteResponseDTO is what an order would cost if it were placed now: its lines as they would
+// be charged, the subtotal, the discounts taken off it, the total to pay and the estimated
+// preparation time in minutes
+type QuoteResponseDTO struct {
+	Items         []OrderItemDTO `json:"items"`
+	Subtotal      money.Money    `json:"subtotal"`
+	Discounts     []DiscountDTO  `json:"discounts"`
+	Discount      money.Money    `json:"discount"`
+	Total         money.Money    `json:"total"`
+	PreparingTime uint           `json:"preparing_time"`
+}
+
+// OrderNumberSequenceDAO is the counter order numbers are drawn from, one row per store and period
+type OrderNumberSequenceDAO struct {
+	StoreID    string `gorm:"type:varchar(50);primaryKey"`
+	Period     string `gorm:"type:varchar(20);primaryKey"`
+	LastNumber uint   `gorm:"type:integer"`
+	UpdatedAt  time.Time
+}
+
+func (OrderNumberSequenceDAO) TableName() string {
+	return "order_number_sequences"
+}
+
+type OrderStatusHistoryDAO struct {
+	ID         string                  `json:"id" gorm:"type:uuid;primaryKey"`
+	OrderID    string                  `json:"order_id" gorm:"type:uuid;index"`
+	FromStatus enum.OrderStatus        `json:"from_status" gorm:"type:varchar(20)"`
+	ToStatus   enum.OrderStatus        `json:"to_status" gorm:"type:varchar(20)"`
+	ChangedBy  string                  `json:"changed_by"`
+	Source     enum.StatusChangeSource `json:"source" gorm:"type:varchar(20)"`
+	CreatedAt  time.Time               `json:"created_at" gorm:"index"`
+}
+
+func (OrderStatusHistoryDAO) TableName() string {
+	return "order_status_history"
+}
+
+type OrderStatusHistoryItemDTO struct {
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	ChangedBy  string    `json:"changed_by"`
+	Source     string    `json:"source"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+// OrderDurationsDTO holds stage durations in seconds, omitted while the stage is not finished
+type OrderDurationsDTO struct {
+	AwaitingPaymentSeconds *int64 `json:"awaiting_payment_seconds,omitempty"`
+	QueueSeconds           *int64 `json:"queue_seconds,omitempty"`
+	PreparationSeconds     *int64 `json:"preparation_seconds,omitempty"`
+	PickupSeconds          *int64 `json:"pickup_seconds,omitempty"`
+	TotalSeconds           *int64 `json:"total_seconds,omitempty"`
+}
+
+type OrderHistoryResponseDTO struct {
+	OrderID   string                      `json:"order_id"`
+	Status    string                      `json:"status"`
+	CreatedAt time.Time                   `json:"created_at"`
+	History   []OrderStatusHistoryItemDTO `json:"history"`
+	Durations OrderDurationsDTO           `json:"durations"`
+}
+
+type OrderItemDTO struct {
+	ProductID   string                 `json:"product_id"`
+	Name        string                 `json:"name"`
+	Category    string                 `json:"category"`
+	Quantity    int                    `json:"quantity"`
+	UnitPrice   money.Money            `json:"unit_price"`
+	LineTotal   money.Money            `json:"line_total"`
+	Modifiers   []OrderItemModifierDTO `json:"modifiers"`
+	Notes       string                 `json:"notes,omitempty"`
+	ComboID     string                 `json:"combo_id,omitempty"`
+	ComboItemID string                 `json:"combo_item_id,omitempty"`
+}
+
+type OrderItemModifierDTO struct {
+	OptionID   string      `json:"option_id"`
+	Group      string      `json:"group"`
+	Name       string      `json:"name"`
+	PriceDelta money.Money `json:"price_delta"`
+}
+
+type OrderResponseDTO struct {
+	OrderDAO
+	Items []OrderItemDTO `json:"items"`
+}
+
+type PriceChangeDTO struct {
+	ProductID     string      `json:"product_id"`
+	Name          string      `json:"name"`
+	PreviousPrice money.Money `json:"previous_price"`
+	CurrentPrice  money.Money `json:"current_price"`
+}
+
+type ReorderResponseDTO struct {
+	Order               OrderResponseDTO `json:"order"`
+	UnavailableProducts []string         `json:"unavailable_products"`
+	PriceChanges        []PriceChangeDTO `json:"price_changes"`
 }
 
 type OrderResponseListDTO struct {
-	Orders []OrderDAO `json:"orders"`
+	Orders     []OrderResponseDTO `json:"orders"`
+	Total      int64              `json:"total"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+// ListOrdersQueryDTO holds the query string of GET /order. Status accepts a comma separated list
+// and dates are RFC 3339 timestamps.
+type ListOrdersQueryDTO struct {
+	ID          string       `form:"id"`
+	Status      string       `form:"status"`
+	CustomerID  string       `form:"customer_id"`
+	CreatedFrom *time.Time   `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   *time.Time   `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	MinPrice    *money.Money `form:"min_price"`
+	MaxPrice    *money.Money `form:"max_price"`
+	Sort        string       `form:"sort"`
+	Order       string       `form:"order"`
+	Limit       int          `form:"limit"`
+	Cursor      string       `form:"cursor"`
+}
+
+// OrderListQuery is the listing query as seen by the datasource. After* are set from
+// page two on and hold the position of the last order of the previous page.
+type OrderListQuery struct {
+	Statuses       []string
+	CustomerID     string
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	MinPrice       *int64
+	MaxPrice       *int64
+	SortBy         string
+	Descending     bool
+	Limit          int
+	AfterCreatedAt *time.Time
+	AfterPrice     *int64
+	AfterID        string
 }
 
 type ProductDTO struct {
 	ID            string
-	Price         float64
+	Price         money.Money
 	PreparingTime uint
 }
 
@@ -57,8 +259,8 @@ type ProductOrderDTO struct{}
 type PaymentDTO struct{ QrCode string }
 
 func (c *CreateOrderDTO) Validate() error {
-	if len(c.Products) == 0 {
-		return errors.New("at least one product is required")
+	if len(c.Products) == 0 && len(c.Combos) == 0 {
+		return errors.New("at least one product or combo is required")
 	}
 	for _, v := range c.Products {
 		if v.ProductID == "" {
@@ -68,28 +270,109 @@ func (c *CreateOrderDTO) Validate() error {
 		if v.Quantity <= 0 {
 			return errors.New("product quantity must be greater than zero")
 		}
+
+		if utf8.RuneCountInString(v.Notes) > MaxLineNotesLength {
+			return fmt.Errorf("product notes must be at most %d characters", MaxLineNotesLength)
+		}
+	}
+	for _, code := range c.Coupons {
+		if strings.TrimSpace(code) == "" {
+			return errors.New("coupons must not contain empty values")
+		}
+	}
+	for _, combo := range c.Combos {
+		if combo.ComboID == "" {
+			return errors.New("combos must not contain empty values")
+		}
+
+		if combo.Quantity <= 0 {
+			return errors.New("combo quantity must be greater than zero")
+		}
+
+		for _, selection := range combo.Selections {
+			if selection.SlotID == "" || selection.ProductID == "" {
+				return errors.New("combo selections must have a slot and a product")
+			}
+
+			if utf8.RuneCountInString(selection.Notes) > MaxLineNotesLength {
+				return fmt.Errorf("product notes must be at most %d characters", MaxLineNotesLength)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *CancelOrderDTO) Validate() error {
+	if !enum.CancellationReason(c.Reason).IsRequestable() {
+		return errors.New("invalid cancellation reason")
 	}
 	return nil
 }
 
 func ToOrderDAO(order orderentity.Order) OrderDAO {
 	return OrderDAO{
-		Entity:        order.Entity,
-		CustomerID:    order.CustomerID,
-		Status:        order.Status,
-		Price:         order.Price,
-		PreparingTime: order.PreparingTime,
+		Entity:             order.Entity,
+		CustomerID:         order.CustomerID,
+		StoreID:            order.StoreID,
+		Number:             order.Number,
+		Status:             order.Status,
+		Subtotal:           order.Subtotal,
+		Discount:           order.Discount,
+		Discounts:          ToDiscountDTOList(order.Discounts),
+		Price:              order.Price,
+		PreparingTime:      order.PreparingTime,
+		CancellationReason: order.CancellationReason,
+		Version:            order.Version,
 	}
 }
 
 func FromOrderDAO(dao OrderDAO) orderentity.Order {
+	// Orders placed before discounts existed were charged their subtotal
+	subtotal := dao.Subtotal
+	if subtotal.IsZero() && dao.Discount.IsZero() {
+		subtotal = dao.Price
+	}
+
 	return orderentity.Order{
-		Entity:        dao.Entity,
-		CustomerID:    dao.CustomerID,
-		Status:        dao.Status,
-		Price:         dao.Price,
-		PreparingTime: dao.PreparingTime,
+		Entity:             dao.Entity,
+		CustomerID:         dao.CustomerID,
+		StoreID:            dao.StoreID,
+		Number:             dao.Number,
+		Status:             dao.Status,
+		Subtotal:           subtotal,
+		Discount:           dao.Discount,
+		Discounts:          FromDiscountDTOList(dao.Discounts),
+		Price:              dao.Price,
+		PreparingTime:      dao.PreparingTime,
+		CancellationReason: dao.CancellationReason,
+		Version:            dao.Version,
+	}
+}
+
+func ToDiscountDTOList(discounts []promotionentity.Discount) []DiscountDTO {
+	result := make([]DiscountDTO, 0, len(discounts))
+	for _, discount := range discounts {
+		result = append(result, DiscountDTO{
+			PromotionID: discount.PromotionID,
+			Code:        discount.Code,
+			Name:        discount.Name,
+			Amount:      discount.Amount,
+		})
 	}
+	return result
+}
+
+func FromDiscountDTOList(discounts []DiscountDTO) []promotionentity.Discount {
+	var result []promotionentity.Discount
+	for _, discount := range discounts {
+		result = append(result, promotionentity.Discount{
+			PromotionID: discount.PromotionID,
+			Code:        discount.Code,
+			Name:        discount.Name,
+			Amount:      discount.Amount,
+		})
+	}
+	return result
 }
 
 func FromCreateOrderDTO(dto CreateOrderDTO) orderentity.Order {
@@ -99,6 +382,67 @@ func FromCreateOrderDTO(dto CreateOrderDTO) orderentity.Order {
 	}
 }
 
+func ToListQuery(q ListOrdersQueryDTO) orderentity.ListQuery {
+	var statuses []enum.OrderStatus
+	for _, status := range strings.Split(q.Status, ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			statuses = append(statuses, enum.OrderStatus(status))
+		}
+	}
+
+	return orderentity.ListQuery{
+		Filter: orderentity.ListFilter{
+			Statuses:    statuses,
+			CustomerID:  q.CustomerID,
+			CreatedFrom: q.CreatedFrom,
+			CreatedTo:   q.CreatedTo,
+			MinPrice:    q.MinPrice,
+			MaxPrice:    q.MaxPrice,
+		},
+		SortBy: orderentity.SortField(q.Sort),
+		Order:  orderentity.SortOrder(q.Order),
+		Limit:  q.Limit,
+		Cursor: q.Cursor,
+	}
+}
+
+func ToOrderListQuery(query orderentity.ListQuery, after *orderentity.Cursor, limit int) OrderListQuery {
+	statuses := make([]string, 0, len(query.Filter.Statuses))
+	for _, status := range query.Filter.Statuses {
+		statuses = append(statuses, status.String())
+	}
+
+	listQuery := OrderListQuery{
+		Statuses:    statuses,
+		CustomerID:  query.Filter.CustomerID,
+		CreatedFrom: query.Filter.CreatedFrom,
+		CreatedTo:   query.Filter.CreatedTo,
+		MinPrice:    amountOf(query.Filter.MinPrice),
+		MaxPrice:    amountOf(query.Filter.MaxPrice),
+		SortBy:      string(query.SortBy),
+		Descending:  query.Order == orderentity.SortDescending,
+		Limit:       limit,
+	}
+	if after != nil {
+		listQuery.AfterID = after.ID
+		switch after.SortBy {
+		case orderentity.SortByPrice:
+			listQuery.AfterPrice = &after.Price
+		default:
+			listQuery.AfterCreatedAt = &after.CreatedAt
+		}
+	}
+	return listQuery
+}
+
+// amountOf compares prices in cents, all orders of a store share its currency
+func amountOf(price *money.Money) *int64 {
+	if price == nil {
+		return nil
+	}
+	return &price.Amount
+}
+
 func EntityListFromDAOList(daoList []OrderDAO) []orderentity.Order {
 	orders := make([]orderentity.Order, 0, len(daoList))
 	for _, dao := range daoList {
@@ -106,3 +450,35 @@ func EntityListFromDAOList(daoList []OrderDAO) []orderentity.Order {
 	}
 	return orders
 }
+
+func ToOrderStatusHistoryDAO(change orderentity.StatusChange) OrderStatusHistoryDAO {
+	return OrderStatusHistoryDAO{
+		ID:         change.ID,
+		OrderID:    change.OrderID,
+		FromStatus: change.FromStatus,
+		ToStatus:   change.ToStatus,
+		ChangedBy:  change.ChangedBy,
+		Source:     change.Source,
+		CreatedAt:  change.CreatedAt,
+	}
+}
+
+func FromOrderStatusHistoryDAO(dao OrderStatusHistoryDAO) orderentity.StatusChange {
+	return orderentity.StatusChange{
+		ID:         dao.ID,
+		OrderID:    dao.OrderID,
+		FromStatus: dao.FromStatus,
+		ToStatus:   dao.ToStatus,
+		ChangedBy:  dao.ChangedBy,
+		Source:     dao.Source,
+		CreatedAt:  dao.CreatedAt,
+	}
+}
+
+func StatusChangeListFromDAOList(daoList []OrderStatusHistoryDAO) []orderentity.StatusChange {
+	changes := make([]orderentity.StatusChange, 0, len(daoList))
+	for _, dao := range daoList {
+		changes = append(changes, FromOrderStatusHistoryDAO(dao))
+	}
+	return changes
+}
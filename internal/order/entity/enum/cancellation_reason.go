@@ -0,0 +1,34 @@
+package enum
+
+type CancellationReason string
+
+const (
+	CancellationReasonCustomerRequest CancellationReason = "customer_request"
+	CancellationReasonWrongOrder      CancellationReason = "wrong_order"
+	CancellationReasonOutOfStock      CancellationReason = "out_of_stock"
+	CancellationReasonOther           CancellationReason = "other"
+	CancellationReasonPaymentRejected CancellationReason = "payment_rejected"
+	CancellationReasonPaymentExpired  CancellationReason = "payment_expired"
+)
+
+// RequestableCancellationReasons are the reasons accepted from customers and admins.
+// Payment related reasons are only set by the payment webhook and the expiry sweeper.
+var RequestableCancellationReasons = []CancellationReason{
+	CancellationReasonCustomerRequest,
+	CancellationReasonWrongOrder,
+	CancellationReasonOutOfStock,
+	CancellationReasonOther,
+}
+
+func (r CancellationReason) IsRequestable() bool {
+	for _, reason := range RequestableCancellationReasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+func (r CancellationReason) String() string {
+	return string(r)
+}
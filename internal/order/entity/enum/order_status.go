@@ -8,6 +8,8 @@ const (
 	OrderStatusInPreparation   OrderStatus = "in_preparation"
 	OrderStatusReady           OrderStatus = "ready"
 	OrderStatusCompleted       OrderStatus = "completed"
+	OrderStatusCancelled       OrderStatus = "cancelled"
+	OrderStatusExpired         OrderStatus = "expired"
 )
 
 var OrderPanelStatus = []string{
@@ -16,12 +18,35 @@ var OrderPanelStatus = []string{
 	OrderStatusReady.String(),
 }
 
+var OrderClosedStatus = []string{
+	OrderStatusCompleted.String(),
+	OrderStatusCancelled.String(),
+	OrderStatusExpired.String(),
+}
+
 var StatusMapper = map[string]OrderStatus{
 	OrderStatusAwaitingPayment.String(): OrderStatusAwaitingPayment,
 	OrderStatusReceived.String():        OrderStatusReceived,
 	OrderStatusInPreparation.String():   OrderStatusInPreparation,
 	OrderStatusReady.String():           OrderStatusReady,
 	OrderStatusCompleted.String():       OrderStatusCompleted,
+	OrderStatusCancelled.String():       OrderStatusCancelled,
+	OrderStatusExpired.String():         OrderStatusExpired,
+}
+
+// NotifiesOperation reports whether the operation service must be told when an order reaches this status
+func (o OrderStatus) NotifiesOperation() bool {
+	switch o {
+	case OrderStatusReceived, OrderStatusInPreparation, OrderStatusReady, OrderStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+func (o OrderStatus) IsValid() bool {
+	_, ok := StatusMapper[o.String()]
+	return ok
 }
 
 func (o OrderStatus) String() string {
@@ -0,0 +1,15 @@
+package enum
+
+type StatusChangeSource string
+
+const (
+	StatusChangeSourceAdmin    StatusChangeSource = "admin"
+	StatusChangeSourceCustomer StatusChangeSource = "customer"
+	StatusChangeSourceWebhook  StatusChangeSource = "webhook"
+	StatusChangeSourceService  StatusChangeSource = "service"
+	StatusChangeSourceSystem   StatusChangeSource = "system"
+)
+
+func (s StatusChangeSource) String() string {
+	return string(s)
+}
@@ -1,20 +1,36 @@
 package entity
 
 import (
+	"fmt"
 	"time"
 
+	comboentity "github.com/fiap-161/tc-golunch-core-service/internal/combo/entity"
+
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
 	productentity "github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	productorderentity "github.com/fiap-161/tc-golunch-core-service/internal/productorder/entity"
+	promotionentity "github.com/fiap-161/tc-golunch-core-service/internal/promotion/entity"
 	"github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
 	"github.com/google/uuid"
 )
 
 type Order struct {
 	entity.Entity
-	CustomerID    string           `json:"customer_id" gorm:"index"`
-	Status        enum.OrderStatus `json:"status" gorm:"type:varchar(20)"`
-	Price         float64          `json:"price" gorm:"type:decimal(10,2)"`
-	PreparingTime uint             `json:"preparing_time" gorm:"type:integer"`
+	CustomerID         string                     `json:"customer_id" gorm:"index"`
+	StoreID            string                     `json:"store_id"`
+	Number             uint                       `json:"order_number"`
+	Status             enum.OrderStatus           `json:"status" gorm:"type:varchar(20)"`
+	Subtotal           money.Money                `json:"subtotal" gorm:"embedded;embeddedPrefix:subtotal_"`
+	Discount           money.Money                `json:"discount" gorm:"embedded;embeddedPrefix:discount_"`
+	Discounts          []promotionentity.Discount `json:"discounts,omitempty" gorm:"-"`
+	Price              money.Money                `json:"price" gorm:"embedded;embeddedPrefix:price_"`
+	PreparingTime      uint                       `json:"preparing_time" gorm:"type:integer"`
+	CancellationReason enum.CancellationReason    `json:"cancellation_reason,omitempty" gorm:"type:varchar(30)"`
+	Items              []OrderItem                `json:"items,omitempty" gorm:"-"`
+	Version            uint                       `json:"version"`
 }
 
 func (o Order) Build() Order {
@@ -26,39 +42,163 @@ func (o Order) Build() Order {
 		},
 		CustomerID:    o.CustomerID,
 		Status:        o.Status,
+		Subtotal:      o.Subtotal,
+		Discount:      o.Discount,
+		Discounts:     o.Discounts,
 		Price:         o.Price,
 		PreparingTime: o.PreparingTime,
+		Version:       1,
 	}
 }
 
 type OrderProductInfo struct {
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
+	ProductID string   `json:"product_id"`
+	Quantity  int      `json:"quantity"`
+	Modifiers []string `json:"modifiers"`
+	Notes     string   `json:"notes"`
 }
 
-func (o Order) FromDTO(customerID string, products []OrderProductInfo, allProducts []productentity.Product) Order {
-	totalPrice, preparingTime := o.getOrderInfoFromProducts(allProducts, products)
+// FromDTO prices the order and its product lines. Each line resolves the modifiers chosen for
+// its product, whose price deltas are part of the unit price of the line. Combos are expanded
+// into one line per slot, see comboLines.
+func (o Order) FromDTO(
+	customerID string,
+	products []OrderProductInfo,
+	combos []OrderComboInfo,
+	allProducts []productentity.Product,
+	allCombos []comboentity.Combo,
+) (Order, []productorderentity.ProductOrder, error) {
+	lines, preparingTime, err := o.getOrderInfoFromProducts(allProducts, products)
+	if err != nil {
+		return Order{}, nil, err
+	}
+
+	combosByID := make(map[string]comboentity.Combo, len(allCombos))
+	for _, combo := range allCombos {
+		combosByID[combo.ID] = combo
+	}
+	for _, item := range combos {
+		combo, ok := combosByID[item.ComboID]
+		if !ok {
+			return Order{}, nil, &apperror.NotFoundError{Msg: fmt.Sprintf("Combo %s not found", item.ComboID)}
+		}
+		expanded, comboPreparingTime, err := comboLines(item, combo, allProducts)
+		if err != nil {
+			return Order{}, nil, err
+		}
+		lines = append(lines, expanded...)
+		preparingTime += comboPreparingTime
+	}
+
+	// Totals are summed in cents; products priced in different currencies cannot share an order
+	var totalPrice money.Money
+	for _, line := range lines {
+		totalPrice, err = totalPrice.Add(line.UnitPrice.Multiply(int64(line.Quantity)))
+		if err != nil {
+			return Order{}, nil, err
+		}
+	}
 
 	return Order{
 		CustomerID:    customerID,
+		Subtotal:      totalPrice,
 		Price:         totalPrice,
 		PreparingTime: preparingTime,
 		Status:        enum.OrderStatusAwaitingPayment,
+	}, lines, nil
+}
+
+// WithDiscounts takes the discounts off the subtotal of the order; Price is what the customer pays
+func (o Order) WithDiscounts(discounts []promotionentity.Discount) (Order, error) {
+	var discount money.Money
+	for _, applied := range discounts {
+		var err error
+		discount, err = discount.Add(applied.Amount)
+		if err != nil {
+			return Order{}, err
+		}
+	}
+	if discount.Amount > o.Subtotal.Amount {
+		return Order{}, &apperror.ValidationError{Msg: "discounts cannot exceed the subtotal of the order"}
 	}
+
+	o.Discounts = discounts
+	o.Discount = money.New(discount.Amount, o.Subtotal.Currency)
+	o.Price = money.New(o.Subtotal.Amount-discount.Amount, o.Subtotal.Currency)
+	return o, nil
 }
 
-func (o Order) getOrderInfoFromProducts(products []productentity.Product, orderProducts []OrderProductInfo) (float64, uint) {
-	var totalPrice float64
+// PromotionLines describes the lines of an order to the promotion engine, which needs the
+// category of each product
+func PromotionLines(lines []productorderentity.ProductOrder, products []productentity.Product) []promotionentity.Line {
+	categories := make(map[string]productenum.Category, len(products))
+	for _, product := range products {
+		categories[product.Id] = product.Category
+	}
+
+	result := make([]promotionentity.Line, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, promotionentity.Line{
+			ProductID: line.ProductID,
+			Category:  categories[line.ProductID],
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice,
+		})
+	}
+	return result
+}
+
+func (o Order) getOrderInfoFromProducts(products []productentity.Product, orderProducts []OrderProductInfo) ([]productorderentity.ProductOrder, uint, error) {
+	var lines []productorderentity.ProductOrder
 	var preparingTime uint
 
 	for _, item := range orderProducts {
 		for _, product := range products {
-			if product.Id == item.ProductID {
-				totalPrice += product.Price * float64(item.Quantity)
-				preparingTime += product.PreparingTime
+			if product.Id != item.ProductID {
+				continue
+			}
+
+			line, err := newLine(product, product.Price, item.Quantity, item.Modifiers, item.Notes)
+			if err != nil {
+				return nil, 0, err
 			}
+			lines = append(lines, line)
+			preparingTime += product.PreparingTime
+		}
+	}
+
+	return lines, preparingTime, nil
+}
+
+// newLine builds a line of the product at basePrice plus the deltas of the modifiers chosen
+func newLine(product productentity.Product, basePrice money.Money, quantity int, modifierIDs []string, notes string) (productorderentity.ProductOrder, error) {
+	selected, err := product.SelectModifiers(modifierIDs)
+	if err != nil {
+		return productorderentity.ProductOrder{}, err
+	}
+
+	unitPrice := basePrice
+	modifiers := make([]productorderentity.Modifier, 0, len(selected))
+	for _, modifier := range selected {
+		unitPrice, err = unitPrice.Add(modifier.PriceDelta)
+		if err != nil {
+			return productorderentity.ProductOrder{}, err
 		}
+		modifiers = append(modifiers, productorderentity.Modifier{
+			OptionID:   modifier.OptionID,
+			GroupName:  modifier.GroupName,
+			Name:       modifier.Name,
+			PriceDelta: modifier.PriceDelta,
+		})
 	}
 
-	return totalPrice, preparingTime
+	return productorderentity.ProductOrder{
+		ProductID:   product.Id,
+		ProductName: product.Name,
+		Category:    product.Category,
+		Quantity:    quantity,
+		UnitPrice:   unitPrice,
+		Modifiers:   modifiers,
+		Notes:       notes,
+	}, nil
 }
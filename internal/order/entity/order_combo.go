@@ -0,0 +1,61 @@
+package entity
+
+import (
+	comboentity "github.com/fiap-161/tc-golunch-core-service/internal/combo/entity"
+	productentity "github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+	productorderentity "github.com/fiap-161/tc-golunch-core-service/internal/productorder/entity"
+	"github.com/google/uuid"
+)
+
+// OrderComboInfo is a combo of a new order with the product picked for each of its slots
+type OrderComboInfo struct {
+	ComboID    string
+	Quantity   int
+	Selections []ComboSelection
+}
+
+type ComboSelection struct {
+	SlotID    string
+	ProductID string
+	Modifiers []string
+	Notes     string
+}
+
+// comboLines expands a combo into one line per slot, all sharing a combo item ID. The combo price
+// is allocated across the products so revenue is still attributed per product, and the modifiers
+// chosen for a product are charged on top of its share.
+func comboLines(item OrderComboInfo, combo comboentity.Combo, products []productentity.Product) ([]productorderentity.ProductOrder, uint, error) {
+	selections := make([]comboentity.Selection, 0, len(item.Selections))
+	bySlot := make(map[string]ComboSelection, len(item.Selections))
+	for _, selection := range item.Selections {
+		selections = append(selections, comboentity.Selection{SlotID: selection.SlotID, ProductID: selection.ProductID})
+		bySlot[selection.SlotID] = selection
+	}
+
+	filled, err := combo.Fill(selections, products)
+	if err != nil {
+		return nil, 0, err
+	}
+	shares, err := combo.Allocate(filled)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	itemID := uuid.NewString()
+	lines := make([]productorderentity.ProductOrder, 0, len(filled))
+	var preparingTime uint
+	for i, slot := range combo.Slots {
+		selection := bySlot[slot.ID]
+		line, err := newLine(filled[i], shares[i], item.Quantity, selection.Modifiers, selection.Notes)
+		if err != nil {
+			return nil, 0, err
+		}
+		line.ComboID = combo.ID
+		line.ComboItemID = itemID
+		line.ComboSlotID = slot.ID
+		lines = append(lines, line)
+		preparingTime += filled[i].PreparingTime
+	}
+
+	return lines, preparingTime, nil
+}
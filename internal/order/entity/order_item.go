@@ -0,0 +1,71 @@
+package entity
+
+import (
+	inventoryentity "github.com/fiap-161/tc-golunch-core-service/internal/inventory/entity"
+	productentity "github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	productorderentity "github.com/fiap-161/tc-golunch-core-service/internal/productorder/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+)
+
+// OrderItem is a product line of an order as charged at checkout
+type OrderItem struct {
+	ProductID string
+	Name      string
+	Category  productenum.Category
+	Quantity  int
+	UnitPrice money.Money
+	Modifiers []productorderentity.Modifier
+	Notes     string
+	// ComboID and ComboItemID are set on the lines of a combo, see OrderComboInfo
+	ComboID     string
+	ComboItemID string
+}
+
+func (i OrderItem) LineTotal() money.Money {
+	return i.UnitPrice.Multiply(int64(i.Quantity))
+}
+
+// BuildItems joins the stored product lines with the product catalog. The price, name and category
+// come from the line; the catalog only names lines stored before they were copied onto it, and
+// a product that no longer exists leaves those lines with an empty name and category.
+func BuildItems(lines []productorderentity.ProductOrder, products []productentity.Product) []OrderItem {
+	productsByID := make(map[string]productentity.Product, len(products))
+	for _, product := range products {
+		productsByID[product.Id] = product
+	}
+
+	items := make([]OrderItem, 0, len(lines))
+	for _, line := range lines {
+		name, category := line.ProductName, line.Category
+		if name == "" {
+			product := productsByID[line.ProductID]
+			name, category = product.Name, product.Category
+		}
+		items = append(items, OrderItem{
+			ProductID:   line.ProductID,
+			Name:        name,
+			Category:    category,
+			Quantity:    line.Quantity,
+			UnitPrice:   line.UnitPrice,
+			Modifiers:   line.Modifiers,
+			Notes:       line.Notes,
+			ComboID:     line.ComboID,
+			ComboItemID: line.ComboItemID,
+		})
+	}
+	return items
+}
+
+// StockRequests tells the inventory how many units of each product the items take
+func StockRequests(items []OrderItem) []inventoryentity.Request {
+	requests := make([]inventoryentity.Request, 0, len(items))
+	for _, item := range items {
+		requests = append(requests, inventoryentity.Request{
+			ProductID: item.ProductID,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+		})
+	}
+	return inventoryentity.MergeRequests(requests)
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"testing"
+
+	productentity "github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	productorderentity "github.com/fiap-161/tc-golunch-core-service/internal/productorder/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildItems(t *testing.T) {
+	lines := []productorderentity.ProductOrder{
+		{ProductID: "burger", OrderID: "order-1", Quantity: 2, UnitPrice: money.FromCents(2550)},
+		{ProductID: "removed", OrderID: "order-1", Quantity: 1, UnitPrice: money.FromCents(800)},
+	}
+	products := []productentity.Product{
+		{Id: "burger", Name: "X-Burger", Category: productenum.Meal, Price: money.FromCents(3000)},
+	}
+
+	t.Run("Given stored lines, when items are built, then the line price is kept over the current product price", func(t *testing.T) {
+		items := BuildItems(lines, products)
+
+		assert.Equal(t, OrderItem{
+			ProductID: "burger",
+			Name:      "X-Burger",
+			Category:  productenum.Meal,
+			Quantity:  2,
+			UnitPrice: money.FromCents(2550),
+		}, items[0])
+		assert.Equal(t, money.FromCents(5100), items[0].LineTotal())
+	})
+
+	t.Run("Given a line whose product no longer exists, when items are built, then the line is kept without product data", func(t *testing.T) {
+		items := BuildItems(lines, products)
+
+		assert.Len(t, items, 2)
+		assert.Equal(t, OrderItem{ProductID: "removed", Quantity: 1, UnitPrice: money.FromCents(800)}, items[1])
+	})
+
+	t.Run("Given a line with the product name copied on it, when the product was renamed, then the name of the line is kept", func(t *testing.T) {
+		snapshot := []productorderentity.ProductOrder{
+			{ProductID: "burger", ProductName: "Cheeseburger", Category: productenum.Meal, Quantity: 1, UnitPrice: money.FromCents(2550)},
+		}
+
+		items := BuildItems(snapshot, products)
+
+		assert.Equal(t, "Cheeseburger", items[0].Name)
+		assert.Equal(t, productenum.Meal, items[0].Category)
+	})
+}
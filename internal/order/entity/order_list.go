@@ -0,0 +1,160 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+type SortField string
+
+const (
+	SortByCreatedAt SortField = "created_at"
+	SortByPrice     SortField = "price"
+)
+
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+type ListFilter struct {
+	Statuses    []enum.OrderStatus
+	CustomerID  string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MinPrice    *money.Money
+	MaxPrice    *money.Money
+}
+
+// ListQuery describes one page of the order listing. Cursor is the opaque value returned
+// as the next cursor of the previous page, empty for the first page.
+type ListQuery struct {
+	Filter ListFilter
+	SortBy SortField
+	Order  SortOrder
+	Limit  int
+	Cursor string
+}
+
+// Cursor marks the last order of a page. Pages are keyed on the sort column with the ID
+// as tie breaker, so orders sharing a timestamp or price are never skipped or repeated.
+type Cursor struct {
+	SortBy    SortField `json:"s"`
+	CreatedAt time.Time `json:"c"`
+	Price     int64     `json:"p"`
+	ID        string    `json:"i"`
+}
+
+type Page struct {
+	Orders     []Order
+	Total      int64
+	NextCursor string
+}
+
+// Normalize fills the defaults of an empty query and rejects inconsistent filters
+func (q ListQuery) Normalize() (ListQuery, error) {
+	if q.SortBy == "" {
+		q.SortBy = SortByCreatedAt
+	}
+	if q.Order == "" {
+		q.Order = SortDescending
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultPageSize
+	}
+
+	if q.SortBy != SortByCreatedAt && q.SortBy != SortByPrice {
+		return ListQuery{}, &apperror.ValidationError{Msg: fmt.Sprintf("cannot sort orders by %s", q.SortBy)}
+	}
+	if q.Order != SortAscending && q.Order != SortDescending {
+		return ListQuery{}, &apperror.ValidationError{Msg: "sort order must be asc or desc"}
+	}
+	if q.Limit < 0 || q.Limit > MaxPageSize {
+		return ListQuery{}, &apperror.ValidationError{Msg: fmt.Sprintf("limit must be between 1 and %d", MaxPageSize)}
+	}
+	for _, status := range q.Filter.Statuses {
+		if !status.IsValid() {
+			return ListQuery{}, &apperror.ValidationError{Msg: fmt.Sprintf("unknown order status %s", status)}
+		}
+	}
+	if q.Filter.CreatedFrom != nil && q.Filter.CreatedTo != nil && q.Filter.CreatedFrom.After(*q.Filter.CreatedTo) {
+		return ListQuery{}, &apperror.ValidationError{Msg: "created_from must not be after created_to"}
+	}
+	if q.Filter.MinPrice != nil && q.Filter.MaxPrice != nil && q.Filter.MinPrice.Amount > q.Filter.MaxPrice.Amount {
+		return ListQuery{}, &apperror.ValidationError{Msg: "min_price must not be greater than max_price"}
+	}
+
+	return q, nil
+}
+
+// After decodes the cursor of the query, nil on the first page. A cursor issued for
+// another sort column is rejected since its position means nothing in this ordering.
+func (q ListQuery) After() (*Cursor, error) {
+	if q.Cursor == "" {
+		return nil, nil
+	}
+
+	cursor, err := DecodeCursor(q.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.SortBy != q.SortBy {
+		return nil, &apperror.ValidationError{Msg: "cursor does not match the requested sort"}
+	}
+	return &cursor, nil
+}
+
+func NewCursor(sortBy SortField, order Order) Cursor {
+	return Cursor{
+		SortBy:    sortBy,
+		CreatedAt: order.CreatedAt,
+		Price:     order.Price.Amount,
+		ID:        order.ID,
+	}
+}
+
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(encoded string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, &apperror.ValidationError{Msg: "invalid cursor"}
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == "" {
+		return Cursor{}, &apperror.ValidationError{Msg: "invalid cursor"}
+	}
+	return cursor, nil
+}
+
+// NewPage builds a page from orders fetched with one row more than the limit,
+// the extra row only signals that a next page exists.
+func NewPage(orders []Order, total int64, query ListQuery) Page {
+	if len(orders) <= query.Limit {
+		return Page{Orders: orders, Total: total}
+	}
+
+	orders = orders[:query.Limit]
+	return Page{
+		Orders:     orders,
+		Total:      total,
+		NextCursor: NewCursor(query.SortBy, orders[len(orders)-1]).Encode(),
+	}
+}
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListQueryNormalize(t *testing.T) {
+	t.Run("Given an empty query, when it is normalized, then the newest orders come first with the default page size", func(t *testing.T) {
+		query, err := ListQuery{}.Normalize()
+
+		assert.NoError(t, err)
+		assert.Equal(t, ListQuery{SortBy: SortByCreatedAt, Order: SortDescending, Limit: DefaultPageSize}, query)
+	})
+
+	minPrice, maxPrice := money.FromCents(5000), money.FromCents(1000)
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+
+	tests := []struct {
+		name  string
+		query ListQuery
+	}{
+		{name: "Given an unknown sort column, when it is normalized, then it is rejected", query: ListQuery{SortBy: "customer_id"}},
+		{name: "Given an unknown sort order, when it is normalized, then it is rejected", query: ListQuery{Order: "up"}},
+		{name: "Given a limit above the maximum, when it is normalized, then it is rejected", query: ListQuery{Limit: MaxPageSize + 1}},
+		{name: "Given an unknown status, when it is normalized, then it is rejected", query: ListQuery{Filter: ListFilter{Statuses: []enum.OrderStatus{"lost"}}}},
+		{name: "Given an inverted date range, when it is normalized, then it is rejected", query: ListQuery{Filter: ListFilter{CreatedFrom: &from, CreatedTo: &to}}},
+		{name: "Given an inverted price range, when it is normalized, then it is rejected", query: ListQuery{Filter: ListFilter{MinPrice: &minPrice, MaxPrice: &maxPrice}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.query.Normalize()
+
+			var validationErr *apperror.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+		})
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	orders := []Order{
+		{Entity: entity.Entity{ID: "order-3", CreatedAt: createdAt.Add(2 * time.Minute)}, Price: money.FromCents(3000)},
+		{Entity: entity.Entity{ID: "order-2", CreatedAt: createdAt.Add(time.Minute)}, Price: money.FromCents(2000)},
+		{Entity: entity.Entity{ID: "order-1", CreatedAt: createdAt}, Price: money.FromCents(1000)},
+	}
+	query := ListQuery{SortBy: SortByCreatedAt, Order: SortDescending, Limit: 2}
+
+	t.Run("Given one row more than the limit, when the page is built, then it is trimmed and points past its last order", func(t *testing.T) {
+		page := NewPage(orders, 7, query)
+
+		assert.Len(t, page.Orders, 2)
+		assert.Equal(t, int64(7), page.Total)
+
+		next, err := ListQuery{SortBy: SortByCreatedAt, Cursor: page.NextCursor}.After()
+		assert.NoError(t, err)
+		assert.Equal(t, "order-2", next.ID)
+		assert.True(t, next.CreatedAt.Equal(orders[1].CreatedAt))
+	})
+
+	t.Run("Given the last rows, when the page is built, then there is no next cursor", func(t *testing.T) {
+		page := NewPage(orders[:2], 2, query)
+
+		assert.Len(t, page.Orders, 2)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("Given a cursor issued for another sort, when it is decoded, then it is rejected", func(t *testing.T) {
+		page := NewPage(orders, 3, query)
+
+		_, err := ListQuery{SortBy: SortByPrice, Cursor: page.NextCursor}.After()
+
+		var validationErr *apperror.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("Given a tampered cursor, when it is decoded, then it is rejected", func(t *testing.T) {
+		_, err := ListQuery{SortBy: SortByCreatedAt, Cursor: "not-a-cursor"}.After()
+
+		var validationErr *apperror.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
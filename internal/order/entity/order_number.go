@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type NumberReset string
+
+const (
+	NumberResetDaily   NumberReset = "daily"
+	NumberResetWeekly  NumberReset = "weekly"
+	NumberResetMonthly NumberReset = "monthly"
+	NumberResetNever   NumberReset = "never"
+)
+
+// Numbering decides which counter an order number is drawn from. Numbers restart at 1 for
+// every store whenever the reset schedule starts a new period. ResetHour moves the start of
+// a period so a store open past midnight keeps counting until it closes.
+type Numbering struct {
+	StoreID   string
+	Reset     NumberReset
+	ResetHour int
+	Location  *time.Location
+}
+
+func NewNumbering(storeID string, reset string, resetHour int, timezone string) (Numbering, error) {
+	if storeID == "" {
+		return Numbering{}, fmt.Errorf("store id is required")
+	}
+
+	numberReset := NumberReset(reset)
+	switch numberReset {
+	case NumberResetDaily, NumberResetWeekly, NumberResetMonthly, NumberResetNever:
+	default:
+		return Numbering{}, fmt.Errorf("unknown order number reset %q", reset)
+	}
+
+	if resetHour < 0 || resetHour > 23 {
+		return Numbering{}, fmt.Errorf("order number reset hour must be between 0 and 23")
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return Numbering{}, fmt.Errorf("invalid order number timezone %q: %w", timezone, err)
+	}
+
+	return Numbering{StoreID: storeID, Reset: numberReset, ResetHour: resetHour, Location: location}, nil
+}
+
+// Period names the counter period t belongs to
+func (n Numbering) Period(t time.Time) string {
+	local := t.In(n.Location).Add(-time.Duration(n.ResetHour) * time.Hour)
+
+	switch n.Reset {
+	case NumberResetWeekly:
+		year, week := local.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case NumberResetMonthly:
+		return local.Format("2006-01")
+	case NumberResetNever:
+		return "all"
+	default:
+		return local.Format("2006-01-02")
+	}
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumberingPeriod(t *testing.T) {
+	// 01:30 on a Monday in São Paulo, still Sunday night's service when the day starts at 04:00
+	at := time.Date(2025, 3, 10, 4, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		reset     string
+		resetHour int
+		want      string
+	}{
+		{name: "Given a daily reset at midnight, when the period is computed, then it is the local date", reset: "daily", want: "2025-03-10"},
+		{name: "Given a daily reset at 04:00, when an order is placed before it, then it counts for the previous day", reset: "daily", resetHour: 4, want: "2025-03-09"},
+		{name: "Given a weekly reset, when the period is computed, then it is the ISO week", reset: "weekly", want: "2025-W11"},
+		{name: "Given a monthly reset, when the period is computed, then it is the local month", reset: "monthly", want: "2025-03"},
+		{name: "Given no reset, when the period is computed, then there is a single period", reset: "never", want: "all"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			numbering, err := NewNumbering("store-1", tt.reset, tt.resetHour, "America/Sao_Paulo")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, numbering.Period(at))
+		})
+	}
+}
+
+func TestNewNumbering(t *testing.T) {
+	t.Run("Given an unknown reset schedule, when numbering is built, then it fails", func(t *testing.T) {
+		_, err := NewNumbering("store-1", "hourly", 0, "UTC")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Given a reset hour out of range, when numbering is built, then it fails", func(t *testing.T) {
+		_, err := NewNumbering("store-1", "daily", 24, "UTC")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"testing"
+
+	comboentity "github.com/fiap-161/tc-golunch-core-service/internal/combo/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
+	productentity "github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	promotionentity "github.com/fiap-161/tc-golunch-core-service/internal/promotion/entity"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderFromDTO(t *testing.T) {
+	products := []productentity.Product{
+		{
+			Id:            "burger",
+			Name:          "X-Burger",
+			Price:         money.FromCents(3000),
+			PreparingTime: 10,
+			ModifierGroups: []productentity.ModifierGroup{{
+				ID:      "extras",
+				Name:    "Extras",
+				Options: []productentity.ModifierOption{{ID: "cheese", Name: "Extra cheese", PriceDelta: money.FromCents(350)}},
+			}},
+		},
+		{Id: "soda", Name: "Soda", Category: productenum.Drink, Price: money.FromCents(600), PreparingTime: 1},
+	}
+
+	t.Run("Given lines with modifiers and notes, when the order is built, then the modifiers are priced into the lines and the total", func(t *testing.T) {
+		order, lines, err := Order{}.FromDTO("customer-1", []OrderProductInfo{
+			{ProductID: "burger", Quantity: 2, Modifiers: []string{"cheese"}, Notes: "no onions"},
+			{ProductID: "burger", Quantity: 1},
+			{ProductID: "soda", Quantity: 1},
+		}, nil, products, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, money.FromCents(2*3350+3000+600), order.Price)
+		assert.Equal(t, uint(21), order.PreparingTime)
+		assert.Equal(t, enum.OrderStatusAwaitingPayment, order.Status)
+
+		assert.Len(t, lines, 3)
+		assert.Equal(t, money.FromCents(3350), lines[0].UnitPrice)
+		assert.Equal(t, money.FromCents(3000), lines[0].BasePrice())
+		assert.Equal(t, "no onions", lines[0].Notes)
+		assert.Equal(t, []string{"cheese"}, lines[0].OptionIDs())
+		assert.Equal(t, money.FromCents(3000), lines[1].UnitPrice)
+	})
+
+	t.Run("Given a modifier the product does not offer, when the order is built, then it is rejected", func(t *testing.T) {
+		_, _, err := Order{}.FromDTO("customer-1", []OrderProductInfo{
+			{ProductID: "soda", Quantity: 1, Modifiers: []string{"cheese"}},
+		}, nil, products, nil)
+
+		var validationErr *apperror.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("Given a combo, when the order is built, then it is expanded into lines sharing the combo price plus modifiers", func(t *testing.T) {
+		combos := []comboentity.Combo{{
+			Name:   "Burger + drink",
+			Price:  money.FromCents(3000),
+			Active: true,
+			Slots: []comboentity.Slot{
+				{ID: "main", Name: "Burger", ProductIDs: []string{"burger"}},
+				{ID: "drink", Name: "Drink", Categories: []productenum.Category{productenum.Drink}},
+			},
+		}}
+		combos[0].ID = "lunch"
+
+		order, lines, err := Order{}.FromDTO("customer-1", nil, []OrderComboInfo{{
+			ComboID:  "lunch",
+			Quantity: 2,
+			Selections: []ComboSelection{
+				{SlotID: "main", ProductID: "burger", Modifiers: []string{"cheese"}},
+				{SlotID: "drink", ProductID: "soda"},
+			},
+		}}, products, combos)
+
+		assert.NoError(t, err)
+		assert.Len(t, lines, 2)
+		assert.Equal(t, money.FromCents(2*(3000+350)), order.Price)
+		shares, _ := lines[0].BasePrice().Add(lines[1].BasePrice())
+		assert.Equal(t, money.FromCents(3000), shares)
+		assert.Equal(t, lines[0].ComboItemID, lines[1].ComboItemID)
+		assert.Equal(t, "lunch", lines[0].ComboID)
+		assert.Equal(t, "drink", lines[1].ComboSlotID)
+	})
+
+	t.Run("Given an unknown combo, when the order is built, then it is not found", func(t *testing.T) {
+		_, _, err := Order{}.FromDTO("customer-1", nil, []OrderComboInfo{{ComboID: "gone", Quantity: 1}}, products, nil)
+
+		var notFoundErr *apperror.NotFoundError
+		assert.ErrorAs(t, err, &notFoundErr)
+	})
+}
+
+func TestOrderWithDiscounts(t *testing.T) {
+	order := Order{Subtotal: money.FromCents(5000), Price: money.FromCents(5000)}
+
+	t.Run("Given discounts, when they are applied, then the price is the subtotal minus the discounts", func(t *testing.T) {
+		discounted, err := order.WithDiscounts([]promotionentity.Discount{
+			{PromotionID: "a", Amount: money.FromCents(1000)},
+			{PromotionID: "b", Amount: money.FromCents(250)},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, money.FromCents(5000), discounted.Subtotal)
+		assert.Equal(t, money.FromCents(1250), discounted.Discount)
+		assert.Equal(t, money.FromCents(3750), discounted.Price)
+	})
+
+	t.Run("Given discounts above the subtotal, when they are applied, then they are rejected", func(t *testing.T) {
+		_, err := order.WithDiscounts([]promotionentity.Discount{{PromotionID: "a", Amount: money.FromCents(5001)}})
+
+		var validationErr *apperror.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
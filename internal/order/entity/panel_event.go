@@ -0,0 +1,11 @@
+package entity
+
+import "github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
+
+// PanelEvent is a change of the order panel. FromStatus is empty for a newly placed order.
+// ID is assigned by the broadcaster and increases with every event.
+type PanelEvent struct {
+	ID         uint64
+	Order      Order
+	FromStatus enum.OrderStatus
+}
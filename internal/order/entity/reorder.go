@@ -0,0 +1,148 @@
+package entity
+
+import (
+	"strings"
+
+	comboentity "github.com/fiap-161/tc-golunch-core-service/internal/combo/entity"
+	productentity "github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+	productorderentity "github.com/fiap-161/tc-golunch-core-service/internal/productorder/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+)
+
+type PriceChange struct {
+	ProductID     string
+	Name          string
+	PreviousPrice money.Money
+	CurrentPrice  money.Money
+}
+
+// ReorderPlan is what can be ordered again out of a previous order. Unavailable and PriceChanges
+// refer to products, or to combos for the lines that came from a combo.
+type ReorderPlan struct {
+	Products     []OrderProductInfo
+	Combos       []OrderComboInfo
+	Unavailable  []string
+	PriceChanges []PriceChange
+}
+
+// PlanReorder matches the lines of a previous order against the current catalog. Lines of the same
+// product with the same modifiers and notes are merged. Products no longer in the catalog, or that
+// no longer offer the modifiers chosen, are left out and reported as unavailable, and products whose
+// price moved since the previous order are reported with both prices. Combo lines are put back
+// together into their combos, see planCombos.
+func PlanReorder(lines []productorderentity.ProductOrder, products []productentity.Product, combos []comboentity.Combo) ReorderPlan {
+	productsByID := make(map[string]productentity.Product, len(products))
+	for _, product := range products {
+		productsByID[product.Id] = product
+	}
+
+	plan := ReorderPlan{}
+	positions := make(map[string]int, len(lines))
+	reported := make(map[string]bool, len(lines))
+	var fromCombos []productorderentity.ProductOrder
+	for _, line := range lines {
+		if line.ComboItemID != "" {
+			fromCombos = append(fromCombos, line)
+			continue
+		}
+
+		product, ok := productsByID[line.ProductID]
+		if ok {
+			_, modifiersErr := product.SelectModifiers(line.OptionIDs())
+			ok = modifiersErr == nil
+		}
+		if !ok {
+			if !reported[line.ProductID] {
+				reported[line.ProductID] = true
+				plan.Unavailable = append(plan.Unavailable, line.ProductID)
+			}
+			continue
+		}
+
+		if !product.Price.Equal(line.BasePrice()) && !reported[line.ProductID] {
+			reported[line.ProductID] = true
+			plan.PriceChanges = append(plan.PriceChanges, PriceChange{
+				ProductID:     product.Id,
+				Name:          product.Name,
+				PreviousPrice: line.BasePrice(),
+				CurrentPrice:  product.Price,
+			})
+		}
+
+		key := strings.Join(append([]string{line.ProductID, line.Notes}, line.OptionIDs()...), "\x00")
+		if i, ok := positions[key]; ok {
+			plan.Products[i].Quantity += line.Quantity
+			continue
+		}
+		positions[key] = len(plan.Products)
+		plan.Products = append(plan.Products, OrderProductInfo{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			Modifiers: line.OptionIDs(),
+			Notes:     line.Notes,
+		})
+	}
+
+	planCombos(&plan, fromCombos, products, combos, reported)
+	return plan
+}
+
+// planCombos rebuilds every combo item of the previous order from its lines. A combo that is gone,
+// inactive or can no longer be filled with the same products and modifiers is reported as
+// unavailable; a combo whose price moved is reported with the price paid and the current one.
+func planCombos(plan *ReorderPlan, lines []productorderentity.ProductOrder, products []productentity.Product, combos []comboentity.Combo, reported map[string]bool) {
+	combosByID := make(map[string]comboentity.Combo, len(combos))
+	for _, combo := range combos {
+		combosByID[combo.ID] = combo
+	}
+
+	var itemIDs []string
+	linesByItem := make(map[string][]productorderentity.ProductOrder)
+	for _, line := range lines {
+		if _, ok := linesByItem[line.ComboItemID]; !ok {
+			itemIDs = append(itemIDs, line.ComboItemID)
+		}
+		linesByItem[line.ComboItemID] = append(linesByItem[line.ComboItemID], line)
+	}
+
+	for _, itemID := range itemIDs {
+		itemLines := linesByItem[itemID]
+		comboID := itemLines[0].ComboID
+
+		item := OrderComboInfo{ComboID: comboID, Quantity: itemLines[0].Quantity}
+		var previousPrice money.Money
+		for _, line := range itemLines {
+			item.Selections = append(item.Selections, ComboSelection{
+				SlotID:    line.ComboSlotID,
+				ProductID: line.ProductID,
+				Modifiers: line.OptionIDs(),
+				Notes:     line.Notes,
+			})
+			previousPrice, _ = previousPrice.Add(line.BasePrice())
+		}
+
+		combo, ok := combosByID[comboID]
+		if ok {
+			_, _, err := comboLines(item, combo, products)
+			ok = err == nil
+		}
+		if !ok {
+			if !reported[comboID] {
+				reported[comboID] = true
+				plan.Unavailable = append(plan.Unavailable, comboID)
+			}
+			continue
+		}
+
+		if !combo.Price.Equal(previousPrice) && !reported[comboID] {
+			reported[comboID] = true
+			plan.PriceChanges = append(plan.PriceChanges, PriceChange{
+				ProductID:     comboID,
+				Name:          combo.Name,
+				PreviousPrice: previousPrice,
+				CurrentPrice:  combo.Price,
+			})
+		}
+		plan.Combos = append(plan.Combos, item)
+	}
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"testing"
+
+	productentity "github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+	productorderentity "github.com/fiap-161/tc-golunch-core-service/internal/productorder/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanReorder(t *testing.T) {
+	products := []productentity.Product{
+		{Id: "burger", Name: "X-Burger", Price: money.FromCents(3000)},
+		{Id: "soda", Name: "Soda", Price: money.FromCents(600)},
+	}
+
+	t.Run("Given an order whose products are unchanged, when it is planned, then every line is ordered again", func(t *testing.T) {
+		lines := []productorderentity.ProductOrder{
+			{ProductID: "burger", Quantity: 2, UnitPrice: money.FromCents(3000)},
+			{ProductID: "soda", Quantity: 1, UnitPrice: money.FromCents(600)},
+		}
+
+		assert.Equal(t, ReorderPlan{
+			Products: []OrderProductInfo{{ProductID: "burger", Quantity: 2}, {ProductID: "soda", Quantity: 1}},
+		}, PlanReorder(lines, products, nil))
+	})
+
+	t.Run("Given removed and re-priced products, when it is planned, then they are reported", func(t *testing.T) {
+		lines := []productorderentity.ProductOrder{
+			{ProductID: "burger", Quantity: 1, UnitPrice: money.FromCents(2500)},
+			{ProductID: "fries", Quantity: 1, UnitPrice: money.FromCents(1000)},
+			{ProductID: "soda", Quantity: 1, UnitPrice: money.FromCents(600)},
+		}
+
+		assert.Equal(t, ReorderPlan{
+			Products:     []OrderProductInfo{{ProductID: "burger", Quantity: 1}, {ProductID: "soda", Quantity: 1}},
+			Unavailable:  []string{"fries"},
+			PriceChanges: []PriceChange{{ProductID: "burger", Name: "X-Burger", PreviousPrice: money.FromCents(2500), CurrentPrice: money.FromCents(3000)}},
+		}, PlanReorder(lines, products, nil))
+	})
+
+	t.Run("Given several lines of the same product, when it is planned, then they are merged", func(t *testing.T) {
+		lines := []productorderentity.ProductOrder{
+			{ProductID: "soda", Quantity: 1, UnitPrice: money.FromCents(600)},
+			{ProductID: "soda", Quantity: 2, UnitPrice: money.FromCents(600)},
+		}
+
+		assert.Equal(t, ReorderPlan{
+			Products: []OrderProductInfo{{ProductID: "soda", Quantity: 3}},
+		}, PlanReorder(lines, products, nil))
+	})
+}
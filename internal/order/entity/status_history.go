@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
+	"github.com/google/uuid"
+)
+
+// Actor identifies who triggered an order status change
+type Actor struct {
+	ID     string
+	Source enum.StatusChangeSource
+}
+
+// StatusChange is one entry of the order status timeline
+type StatusChange struct {
+	ID         string
+	OrderID    string
+	FromStatus enum.OrderStatus
+	ToStatus   enum.OrderStatus
+	ChangedBy  string
+	Source     enum.StatusChangeSource
+	CreatedAt  time.Time
+}
+
+func NewStatusChange(orderID string, from, to enum.OrderStatus, actor Actor) StatusChange {
+	return StatusChange{
+		ID:         uuid.NewString(),
+		OrderID:    orderID,
+		FromStatus: from,
+		ToStatus:   to,
+		ChangedBy:  actor.ID,
+		Source:     actor.Source,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// Durations holds how long an order spent in each stage. A nil field means the stage was not reached yet.
+type Durations struct {
+	AwaitingPayment *time.Duration
+	Queue           *time.Duration
+	Preparation     *time.Duration
+	Pickup          *time.Duration
+	Total           *time.Duration
+}
+
+// ComputeDurations derives the stage durations of an order from its status history.
+// The order creation time is used as the start of the awaiting payment stage.
+func ComputeDurations(order Order, history []StatusChange) Durations {
+	enteredAt := map[enum.OrderStatus]time.Time{
+		enum.OrderStatusAwaitingPayment: order.CreatedAt,
+	}
+	for _, change := range history {
+		if _, seen := enteredAt[change.ToStatus]; !seen {
+			enteredAt[change.ToStatus] = change.CreatedAt
+		}
+	}
+
+	between := func(from, to enum.OrderStatus) *time.Duration {
+		start, hasStart := enteredAt[from]
+		end, hasEnd := enteredAt[to]
+		if !hasStart || !hasEnd {
+			return nil
+		}
+		d := end.Sub(start)
+		return &d
+	}
+
+	return Durations{
+		AwaitingPayment: between(enum.OrderStatusAwaitingPayment, enum.OrderStatusReceived),
+		Queue:           between(enum.OrderStatusReceived, enum.OrderStatusInPreparation),
+		Preparation:     between(enum.OrderStatusInPreparation, enum.OrderStatusReady),
+		Pickup:          between(enum.OrderStatusReady, enum.OrderStatusCompleted),
+		Total:           between(enum.OrderStatusAwaitingPayment, enum.OrderStatusCompleted),
+	}
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeDurations(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	order := Order{Entity: entity.Entity{ID: "order-1", CreatedAt: createdAt}}
+	at := func(minutes int) time.Time { return createdAt.Add(time.Duration(minutes) * time.Minute) }
+	minutes := func(m int) *time.Duration {
+		d := time.Duration(m) * time.Minute
+		return &d
+	}
+
+	t.Run("Given a completed order, when durations are computed, then every stage is filled", func(t *testing.T) {
+		history := []StatusChange{
+			{ToStatus: enum.OrderStatusReceived, CreatedAt: at(2)},
+			{ToStatus: enum.OrderStatusInPreparation, CreatedAt: at(5)},
+			{ToStatus: enum.OrderStatusReady, CreatedAt: at(17)},
+			{ToStatus: enum.OrderStatusCompleted, CreatedAt: at(20)},
+		}
+
+		assert.Equal(t, Durations{
+			AwaitingPayment: minutes(2),
+			Queue:           minutes(3),
+			Preparation:     minutes(12),
+			Pickup:          minutes(3),
+			Total:           minutes(20),
+		}, ComputeDurations(order, history))
+	})
+
+	t.Run("Given an order in preparation, when durations are computed, then unfinished stages are nil", func(t *testing.T) {
+		history := []StatusChange{
+			{ToStatus: enum.OrderStatusReceived, CreatedAt: at(1)},
+			{ToStatus: enum.OrderStatusInPreparation, CreatedAt: at(4)},
+		}
+
+		assert.Equal(t, Durations{
+			AwaitingPayment: minutes(1),
+			Queue:           minutes(3),
+		}, ComputeDurations(order, history))
+	})
+}
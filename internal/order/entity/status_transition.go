@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+)
+
+// allowedTransitions is the order lifecycle: every status maps to the statuses it may move to.
+// Final statuses (completed, cancelled, expired) have no outgoing transitions.
+var allowedTransitions = map[enum.OrderStatus][]enum.OrderStatus{
+	enum.OrderStatusAwaitingPayment: {enum.OrderStatusReceived, enum.OrderStatusCancelled, enum.OrderStatusExpired},
+	enum.OrderStatusReceived:        {enum.OrderStatusInPreparation, enum.OrderStatusCancelled},
+	enum.OrderStatusInPreparation:   {enum.OrderStatusReady, enum.OrderStatusCancelled},
+	enum.OrderStatusReady:           {enum.OrderStatusCompleted},
+}
+
+// CanTransitionTo reports whether the order may move from its current status to next.
+// Keeping the same status is always allowed so retried updates stay idempotent.
+func (o Order) CanTransitionTo(next enum.OrderStatus) bool {
+	if o.Status == next {
+		return true
+	}
+	for _, allowed := range allowedTransitions[o.Status] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition returns an error when next is unknown or not reachable from the current status.
+func (o Order) ValidateTransition(next enum.OrderStatus) error {
+	if !next.IsValid() {
+		return &apperror.ValidationError{Msg: "invalid order status: " + next.String()}
+	}
+	if !o.CanTransitionTo(next) {
+		return &apperror.InvalidTransitionError{From: o.Status.String(), To: next.String()}
+	}
+	return nil
+}
+
+// IsFinal reports whether the order reached a status with no further transitions.
+func (o Order) IsFinal() bool {
+	return len(allowedTransitions[o.Status]) == 0
+}
+
+// IsCancellableByCustomer reports whether a customer may still cancel the order by themselves.
+// Once the kitchen starts preparing it only an admin can cancel.
+func (o Order) IsCancellableByCustomer() bool {
+	return o.Status == enum.OrderStatusAwaitingPayment || o.Status == enum.OrderStatusReceived
+}
+
+// Cancel moves the order to cancelled, recording why.
+func (o Order) Cancel(reason enum.CancellationReason) (Order, error) {
+	if err := o.ValidateTransition(enum.OrderStatusCancelled); err != nil {
+		return Order{}, err
+	}
+	o.Status = enum.OrderStatusCancelled
+	o.CancellationReason = reason
+	return o, nil
+}
+
+// Expire moves an order whose payment never arrived to expired.
+func (o Order) Expire() (Order, error) {
+	if err := o.ValidateTransition(enum.OrderStatusExpired); err != nil {
+		return Order{}, err
+	}
+	o.Status = enum.OrderStatusExpired
+	o.CancellationReason = enum.CancellationReasonPaymentExpired
+	return o, nil
+}
@@ -0,0 +1,106 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrder_ValidateTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    enum.OrderStatus
+		to      enum.OrderStatus
+		wantErr error
+	}{
+		{
+			name: "Given an awaiting payment order, when it is paid, then it moves to received",
+			from: enum.OrderStatusAwaitingPayment,
+			to:   enum.OrderStatusReceived,
+		},
+		{
+			name: "Given a received order, when the kitchen starts it, then it moves to in preparation",
+			from: enum.OrderStatusReceived,
+			to:   enum.OrderStatusInPreparation,
+		},
+		{
+			name: "Given an order in preparation, when it is done, then it moves to ready",
+			from: enum.OrderStatusInPreparation,
+			to:   enum.OrderStatusReady,
+		},
+		{
+			name: "Given a ready order, when it is picked up, then it moves to completed",
+			from: enum.OrderStatusReady,
+			to:   enum.OrderStatusCompleted,
+		},
+		{
+			name: "Given an awaiting payment order, when it is cancelled, then the transition is allowed",
+			from: enum.OrderStatusAwaitingPayment,
+			to:   enum.OrderStatusCancelled,
+		},
+		{
+			name: "Given an order, when the same status is applied again, then the transition is allowed",
+			from: enum.OrderStatusReceived,
+			to:   enum.OrderStatusReceived,
+		},
+		{
+			name:    "Given a completed order, when it goes back to awaiting payment, then it is rejected",
+			from:    enum.OrderStatusCompleted,
+			to:      enum.OrderStatusAwaitingPayment,
+			wantErr: &apperror.InvalidTransitionError{From: "completed", To: "awaiting_payment"},
+		},
+		{
+			name:    "Given a received order, when it skips to ready, then it is rejected",
+			from:    enum.OrderStatusReceived,
+			to:      enum.OrderStatusReady,
+			wantErr: &apperror.InvalidTransitionError{From: "received", To: "ready"},
+		},
+		{
+			name:    "Given a ready order, when it is cancelled, then it is rejected",
+			from:    enum.OrderStatusReady,
+			to:      enum.OrderStatusCancelled,
+			wantErr: &apperror.InvalidTransitionError{From: "ready", To: "cancelled"},
+		},
+		{
+			name:    "Given an order, when an unknown status is applied, then a validation error is returned",
+			from:    enum.OrderStatusReceived,
+			to:      enum.OrderStatus("paid"),
+			wantErr: &apperror.ValidationError{Msg: "invalid order status: paid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := Order{Status: tt.from}
+			assert.Equal(t, tt.wantErr, order.ValidateTransition(tt.to))
+		})
+	}
+}
+
+func TestOrder_IsFinal(t *testing.T) {
+	assert.True(t, Order{Status: enum.OrderStatusCompleted}.IsFinal())
+	assert.True(t, Order{Status: enum.OrderStatusCancelled}.IsFinal())
+	assert.False(t, Order{Status: enum.OrderStatusReady}.IsFinal())
+}
+
+func TestOrder_Cancel(t *testing.T) {
+	cancelled, err := Order{Status: enum.OrderStatusReceived}.Cancel(enum.CancellationReasonCustomerRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, enum.OrderStatusCancelled, cancelled.Status)
+	assert.Equal(t, enum.CancellationReasonCustomerRequest, cancelled.CancellationReason)
+
+	_, err = Order{Status: enum.OrderStatusCompleted}.Cancel(enum.CancellationReasonCustomerRequest)
+	assert.Equal(t, &apperror.InvalidTransitionError{From: "completed", To: "cancelled"}, err)
+}
+
+func TestOrder_Expire(t *testing.T) {
+	expired, err := Order{Status: enum.OrderStatusAwaitingPayment}.Expire()
+	assert.NoError(t, err)
+	assert.Equal(t, enum.OrderStatusExpired, expired.Status)
+	assert.Equal(t, enum.CancellationReasonPaymentExpired, expired.CancellationReason)
+
+	_, err = Order{Status: enum.OrderStatusReceived}.Expire()
+	assert.Equal(t, &apperror.InvalidTransitionError{From: "received", To: "expired"}, err)
+}
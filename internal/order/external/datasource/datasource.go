@@ -2,14 +2,19 @@ package datasource
 
 import (
 	"context"
+	"time"
 
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/dto"
 )
 
 type DataSource interface {
 	Create(ctx context.Context, order dto.OrderDAO) (dto.OrderDAO, error)
-	GetAll(ctx context.Context) ([]dto.OrderDAO, error)
+	List(ctx context.Context, query dto.OrderListQuery) ([]dto.OrderDAO, int64, error)
 	FindByID(ctx context.Context, id string) (dto.OrderDAO, error)
 	GetPanel(ctx context.Context) ([]dto.OrderDAO, error)
 	Update(ctx context.Context, order dto.OrderDAO) (dto.OrderDAO, error)
+	FindByStatusCreatedBefore(ctx context.Context, status string, before time.Time) ([]dto.OrderDAO, error)
+	NextNumber(ctx context.Context, storeID string, period string) (uint, error)
+	CreateStatusHistory(ctx context.Context, entry dto.OrderStatusHistoryDAO) error
+	FindStatusHistoryByOrderID(ctx context.Context, orderID string) ([]dto.OrderStatusHistoryDAO, error)
 }
@@ -2,8 +2,13 @@ package datasource
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/fiap-161/tc-golunch-core-service/database"
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
 	"gorm.io/gorm"
 )
 
@@ -18,6 +23,7 @@ type DB interface {
 	Updates(values any) *gorm.DB
 	Save(value any) *gorm.DB
 	Order(value any) *gorm.DB
+	Raw(sql string, values ...any) *gorm.DB
 }
 
 // GormDataSource implements DataSource interface using GORM
@@ -33,7 +39,7 @@ func New(db DB) DataSource {
 }
 
 func (g *GormDataSource) Create(ctx context.Context, order dto.OrderDAO) (dto.OrderDAO, error) {
-	tx := g.db.Create(&order)
+	tx := database.Conn(ctx, g.db).Create(&order)
 	if tx.Error != nil {
 		return dto.OrderDAO{}, tx.Error
 	}
@@ -41,20 +47,66 @@ func (g *GormDataSource) Create(ctx context.Context, order dto.OrderDAO) (dto.Or
 	return order, nil
 }
 
-func (g *GormDataSource) GetAll(ctx context.Context) ([]dto.OrderDAO, error) {
-	var orders []dto.OrderDAO
+// List returns one page of orders matching the query and the number of orders matching
+// its filters across all pages
+func (g *GormDataSource) List(ctx context.Context, query dto.OrderListQuery) ([]dto.OrderDAO, int64, error) {
+	filtered := database.Conn(ctx, g.db).Model(&dto.OrderDAO{})
+	if len(query.Statuses) > 0 {
+		filtered = filtered.Where("status IN ?", query.Statuses)
+	}
+	if query.CustomerID != "" {
+		filtered = filtered.Where("customer_id = ?", query.CustomerID)
+	}
+	if query.CreatedFrom != nil {
+		filtered = filtered.Where("created_at >= ?", *query.CreatedFrom)
+	}
+	if query.CreatedTo != nil {
+		filtered = filtered.Where("created_at <= ?", *query.CreatedTo)
+	}
+	if query.MinPrice != nil {
+		filtered = filtered.Where("price_amount >= ?", *query.MinPrice)
+	}
+	if query.MaxPrice != nil {
+		filtered = filtered.Where("price_amount <= ?", *query.MaxPrice)
+	}
+	filtered = filtered.Session(&gorm.Session{})
 
-	if err := g.db.Find(&orders).Error; err != nil {
-		return nil, err
+	var total int64
+	if err := filtered.Count(&total).Error; err != nil {
+		return nil, 0, err
 	}
 
-	return orders, nil
+	column, direction, comparison := "created_at", "ASC", ">"
+	if query.SortBy == "price" {
+		column = "price_amount"
+	}
+	if query.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	page := filtered
+	switch {
+	case query.AfterCreatedAt != nil:
+		page = page.Where(fmt.Sprintf("(created_at, id) %s (?, ?)", comparison), *query.AfterCreatedAt, query.AfterID)
+	case query.AfterPrice != nil:
+		page = page.Where(fmt.Sprintf("(price_amount, id) %s (?, ?)", comparison), *query.AfterPrice, query.AfterID)
+	}
+
+	var orders []dto.OrderDAO
+	if err := page.
+		Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).
+		Limit(query.Limit).
+		Find(&orders).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return orders, total, nil
 }
 
 func (g *GormDataSource) FindByID(ctx context.Context, id string) (dto.OrderDAO, error) {
 	var order dto.OrderDAO
 
-	tx := g.db.First(&order, "id = ?", id)
+	tx := database.Conn(ctx, g.db).First(&order, "id = ?", id)
 	if tx.Error != nil {
 		return dto.OrderDAO{}, tx.Error
 	}
@@ -65,8 +117,8 @@ func (g *GormDataSource) FindByID(ctx context.Context, id string) (dto.OrderDAO,
 func (g *GormDataSource) GetPanel(ctx context.Context) ([]dto.OrderDAO, error) {
 	var orders []dto.OrderDAO
 
-	if err := g.db.
-		Where("status != ?", "completed").
+	if err := database.Conn(ctx, g.db).
+		Where("status NOT IN ?", enum.OrderClosedStatus).
 		Order(`
 			CASE 
 				WHEN status = 'ready' THEN 1
@@ -83,11 +135,79 @@ func (g *GormDataSource) GetPanel(ctx context.Context) ([]dto.OrderDAO, error) {
 	return orders, nil
 }
 
+// Update writes the order only if it still has the version it was read with, and bumps that version.
+// A write made in between by someone else fails with a ConflictError instead of being overwritten.
 func (g *GormDataSource) Update(ctx context.Context, order dto.OrderDAO) (dto.OrderDAO, error) {
-	tx := g.db.Save(&order)
+	tx := database.Conn(ctx, g.db).Model(&dto.OrderDAO{}).
+		Where("id = ? AND version = ?", order.ID, order.Version).
+		Updates(map[string]any{
+			"customer_id":         order.CustomerID,
+			"store_id":            order.StoreID,
+			"number":              order.Number,
+			"status":              order.Status,
+			"price_amount":        order.Price.Amount,
+			"price_currency":      order.Price.Currency,
+			"preparing_time":      order.PreparingTime,
+			"cancellation_reason": order.CancellationReason,
+			"updated_at":          order.UpdatedAt,
+			"version":             order.Version + 1,
+		})
 	if tx.Error != nil {
 		return dto.OrderDAO{}, tx.Error
 	}
+	if tx.RowsAffected == 0 {
+		return dto.OrderDAO{}, &apperror.ConflictError{Msg: "order was changed by another request, reload it and try again"}
+	}
 
+	order.Version++
 	return order, nil
 }
+
+func (g *GormDataSource) FindByStatusCreatedBefore(ctx context.Context, status string, before time.Time) ([]dto.OrderDAO, error) {
+	var orders []dto.OrderDAO
+
+	if err := database.Conn(ctx, g.db).
+		Where("status = ? AND created_at < ?", status, before).
+		Order("created_at ASC").
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// NextNumber increments the counter of the store for the period and returns the new value. The upsert
+// takes a row lock, so concurrent orders on any replica never draw the same number.
+func (g *GormDataSource) NextNumber(ctx context.Context, storeID string, period string) (uint, error) {
+	var number uint
+
+	err := database.Conn(ctx, g.db).Raw(`
+		INSERT INTO order_number_sequences (store_id, period, last_number, updated_at)
+		VALUES (?, ?, 1, NOW())
+		ON CONFLICT (store_id, period)
+		DO UPDATE SET last_number = order_number_sequences.last_number + 1, updated_at = NOW()
+		RETURNING last_number
+	`, storeID, period).Scan(&number).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return number, nil
+}
+
+func (g *GormDataSource) CreateStatusHistory(ctx context.Context, entry dto.OrderStatusHistoryDAO) error {
+	return database.Conn(ctx, g.db).Create(&entry).Error
+}
+
+func (g *GormDataSource) FindStatusHistoryByOrderID(ctx context.Context, orderID string) ([]dto.OrderStatusHistoryDAO, error) {
+	var history []dto.OrderStatusHistoryDAO
+
+	if err := database.Conn(ctx, g.db).
+		Where("order_id = ?", orderID).
+		Order("created_at ASC").
+		Find(&history).Error; err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
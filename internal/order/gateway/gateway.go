@@ -2,9 +2,12 @@ package gateway
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/dto"
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/external/datasource"
 	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
 )
@@ -28,12 +31,13 @@ func (g *Gateway) Create(ctx context.Context, order entity.Order) (entity.Order,
 	return dto.FromOrderDAO(created), nil
 }
 
-func (g *Gateway) GetAll(ctx context.Context) ([]entity.Order, error) {
-	ordersDAO, err := g.Datasource.GetAll(ctx)
+// List fetches up to limit orders of the query positioned after the given cursor
+func (g *Gateway) List(ctx context.Context, query entity.ListQuery, after *entity.Cursor, limit int) ([]entity.Order, int64, error) {
+	ordersDAO, total, err := g.Datasource.List(ctx, dto.ToOrderListQuery(query, after, limit))
 	if err != nil {
-		return nil, &apperror.InternalError{Msg: err.Error()}
+		return nil, 0, &apperror.InternalError{Msg: err.Error()}
 	}
-	return dto.EntityListFromDAOList(ordersDAO), nil
+	return dto.EntityListFromDAOList(ordersDAO), total, nil
 }
 
 func (g *Gateway) GetPanel(ctx context.Context) ([]entity.Order, error) {
@@ -56,7 +60,42 @@ func (g *Gateway) Update(ctx context.Context, order entity.Order) (entity.Order,
 	orderDAO := dto.ToOrderDAO(order)
 	updated, err := g.Datasource.Update(ctx, orderDAO)
 	if err != nil {
+		var conflictErr *apperror.ConflictError
+		if errors.As(err, &conflictErr) {
+			return entity.Order{}, conflictErr
+		}
 		return entity.Order{}, &apperror.InternalError{Msg: err.Error()}
 	}
 	return dto.FromOrderDAO(updated), nil
 }
+
+func (g *Gateway) FindByStatusCreatedBefore(ctx context.Context, status enum.OrderStatus, before time.Time) ([]entity.Order, error) {
+	ordersDAO, err := g.Datasource.FindByStatusCreatedBefore(ctx, status.String(), before)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.EntityListFromDAOList(ordersDAO), nil
+}
+
+func (g *Gateway) NextNumber(ctx context.Context, storeID string, period string) (uint, error) {
+	number, err := g.Datasource.NextNumber(ctx, storeID, period)
+	if err != nil {
+		return 0, &apperror.InternalError{Msg: err.Error()}
+	}
+	return number, nil
+}
+
+func (g *Gateway) CreateStatusChange(ctx context.Context, change entity.StatusChange) error {
+	if err := g.Datasource.CreateStatusHistory(ctx, dto.ToOrderStatusHistoryDAO(change)); err != nil {
+		return &apperror.InternalError{Msg: err.Error()}
+	}
+	return nil
+}
+
+func (g *Gateway) FindStatusHistory(ctx context.Context, orderID string) ([]entity.StatusChange, error) {
+	historyDAO, err := g.Datasource.FindStatusHistoryByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.StatusChangeListFromDAOList(historyDAO), nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/controller"
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity"
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
 	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
 	"github.com/fiap-161/tc-golunch-core-service/internal/shared/helper"
@@ -27,10 +28,13 @@ func New(controller *controller.Controller) *Handler {
 // @Security BearerAuth
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key header string false "Unique key of this order attempt. Retries with the same key and body return the first response instead of creating another order."
 // @Param        request body dto.CreateOrderDTO true "Order to create. Note that the customer_id is automatically set from the authenticated user."
 // @Success      200  {object}  map[string]any
 // @Failure      400  {object}  errors.ErrorDTO
 // @Failure      401  {object}  errors.ErrorDTO
+// @Failure      409  {object}  errors.ErrorDTO
+// @Failure      422  {object}  errors.ErrorDTO
 // @Router       /order/ [post]
 func (h *Handler) Create(c *gin.Context) {
 	var orderDTO dto.CreateOrderDTO
@@ -70,6 +74,47 @@ func (h *Handler) Create(c *gin.Context) {
 	})
 }
 
+// Quote Order godoc
+// @Summary      Quote Order
+// @Description  Price an order without placing it, through the same pricing as POST /order: products, modifiers, combos, coupons and promotions. Returns every line as it would be charged, the subtotal, the discounts, the total and the estimated preparation time in minutes. Nothing is saved and no coupon use is counted.
+// @Tags         Order Domain
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CreateOrderDTO true "Order to price"
+// @Success      200  {object}  dto.QuoteResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Router       /order/quote [post]
+func (h *Handler) Quote(c *gin.Context) {
+	var orderDTO dto.CreateOrderDTO
+	if err := c.ShouldBindJSON(&orderDTO); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "invalid request body",
+			MessageError: err.Error(),
+		})
+		return
+	}
+	if err := orderDTO.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "validation failed",
+			MessageError: err.Error(),
+		})
+		return
+	}
+	// Per-customer coupon limits are only checked when the customer is known
+	orderDTO.CustomerID = ""
+	if customerID, exists := c.Get("user_id"); exists {
+		orderDTO.CustomerID, _ = customerID.(string)
+	}
+
+	quote, err := h.controller.Quote(context.Background(), orderDTO)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, quote)
+}
+
 // Update Order godoc
 // @Summary      Update Order
 // @Description  Update an existing order status
@@ -78,11 +123,13 @@ func (h *Handler) Create(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "Order ID"
+// @Param        If-Match header string false "ETag of the order as last read; the update is rejected with 409 if the order changed since"
 // @Param        request body dto.UpdateOrderDTO true "Order status update"
 // @Success      204  "No Content"
 // @Failure      400  {object}  errors.ErrorDTO
 // @Failure      401  {object}  errors.ErrorDTO
 // @Failure      404  {object}  errors.ErrorDTO
+// @Failure      409  {object}  errors.ErrorDTO
 // @Router       /order/{id} [put]
 func (h *Handler) Update(c *gin.Context) {
 	id := c.Param("id")
@@ -99,38 +146,252 @@ func (h *Handler) Update(c *gin.Context) {
 		helper.HandleError(c, err)
 		return
 	}
+	expectedVersion, err := helper.IfMatchVersion(c)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+	if expectedVersion != nil {
+		orderDAO.Version = *expectedVersion
+	}
 	orderDAO.Status = enum.OrderStatus(orderUpdate.Status)
-	_, err = h.controller.Update(context.Background(), orderDAO)
+	updated, err := h.controller.Update(context.Background(), orderDAO, actorFromContext(c, enum.StatusChangeSourceAdmin))
 	if err != nil {
 		helper.HandleError(c, err)
 		return
 	}
+	c.Header("ETag", helper.ETag(updated.Version))
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// Cancel Order godoc
+// @Summary      Cancel Order
+// @Description  Cancel an order with a reason code (customer_request, wrong_order, out_of_stock, other). Customers can only cancel their own orders before preparation starts; admins can cancel any order that is not ready yet. Other services cancel through /service/order/{id}/cancel with their X-Service-Name and X-Service-Key headers.
+// @Tags         Order Domain
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Order ID"
+// @Param        request body dto.CancelOrderDTO true "Cancellation reason"
+// @Success      200  {object}  dto.OrderDAO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Failure      409  {object}  errors.ErrorDTO
+// @Router       /order/{id}/cancel [post]
+// @Router       /service/order/{id}/cancel [post]
+func (h *Handler) Cancel(c *gin.Context) {
+	id := c.Param("id")
+	var cancelDTO dto.CancelOrderDTO
+	if err := c.ShouldBindJSON(&cancelDTO); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "invalid request body",
+			MessageError: err.Error(),
+		})
+		return
+	}
+	if err := cancelDTO.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "validation failed",
+			MessageError: err.Error(),
+		})
+		return
+	}
+	actor := actorFromContext(c, "")
+	if actor.Source == "" {
+		c.JSON(http.StatusUnauthorized, apperror.ErrorDTO{
+			Message:      "unauthorized",
+			MessageError: "user id not found in context",
+		})
+		return
+	}
+
+	order, err := h.controller.Cancel(context.Background(), id, enum.CancellationReason(cancelDTO.Reason), actor)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
+
+// Reorder godoc
+// @Summary      Reorder
+// @Description  Place a new order with the products of a previous order of the authenticated customer, at current prices. Products that are no longer sold are left out and reported, as are products whose price changed.
+// @Tags         Order Domain
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Previous order ID"
+// @Success      201  {object}  dto.ReorderResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Failure      500  {object}  errors.ErrorDTO
+// @Router       /order/{id}/reorder [post]
+func (h *Handler) Reorder(c *gin.Context) {
+	id := c.Param("id")
+	customerID := c.GetString("user_id")
+	if customerID == "" {
+		c.JSON(http.StatusUnauthorized, apperror.ErrorDTO{
+			Message:      "unauthorized",
+			MessageError: "user id not found in context",
+		})
+		return
+	}
+
+	reorder, err := h.controller.Reorder(context.Background(), id, customerID)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, reorder)
+}
+
+// GetStatusHistory godoc
+// @Summary      Get Order Status History
+// @Description  Get the status timeline of an order (who changed it, from/to and source) and how long it spent in each stage
+// @Tags         Order Domain
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Order ID"
+// @Success      200  {object}  dto.OrderHistoryResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Failure      500  {object}  errors.ErrorDTO
+// @Router       /order/{id}/history [get]
+func (h *Handler) GetStatusHistory(c *gin.Context) {
+	id := c.Param("id")
+	history, err := h.controller.GetStatusHistory(context.Background(), id)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
 // GetAll godoc
 // @Summary      Get all orders
-// @Description  Retrieve a list of all orders, optionally filtered by ID
+// @Description  Retrieve a page of orders with their product lines. Pass the next_cursor of a page as cursor to fetch the following one.
 // @Tags         Order Domain
 // @Security     BearerAuth
 // @Accept       json
 // @Produce      json
-// @Param        id   query     string  false  "Optional order ID filter"
+// @Param        id            query  string  false  "Optional order ID filter"
+// @Param        status        query  string  false  "Comma separated statuses"
+// @Param        customer_id   query  string  false  "Customer ID"
+// @Param        created_from  query  string  false  "Created at or after (RFC 3339)"
+// @Param        created_to    query  string  false  "Created at or before (RFC 3339)"
+// @Param        min_price     query  string  false  "Minimum order price, as a decimal such as 10.50"
+// @Param        max_price     query  string  false  "Maximum order price, as a decimal such as 10.50"
+// @Param        sort          query  string  false  "Sort column: created_at (default) or price"
+// @Param        order         query  string  false  "Sort order: desc (default) or asc"
+// @Param        limit         query  int     false  "Page size, 20 by default and 100 at most"
+// @Param        cursor        query  string  false  "Cursor returned by the previous page"
 // @Success      200  {object}  dto.OrderResponseListDTO
 // @Failure      400  {object}  errors.ErrorDTO
 // @Failure      401  {object}  errors.ErrorDTO
 // @Failure      500  {object}  errors.ErrorDTO
 // @Router       /order/ [get]
 func (h *Handler) GetAll(c *gin.Context) {
-	id := c.Query("id")
-	orders, err := h.controller.GetAll(context.Background(), id)
+	var query dto.ListOrdersQueryDTO
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "invalid query parameters",
+			MessageError: err.Error(),
+		})
+		return
+	}
+
+	if query.ID != "" {
+		order, err := h.controller.GetByID(context.Background(), query.ID)
+		if err != nil {
+			helper.HandleError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, dto.OrderResponseListDTO{
+			Orders: []dto.OrderResponseDTO{order},
+			Total:  1,
+		})
+		return
+	}
+
+	orders, err := h.controller.List(context.Background(), query)
 	if err != nil {
 		helper.HandleError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, dto.OrderResponseListDTO{
-		Orders: orders,
+	c.JSON(http.StatusOK, orders)
+}
+
+// ListMine godoc
+// @Summary      List my orders
+// @Description  Retrieve the orders of the authenticated customer with their product lines, newest first
+// @Tags         Order Domain
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        status  query  string  false  "Comma separated statuses"
+// @Param        limit   query  int     false  "Page size, 20 by default and 100 at most"
+// @Param        cursor  query  string  false  "Cursor returned by the previous page"
+// @Success      200  {object}  dto.OrderResponseListDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Failure      500  {object}  errors.ErrorDTO
+// @Router       /customer/me/orders [get]
+func (h *Handler) ListMine(c *gin.Context) {
+	customerID := c.GetString("user_id")
+	if customerID == "" {
+		c.JSON(http.StatusUnauthorized, apperror.ErrorDTO{
+			Message:      "unauthorized",
+			MessageError: "user id not found in context",
+		})
+		return
+	}
+
+	var query dto.ListOrdersQueryDTO
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "invalid query parameters",
+			MessageError: err.Error(),
+		})
+		return
+	}
+
+	// Customers only ever see their own orders, in the default order
+	orders, err := h.controller.List(context.Background(), dto.ListOrdersQueryDTO{
+		Status:     query.Status,
+		CustomerID: customerID,
+		Limit:      query.Limit,
+		Cursor:     query.Cursor,
 	})
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, orders)
+}
+
+// GetByID godoc
+// @Summary      Get Order
+// @Description  Get a single order with its product lines (name, category, quantity, unit price and line total). The ETag header carries the order version for If-Match on updates.
+// @Tags         Order Domain
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Order ID"
+// @Success      200  {object}  dto.OrderResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Failure      500  {object}  errors.ErrorDTO
+// @Router       /order/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	order, err := h.controller.GetByID(context.Background(), id)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+	c.Header("ETag", helper.ETag(order.Version))
+	c.JSON(http.StatusOK, order)
 }
 
 // GetPanel Get Order Panel godoc
@@ -145,19 +406,31 @@ func (h *Handler) GetAll(c *gin.Context) {
 // @Failure      401  {object}  errors.ErrorDTO
 // @Router       /order/panel [get]
 func (h *Handler) GetPanel(c *gin.Context) {
-	orders, err := h.controller.GetPanel(context.Background())
+	panel, err := h.controller.GetPanel(context.Background())
 	if err != nil {
 		helper.HandleError(c, err)
 		return
 	}
-	panel := dto.OrderPanelDTO{Orders: []dto.OrderPanelItemDTO{}}
-	for _, order := range orders {
-		panel.Orders = append(panel.Orders, dto.OrderPanelItemDTO{
-			OrderNumber:   order.Entity.ID[len(order.Entity.ID)-4:],
-			Status:        string(order.Status),
-			PreparingTime: order.PreparingTime,
-			CreatedAt:     order.CreatedAt,
-		})
-	}
 	c.JSON(http.StatusOK, panel)
 }
+
+// actorFromContext identifies who is calling from the values set by the auth middlewares.
+// fallback is used as the source when the request carries no identity at all.
+func actorFromContext(c *gin.Context, fallback enum.StatusChangeSource) entity.Actor {
+	if service := c.GetString("authenticated_service"); service != "" {
+		return entity.Actor{ID: service, Source: enum.StatusChangeSourceService}
+	}
+	if adminID := c.GetString("admin_id"); adminID != "" {
+		return entity.Actor{ID: adminID, Source: enum.StatusChangeSourceAdmin}
+	}
+
+	userID := c.GetString("user_id")
+	if c.GetString("user_type") == "admin" {
+		return entity.Actor{ID: userID, Source: enum.StatusChangeSourceAdmin}
+	}
+	if userID != "" {
+		return entity.Actor{ID: userID, Source: enum.StatusChangeSourceCustomer}
+	}
+
+	return entity.Actor{Source: fallback}
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/controller"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/external/datasource"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/gateway"
+	productorderentity "github.com/fiap-161/tc-golunch-core-service/internal/productorder/entity"
+	sharedentity "github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryOrderDataSource filters orders by customer the way the persisted datasource does;
+// the methods the tests do not reach are left to the embedded interface
+type memoryOrderDataSource struct {
+	datasource.DataSource
+	orders []dto.OrderDAO
+}
+
+func (d *memoryOrderDataSource) List(_ context.Context, query dto.OrderListQuery) ([]dto.OrderDAO, int64, error) {
+	var found []dto.OrderDAO
+	for _, order := range d.orders {
+		if query.CustomerID != "" && order.CustomerID != query.CustomerID {
+			continue
+		}
+		found = append(found, order)
+	}
+	return found, int64(len(found)), nil
+}
+
+// emptyProductOrderService has no product lines for any order
+type emptyProductOrderService struct{}
+
+func (emptyProductOrderService) CreateBulk(_ context.Context, productOrders []productorderentity.ProductOrder) (int, error) {
+	return len(productOrders), nil
+}
+
+func (emptyProductOrderService) FindByOrderID(_ context.Context, _ string) ([]productorderentity.ProductOrder, error) {
+	return nil, nil
+}
+
+func (emptyProductOrderService) FindByOrderIDs(_ context.Context, _ []string) ([]productorderentity.ProductOrder, error) {
+	return nil, nil
+}
+
+func TestHandler_ListMine(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	createdAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	order := func(id, customerID string) dto.OrderDAO {
+		return dto.OrderDAO{
+			Entity:     sharedentity.Entity{ID: id, CreatedAt: createdAt, UpdatedAt: createdAt},
+			CustomerID: customerID,
+			Status:     enum.OrderStatusReceived,
+			Version:    1,
+		}
+	}
+	orderDataSource := &memoryOrderDataSource{orders: []dto.OrderDAO{
+		order("order-1", "customer-1"),
+		order("order-2", "customer-2"),
+		order("order-3", "customer-1"),
+	}}
+	orderController := controller.Build(
+		gateway.Build(orderDataSource),
+		nil, nil, nil, nil,
+		emptyProductOrderService{},
+		nil, nil, nil,
+		entity.Numbering{},
+	)
+	orderHandler := New(orderController)
+
+	setup := func(userID string) *gin.Engine {
+		router := gin.New()
+		router.GET("/customer/me/orders", func(c *gin.Context) {
+			if userID != "" {
+				c.Set("user_id", userID)
+			}
+			c.Next()
+		}, orderHandler.ListMine)
+		return router
+	}
+	get := func(router *gin.Engine, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w
+	}
+
+	t.Run("Given an authenticated customer, when listing their orders, then only that customer's orders are returned", func(t *testing.T) {
+		w := get(setup("customer-1"), "/customer/me/orders")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response dto.OrderResponseListDTO
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, int64(2), response.Total)
+		assert.Len(t, response.Orders, 2)
+		for _, o := range response.Orders {
+			assert.Equal(t, "customer-1", o.CustomerID)
+		}
+	})
+
+	t.Run("Given a customer_id in the query, when listing, then it is ignored in favour of the authenticated customer", func(t *testing.T) {
+		w := get(setup("customer-1"), "/customer/me/orders?customer_id=customer-2")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response dto.OrderResponseListDTO
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Orders, 2)
+		for _, o := range response.Orders {
+			assert.Equal(t, "customer-1", o.CustomerID)
+		}
+	})
+
+	t.Run("Given no authenticated customer, when listing, then it returns 401", func(t *testing.T) {
+		w := get(setup(""), "/customer/me/orders")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/dto"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/helper"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// panelHeartbeatInterval keeps idle streams from being closed by proxies
+const panelHeartbeatInterval = 15 * time.Second
+
+// StreamPanel godoc
+// @Summary      Stream Order Panel
+// @Description  Server-sent events for the order panel. The first event is a snapshot of the whole panel, followed by a status_changed event every time an order changes status. Reconnecting with the Last-Event-ID header (or the last_event_id query parameter) resumes after that event when possible, otherwise a new snapshot is sent.
+// @Tags         Order Domain
+// @Security     BearerAuth
+// @Produce      text/event-stream
+// @Param        Last-Event-ID  header  string  false  "ID of the last event received"
+// @Param        last_event_id  query   string  false  "ID of the last event received, for clients that cannot set headers"
+// @Success      200  {object}  dto.OrderPanelEventDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /order/panel/stream [get]
+func (h *Handler) StreamPanel(c *gin.Context) {
+	lastEventID, err := lastEventIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "invalid last event id",
+			MessageError: err.Error(),
+		})
+		return
+	}
+
+	feed, err := h.controller.SubscribePanel(c.Request.Context(), lastEventID)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+	defer feed.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	for _, event := range feed.Initial {
+		if err := writePanelEvent(c.Writer, event); err != nil {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(panelHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-feed.Events:
+			if !ok {
+				return
+			}
+			if err := writePanelEvent(c.Writer, event); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case <-heartbeat.C:
+			if _, err := io.WriteString(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// StreamPanelWebSocket godoc
+// @Summary      Stream Order Panel over WebSocket
+// @Description  WebSocket variant of /order/panel/stream. Every message is a JSON panel event; pass last_event_id to resume after a reconnect.
+// @Tags         Order Domain
+// @Security     BearerAuth
+// @Param        last_event_id  query  string  false  "ID of the last event received"
+// @Success      101  {object}  dto.OrderPanelEventDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /order/panel/ws [get]
+func (h *Handler) StreamPanelWebSocket(c *gin.Context) {
+	lastEventID, err := lastEventIDFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "invalid last event id",
+			MessageError: err.Error(),
+		})
+		return
+	}
+
+	feed, err := h.controller.SubscribePanel(c.Request.Context(), lastEventID)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+	defer feed.Close()
+
+	server := websocket.Server{
+		// Panel screens are plain displays and may connect from any origin, as with the SSE stream
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			// The client never sends anything, reading only tells when it went away
+			disconnected := make(chan struct{})
+			go func() {
+				defer close(disconnected)
+				var discard string
+				for websocket.Message.Receive(conn, &discard) == nil {
+				}
+			}()
+
+			for _, event := range feed.Initial {
+				if err := websocket.JSON.Send(conn, event); err != nil {
+					return
+				}
+			}
+
+			for {
+				select {
+				case <-disconnected:
+					return
+				case event, ok := <-feed.Events:
+					if !ok {
+						return
+					}
+					if err := websocket.JSON.Send(conn, event); err != nil {
+						return
+					}
+				}
+			}
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+func lastEventIDFromRequest(c *gin.Context) (*uint64, error) {
+	raw := c.GetHeader("Last-Event-ID")
+	if raw == "" {
+		raw = c.Query("last_event_id")
+	}
+	if raw == "" {
+		return nil, nil
+	}
+
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func writePanelEvent(w io.Writer, event dto.OrderPanelEventDTO) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
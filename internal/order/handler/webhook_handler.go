@@ -5,8 +5,10 @@ import (
 	"net/http"
 
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/controller"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity"
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
 	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/helper"
 	"github.com/gin-gonic/gin"
 )
 
@@ -19,6 +21,8 @@ type PaymentWebhookRequest struct {
 	Status  string `json:"status" binding:"required"`
 }
 
+var paymentWebhookActor = entity.Actor{ID: "payment-service", Source: enum.StatusChangeSourceWebhook}
+
 func NewWebhookHandler(controller *controller.Controller) *WebhookHandler {
 	return &WebhookHandler{controller: controller}
 }
@@ -29,10 +33,13 @@ func NewWebhookHandler(controller *controller.Controller) *WebhookHandler {
 // @Tags         Webhooks
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key header string false "Unique key of this delivery. Redeliveries with the same key and body return the first response."
 // @Param        request body PaymentWebhookRequest true "Payment status update"
 // @Success      200  {object}  map[string]any
 // @Failure      400  {object}  errors.ErrorDTO
 // @Failure      404  {object}  errors.ErrorDTO
+// @Failure      409  {object}  errors.ErrorDTO
+// @Failure      422  {object}  errors.ErrorDTO
 // @Router       /webhook/payment [post]
 func (h *WebhookHandler) PaymentWebhook(c *gin.Context) {
 	var webhookReq PaymentWebhookRequest
@@ -58,23 +65,21 @@ func (h *WebhookHandler) PaymentWebhook(c *gin.Context) {
 	var newStatus enum.OrderStatus
 	switch webhookReq.Status {
 	case "paid", "approved":
-		newStatus = enum.OrderStatusReceived // Move to received when payment is confirmed
+		// Move to received when payment is confirmed
+		newStatus = enum.OrderStatusReceived
+		orderDAO.Status = newStatus
+		_, err = h.controller.Update(context.Background(), orderDAO, paymentWebhookActor)
 	case "cancelled", "rejected":
-		newStatus = enum.OrderStatusAwaitingPayment // Keep awaiting payment for failed payments
+		// Failed payments close the order instead of leaving it awaiting payment forever
+		newStatus = enum.OrderStatusCancelled
+		_, err = h.controller.Cancel(context.Background(), orderDAO.ID, enum.CancellationReasonPaymentRejected, paymentWebhookActor)
 	default:
 		// Unknown status, ignore
 		c.JSON(http.StatusOK, gin.H{"message": "webhook received, status ignored"})
 		return
 	}
-
-	// Update the order with new status
-	orderDAO.Status = newStatus
-	_, err = h.controller.Update(context.Background(), orderDAO)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, apperror.ErrorDTO{
-			Message:      "failed to update order status",
-			MessageError: err.Error(),
-		})
+		helper.HandleError(c, err)
 		return
 	}
 
@@ -83,4 +88,4 @@ func (h *WebhookHandler) PaymentWebhook(c *gin.Context) {
 		"order_id": webhookReq.OrderID,
 		"status":   string(newStatus),
 	})
-}
\ No newline at end of file
+}
@@ -3,14 +3,61 @@ package interfaces
 import (
 	"context"
 
+	comboentity "github.com/fiap-161/tc-golunch-core-service/internal/combo/entity"
+	inventoryentity "github.com/fiap-161/tc-golunch-core-service/internal/inventory/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity"
+	outboxentity "github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity"
 	productentity "github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
 	productorderentity "github.com/fiap-161/tc-golunch-core-service/internal/productorder/entity"
+	promotionentity "github.com/fiap-161/tc-golunch-core-service/internal/promotion/entity"
 )
 
+// ProductService finds products of the catalog. FindByIDsWithArchived also finds deleted
+// products, which past orders still show. InActiveCategories keeps the products whose category
+// is active, leaving out those customers cannot order from.
 type ProductService interface {
 	FindByIDs(ctx context.Context, productIDs []string) ([]productentity.Product, error)
+	FindByIDsWithArchived(ctx context.Context, productIDs []string) ([]productentity.Product, error)
+	InActiveCategories(ctx context.Context, products []productentity.Product) ([]productentity.Product, error)
+}
+
+// ComboService returns the combos found among comboIDs, leaving out those that do not exist
+type ComboService interface {
+	FindByIDs(ctx context.Context, comboIDs []string) ([]comboentity.Combo, error)
+}
+
+// PromotionService prices discounts. Redeem and Release are called inside the transaction that
+// creates or closes the order, so uses are counted only for orders that exist.
+type PromotionService interface {
+	Evaluate(ctx context.Context, basket promotionentity.Basket) ([]promotionentity.Discount, error)
+	Redeem(ctx context.Context, orderID, customerID string, discounts []promotionentity.Discount) error
+	Release(ctx context.Context, orderID string) error
+}
+
+// InventoryService holds the stock of the products of an order. Reserve, Release and Consume are
+// called inside the transaction that creates or closes the order; products whose stock is not
+// tracked are always available.
+type InventoryService interface {
+	Check(ctx context.Context, requests []inventoryentity.Request) error
+	Reserve(ctx context.Context, orderID string, requests []inventoryentity.Request) error
+	Release(ctx context.Context, orderID string) error
+	Consume(ctx context.Context, orderID string) error
 }
 
 type ProductOrderService interface {
 	CreateBulk(ctx context.Context, productOrders []productorderentity.ProductOrder) (int, error)
+	FindByOrderID(ctx context.Context, orderID string) ([]productorderentity.ProductOrder, error)
+	FindByOrderIDs(ctx context.Context, orderIDs []string) ([]productorderentity.ProductOrder, error)
+}
+
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type PanelPublisher interface {
+	Publish(event entity.PanelEvent)
+}
+
+type OutboxService interface {
+	Enqueue(ctx context.Context, events ...outboxentity.Event) error
 }
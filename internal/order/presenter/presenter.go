@@ -1,6 +1,9 @@
 package presenter
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/dto"
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity"
 )
@@ -15,6 +18,17 @@ func (p *Presenter) FromEntityToDAO(order entity.Order) dto.OrderDAO {
 	return dto.ToOrderDAO(order)
 }
 
+func (p *Presenter) FromEntityToQuoteDTO(order entity.Order) dto.QuoteResponseDTO {
+	return dto.QuoteResponseDTO{
+		Items:         p.fromItemsToDTOList(order.Items),
+		Subtotal:      order.Subtotal,
+		Discounts:     dto.ToDiscountDTOList(order.Discounts),
+		Discount:      order.Discount,
+		Total:         order.Price,
+		PreparingTime: order.PreparingTime,
+	}
+}
+
 func (p *Presenter) FromEntityListToDAOList(orders []entity.Order) []dto.OrderDAO {
 	var ordersDAO []dto.OrderDAO
 	for _, order := range orders {
@@ -22,3 +36,148 @@ func (p *Presenter) FromEntityListToDAOList(orders []entity.Order) []dto.OrderDA
 	}
 	return ordersDAO
 }
+
+func (p *Presenter) FromEntityToPanelItemDTO(order entity.Order) dto.OrderPanelItemDTO {
+	// Orders placed before numbering existed are still called by the end of their ID
+	orderNumber := strconv.FormatUint(uint64(order.Number), 10)
+	if order.Number == 0 && len(order.ID) > 4 {
+		orderNumber = order.ID[len(order.ID)-4:]
+	}
+
+	return dto.OrderPanelItemDTO{
+		OrderNumber:   orderNumber,
+		Status:        order.Status.String(),
+		PreparingTime: order.PreparingTime,
+		CreatedAt:     order.CreatedAt,
+	}
+}
+
+func (p *Presenter) FromEntityListToPanelDTO(orders []entity.Order) dto.OrderPanelDTO {
+	panel := dto.OrderPanelDTO{Orders: []dto.OrderPanelItemDTO{}}
+	for _, order := range orders {
+		panel.Orders = append(panel.Orders, p.FromEntityToPanelItemDTO(order))
+	}
+	return panel
+}
+
+func (p *Presenter) FromPanelEventToDTO(event entity.PanelEvent) dto.OrderPanelEventDTO {
+	return dto.OrderPanelEventDTO{
+		ID:   event.ID,
+		Type: dto.PanelEventStatusChanged,
+		Change: &dto.OrderPanelChangeDTO{
+			OrderID:    event.Order.ID,
+			FromStatus: event.FromStatus.String(),
+			Order:      p.FromEntityToPanelItemDTO(event.Order),
+		},
+	}
+}
+
+func (p *Presenter) FromEntityToResponseDTO(order entity.Order) dto.OrderResponseDTO {
+	return dto.OrderResponseDTO{
+		OrderDAO: dto.ToOrderDAO(order),
+		Items:    p.fromItemsToDTOList(order.Items),
+	}
+}
+
+func (p *Presenter) fromItemsToDTOList(orderItems []entity.OrderItem) []dto.OrderItemDTO {
+	items := make([]dto.OrderItemDTO, 0, len(orderItems))
+	for _, item := range orderItems {
+		modifiers := make([]dto.OrderItemModifierDTO, 0, len(item.Modifiers))
+		for _, modifier := range item.Modifiers {
+			modifiers = append(modifiers, dto.OrderItemModifierDTO{
+				OptionID:   modifier.OptionID,
+				Group:      modifier.GroupName,
+				Name:       modifier.Name,
+				PriceDelta: modifier.PriceDelta,
+			})
+		}
+		items = append(items, dto.OrderItemDTO{
+			ProductID:   item.ProductID,
+			Name:        item.Name,
+			Category:    string(item.Category),
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			LineTotal:   item.LineTotal(),
+			Modifiers:   modifiers,
+			Notes:       item.Notes,
+			ComboID:     item.ComboID,
+			ComboItemID: item.ComboItemID,
+		})
+	}
+	return items
+}
+
+func (p *Presenter) FromEntityListToResponseDTOList(orders []entity.Order) []dto.OrderResponseDTO {
+	response := make([]dto.OrderResponseDTO, 0, len(orders))
+	for _, order := range orders {
+		response = append(response, p.FromEntityToResponseDTO(order))
+	}
+	return response
+}
+
+func (p *Presenter) FromPageToResponseListDTO(page entity.Page) dto.OrderResponseListDTO {
+	return dto.OrderResponseListDTO{
+		Orders:     p.FromEntityListToResponseDTOList(page.Orders),
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+	}
+}
+
+func (p *Presenter) FromReorderToResponseDTO(order entity.Order, plan entity.ReorderPlan) dto.ReorderResponseDTO {
+	priceChanges := make([]dto.PriceChangeDTO, 0, len(plan.PriceChanges))
+	for _, change := range plan.PriceChanges {
+		priceChanges = append(priceChanges, dto.PriceChangeDTO{
+			ProductID:     change.ProductID,
+			Name:          change.Name,
+			PreviousPrice: change.PreviousPrice,
+			CurrentPrice:  change.CurrentPrice,
+		})
+	}
+
+	unavailable := plan.Unavailable
+	if unavailable == nil {
+		unavailable = []string{}
+	}
+
+	return dto.ReorderResponseDTO{
+		Order:               p.FromEntityToResponseDTO(order),
+		UnavailableProducts: unavailable,
+		PriceChanges:        priceChanges,
+	}
+}
+
+func (p *Presenter) FromHistoryToResponseDTO(order entity.Order, history []entity.StatusChange) dto.OrderHistoryResponseDTO {
+	items := make([]dto.OrderStatusHistoryItemDTO, 0, len(history))
+	for _, change := range history {
+		items = append(items, dto.OrderStatusHistoryItemDTO{
+			FromStatus: change.FromStatus.String(),
+			ToStatus:   change.ToStatus.String(),
+			ChangedBy:  change.ChangedBy,
+			Source:     change.Source.String(),
+			ChangedAt:  change.CreatedAt,
+		})
+	}
+
+	durations := entity.ComputeDurations(order, history)
+	return dto.OrderHistoryResponseDTO{
+		OrderID:   order.ID,
+		Status:    order.Status.String(),
+		CreatedAt: order.CreatedAt,
+		History:   items,
+		Durations: dto.OrderDurationsDTO{
+			AwaitingPaymentSeconds: toSeconds(durations.AwaitingPayment),
+			QueueSeconds:           toSeconds(durations.Queue),
+			PreparationSeconds:     toSeconds(durations.Preparation),
+			PickupSeconds:          toSeconds(durations.Pickup),
+			TotalSeconds:           toSeconds(durations.Total),
+		},
+	}
+}
+
+func toSeconds(d *time.Duration) *int64 {
+	if d == nil {
+		return nil
+	}
+	seconds := int64(d.Seconds())
+	return &seconds
+}
@@ -0,0 +1,116 @@
+package stream
+
+import (
+	"sync"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity"
+)
+
+// DefaultHistorySize is how many past events are kept for reconnecting clients
+const DefaultHistorySize = 256
+
+const subscriberBuffer = 64
+
+// Broadcaster fans panel events out to every connected screen. It keeps the latest events
+// so a client reconnecting with the ID of the last event it saw receives only what it missed.
+// Events live in memory, so each instance of the service only sees the changes it made itself.
+type Broadcaster struct {
+	mu          sync.Mutex
+	seq         uint64
+	history     []entity.PanelEvent
+	historySize int
+	subscribers map[*Subscription]struct{}
+}
+
+// Subscription receives the events published after it was opened. When NeedsSnapshot is set
+// the client has to reload the whole panel first, since the missed events are no longer kept.
+// Events is closed when the subscriber falls too far behind and is dropped.
+type Subscription struct {
+	Events        <-chan entity.PanelEvent
+	Replay        []entity.PanelEvent
+	NeedsSnapshot bool
+	LastID        uint64
+
+	events chan entity.PanelEvent
+	closed bool
+}
+
+func NewBroadcaster(historySize int) *Broadcaster {
+	return &Broadcaster{
+		historySize: historySize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish assigns the next ID to event and delivers it without waiting on slow subscribers
+func (b *Broadcaster) Publish(event entity.PanelEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	event.ID = b.seq
+
+	b.history = append(b.history, event)
+	if len(b.history) > b.historySize {
+		b.history = b.history[len(b.history)-b.historySize:]
+	}
+
+	for subscription := range b.subscribers {
+		select {
+		case subscription.events <- event:
+		default:
+			// Dropping the subscriber makes the client reconnect and resume from its last event
+			b.closeLocked(subscription)
+		}
+	}
+}
+
+// Subscribe opens a subscription resuming after lastEventID, or from a fresh snapshot when nil
+func (b *Broadcaster) Subscribe(lastEventID *uint64) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	events := make(chan entity.PanelEvent, subscriberBuffer)
+	subscription := &Subscription{Events: events, events: events, LastID: b.seq}
+	b.subscribers[subscription] = struct{}{}
+
+	if lastEventID == nil || !b.canResumeLocked(*lastEventID) {
+		subscription.NeedsSnapshot = true
+		return subscription
+	}
+
+	for _, event := range b.history {
+		if event.ID > *lastEventID {
+			subscription.Replay = append(subscription.Replay, event)
+		}
+	}
+	return subscription
+}
+
+func (b *Broadcaster) Unsubscribe(subscription *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closeLocked(subscription)
+}
+
+// canResumeLocked reports whether every event after lastEventID is still kept. An ID ahead of
+// the sequence comes from before a restart and cannot be resumed either.
+func (b *Broadcaster) canResumeLocked(lastEventID uint64) bool {
+	if lastEventID > b.seq {
+		return false
+	}
+	if len(b.history) == 0 {
+		return lastEventID == b.seq
+	}
+	return lastEventID+1 >= b.history[0].ID
+}
+
+func (b *Broadcaster) closeLocked(subscription *Subscription) {
+	if subscription.closed {
+		return
+	}
+	subscription.closed = true
+	delete(b.subscribers, subscription)
+	close(subscription.events)
+}
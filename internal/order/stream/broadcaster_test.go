@@ -0,0 +1,81 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
+	sharedentity "github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func panelEvent(orderID string, status enum.OrderStatus) entity.PanelEvent {
+	return entity.PanelEvent{Order: entity.Order{Entity: sharedentity.Entity{ID: orderID}, Status: status}}
+}
+
+func TestBroadcaster(t *testing.T) {
+	t.Run("Given a new subscriber, when an event is published, then it is delivered with the next ID", func(t *testing.T) {
+		broadcaster := NewBroadcaster(10)
+		subscription := broadcaster.Subscribe(nil)
+
+		broadcaster.Publish(panelEvent("order-1", enum.OrderStatusReceived))
+
+		assert.True(t, subscription.NeedsSnapshot)
+		event := <-subscription.Events
+		assert.Equal(t, uint64(1), event.ID)
+		assert.Equal(t, "order-1", event.Order.ID)
+	})
+
+	t.Run("Given a client resuming from a kept event, when it subscribes, then only the missed events are replayed", func(t *testing.T) {
+		broadcaster := NewBroadcaster(10)
+		broadcaster.Publish(panelEvent("order-1", enum.OrderStatusReceived))
+		broadcaster.Publish(panelEvent("order-2", enum.OrderStatusReceived))
+		broadcaster.Publish(panelEvent("order-1", enum.OrderStatusInPreparation))
+
+		lastEventID := uint64(1)
+		subscription := broadcaster.Subscribe(&lastEventID)
+
+		assert.False(t, subscription.NeedsSnapshot)
+		assert.Len(t, subscription.Replay, 2)
+		assert.Equal(t, uint64(2), subscription.Replay[0].ID)
+		assert.Equal(t, uint64(3), subscription.LastID)
+	})
+
+	t.Run("Given a client resuming from an evicted event, when it subscribes, then it needs a snapshot", func(t *testing.T) {
+		broadcaster := NewBroadcaster(2)
+		for range 4 {
+			broadcaster.Publish(panelEvent("order-1", enum.OrderStatusReceived))
+		}
+
+		lastEventID := uint64(1)
+		subscription := broadcaster.Subscribe(&lastEventID)
+
+		assert.True(t, subscription.NeedsSnapshot)
+		assert.Empty(t, subscription.Replay)
+	})
+
+	t.Run("Given a client resuming from before a restart, when it subscribes, then it needs a snapshot", func(t *testing.T) {
+		broadcaster := NewBroadcaster(10)
+
+		lastEventID := uint64(42)
+		subscription := broadcaster.Subscribe(&lastEventID)
+
+		assert.True(t, subscription.NeedsSnapshot)
+	})
+
+	t.Run("Given a subscriber that stopped reading, when its buffer fills up, then it is dropped", func(t *testing.T) {
+		broadcaster := NewBroadcaster(10)
+		slow := broadcaster.Subscribe(nil)
+
+		for range subscriberBuffer + 1 {
+			broadcaster.Publish(panelEvent("order-1", enum.OrderStatusReceived))
+		}
+
+		received := 0
+		for range slow.Events {
+			received++
+		}
+		assert.Equal(t, subscriberBuffer, received)
+		broadcaster.Unsubscribe(slow)
+	})
+}
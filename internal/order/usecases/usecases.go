@@ -2,113 +2,327 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
 
+	comboentity "github.com/fiap-161/tc-golunch-core-service/internal/combo/entity"
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/dto"
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity/enum"
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/gateway"
 	"github.com/fiap-161/tc-golunch-core-service/internal/order/interfaces"
+	outboxentity "github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity"
+	outboxenum "github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity/enum"
 	productentity "github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
 	productorderentity "github.com/fiap-161/tc-golunch-core-service/internal/productorder/entity"
+	promotionentity "github.com/fiap-161/tc-golunch-core-service/internal/promotion/entity"
 	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
 )
 
 type UseCases struct {
 	orderGateway        *gateway.Gateway
 	productService      interfaces.ProductService
+	comboService        interfaces.ComboService
+	promotionService    interfaces.PromotionService
+	inventoryService    interfaces.InventoryService
 	productOrderService interfaces.ProductOrderService
+	unitOfWork          interfaces.UnitOfWork
+	outboxService       interfaces.OutboxService
+	panelPublisher      interfaces.PanelPublisher
+	numbering           entity.Numbering
 }
 
 func Build(
 	orderGateway *gateway.Gateway,
 	productService interfaces.ProductService,
+	comboService interfaces.ComboService,
+	promotionService interfaces.PromotionService,
+	inventoryService interfaces.InventoryService,
 	productOrderService interfaces.ProductOrderService,
+	unitOfWork interfaces.UnitOfWork,
+	outboxService interfaces.OutboxService,
+	panelPublisher interfaces.PanelPublisher,
+	numbering entity.Numbering,
 ) *UseCases {
 	return &UseCases{
 		orderGateway:        orderGateway,
 		productService:      productService,
+		comboService:        comboService,
+		promotionService:    promotionService,
+		inventoryService:    inventoryService,
 		productOrderService: productOrderService,
+		unitOfWork:          unitOfWork,
+		outboxService:       outboxService,
+		panelPublisher:      panelPublisher,
+		numbering:           numbering,
 	}
 }
 
 func (u *UseCases) CreateCompleteOrder(ctx context.Context, orderDTO dto.CreateOrderDTO) (entity.Order, error) {
+	populatedOrder, productOrders, err := u.price(ctx, orderDTO)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	// The order, its number, its product lines, its discounts, its stock and the payment request are committed together or not at all
+	var createdOrder entity.Order
+	txErr := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		order := populatedOrder.Build()
+		order.StoreID = u.numbering.StoreID
+
+		var numberErr error
+		order.Number, numberErr = u.orderGateway.NextNumber(ctx, order.StoreID, u.numbering.Period(order.CreatedAt))
+		if numberErr != nil {
+			return numberErr
+		}
+
+		var createErr error
+		createdOrder, createErr = u.orderGateway.Create(ctx, order)
+		if createErr != nil {
+			return createErr
+		}
+
+		for i := range productOrders {
+			productOrders[i].OrderID = createdOrder.ID
+		}
+		if _, createBulkErr := u.productOrderService.CreateBulk(ctx, productOrders); createBulkErr != nil {
+			return createBulkErr
+		}
+
+		if redeemErr := u.promotionService.Redeem(ctx, createdOrder.ID, createdOrder.CustomerID, createdOrder.Discounts); redeemErr != nil {
+			return redeemErr
+		}
+
+		if reserveErr := u.inventoryService.Reserve(ctx, createdOrder.ID, entity.StockRequests(populatedOrder.Items)); reserveErr != nil {
+			return reserveErr
+		}
+
+		return u.enqueueOrderEvent(ctx, outboxenum.EventTypeCreatePayment, createdOrder)
+	})
+	if txErr != nil {
+		return entity.Order{}, txErr
+	}
+
+	u.panelPublisher.Publish(entity.PanelEvent{Order: createdOrder})
+
+	return createdOrder, nil
+}
+
+// Quote prices an order exactly as CreateCompleteOrder would right now, without persisting
+// anything. The lines of the quote come back as the items of the order.
+func (u *UseCases) Quote(ctx context.Context, orderDTO dto.CreateOrderDTO) (entity.Order, error) {
+	order, _, err := u.price(ctx, orderDTO)
+	return order, err
+}
+
+// price looks up the products and combos of the order, builds its lines, checks they are in
+// stock and takes off the discounts of the promotions it is entitled to
+func (u *UseCases) price(ctx context.Context, orderDTO dto.CreateOrderDTO) (entity.Order, []productorderentity.ProductOrder, error) {
+	// The same product may come in several lines with different modifiers, or inside combos
 	var productIds []string
+	seen := make(map[string]bool, len(orderDTO.Products))
+	addProduct := func(productID string) {
+		if !seen[productID] {
+			seen[productID] = true
+			productIds = append(productIds, productID)
+		}
+	}
 	for _, item := range orderDTO.Products {
-		productIds = append(productIds, item.ProductID)
+		addProduct(item.ProductID)
+	}
+	var comboIDs []string
+	for _, item := range orderDTO.Combos {
+		comboIDs = append(comboIDs, item.ComboID)
+		for _, selection := range item.Selections {
+			addProduct(selection.ProductID)
+		}
+	}
+
+	var combos []comboentity.Combo
+	if len(comboIDs) > 0 {
+		var comboErr error
+		combos, comboErr = u.comboService.FindByIDs(ctx, comboIDs)
+		if comboErr != nil {
+			return entity.Order{}, nil, comboErr
+		}
 	}
 
 	products, findErr := u.productService.FindByIDs(ctx, productIds)
 	if findErr != nil {
-		return entity.Order{}, findErr
+		return entity.Order{}, nil, findErr
 	}
-	if len(products) != len(orderDTO.Products) {
-		return entity.Order{}, &apperror.NotFoundError{
+	if len(products) != len(productIds) {
+		return entity.Order{}, nil, &apperror.NotFoundError{
 			Msg: "some products not found",
 		}
 	}
+	// The products of inactive categories are hidden from the menu, so they cannot be ordered either
+	orderable, categoryErr := u.productService.InActiveCategories(ctx, products)
+	if categoryErr != nil {
+		return entity.Order{}, nil, categoryErr
+	}
+	inActiveCategory := make(map[string]bool, len(orderable))
+	for _, product := range orderable {
+		inActiveCategory[product.Id] = true
+	}
+	now := time.Now()
+	for _, product := range products {
+		if !inActiveCategory[product.Id] || !product.IsAvailableAt(now.In(u.numbering.Location)) {
+			return entity.Order{}, nil, &apperror.ValidationError{Msg: fmt.Sprintf("%s is not available right now", product.Name)}
+		}
+	}
 
-	populatedOrder := generateOrderByProducts(orderDTO, products)
-	createdOrder, createErr := u.orderGateway.Create(ctx, populatedOrder.Build())
-	if createErr != nil {
-		return entity.Order{}, createErr
+	populatedOrder, productOrders, err := generateOrderByProducts(orderDTO, products, combos)
+	if err != nil {
+		return entity.Order{}, nil, err
 	}
+	items := entity.BuildItems(productOrders, products)
 
-	productOrders, _ := generateProductOrderFromOrderAndProducts(createdOrder.ID, orderDTO.Products, products)
-	_, createBulkErr := u.productOrderService.CreateBulk(ctx, productOrders)
-	if createBulkErr != nil {
-		return entity.Order{}, createBulkErr
+	if err := u.inventoryService.Check(ctx, entity.StockRequests(items)); err != nil {
+		return entity.Order{}, nil, err
 	}
 
-	return createdOrder, nil
+	discounts, err := u.promotionService.Evaluate(ctx, promotionentity.Basket{
+		CustomerID: orderDTO.CustomerID,
+		Codes:      orderDTO.Coupons,
+		Lines:      entity.PromotionLines(productOrders, products),
+		At:         now,
+	})
+	if err != nil {
+		return entity.Order{}, nil, err
+	}
+
+	populatedOrder, err = populatedOrder.WithDiscounts(discounts)
+	if err != nil {
+		return entity.Order{}, nil, err
+	}
+	populatedOrder.Items = items
+	return populatedOrder, productOrders, nil
 }
 
-func generateOrderByProducts(orderDTO dto.CreateOrderDTO, products []productentity.Product) entity.Order {
+func generateOrderByProducts(
+	orderDTO dto.CreateOrderDTO,
+	products []productentity.Product,
+	combos []comboentity.Combo,
+) (entity.Order, []productorderentity.ProductOrder, error) {
 	orderProductInfo := make([]entity.OrderProductInfo, len(orderDTO.Products))
 	for i, product := range orderDTO.Products {
 		orderProductInfo[i] = entity.OrderProductInfo{
 			ProductID: product.ProductID,
 			Quantity:  product.Quantity,
+			Modifiers: product.Modifiers,
+			Notes:     product.Notes,
 		}
 	}
 
-	return entity.Order{}.FromDTO(orderDTO.CustomerID, orderProductInfo, products)
-}
-
-func generateProductOrderFromOrderAndProducts(
-	orderID string,
-	orderProductInfo []dto.OrderProductInfo,
-	products []productentity.Product,
-) ([]productorderentity.ProductOrder, error) {
-	var res []productorderentity.ProductOrder
-
-	for _, product := range products {
-		for _, item := range orderProductInfo {
-			if product.Id == item.ProductID {
-				res = append(res, productorderentity.ProductOrder{
-					OrderID:   orderID,
-					ProductID: product.Id,
-					Quantity:  item.Quantity,
-					UnitPrice: product.Price,
-				})
+	orderComboInfo := make([]entity.OrderComboInfo, len(orderDTO.Combos))
+	for i, combo := range orderDTO.Combos {
+		selections := make([]entity.ComboSelection, len(combo.Selections))
+		for j, selection := range combo.Selections {
+			selections[j] = entity.ComboSelection{
+				SlotID:    selection.SlotID,
+				ProductID: selection.ProductID,
+				Modifiers: selection.Modifiers,
+				Notes:     selection.Notes,
 			}
 		}
+		orderComboInfo[i] = entity.OrderComboInfo{
+			ComboID:    combo.ComboID,
+			Quantity:   combo.Quantity,
+			Selections: selections,
+		}
 	}
 
-	return res, nil
+	return entity.Order{}.FromDTO(orderDTO.CustomerID, orderProductInfo, orderComboInfo, products, combos)
 }
 
 func (u *UseCases) CreateOrder(ctx context.Context, order entity.Order) (entity.Order, error) {
 	return u.orderGateway.Create(ctx, order)
 }
 
-func (u *UseCases) GetAllOrById(ctx context.Context, id string) ([]entity.Order, error) {
-	if id != "" {
-		order, err := u.orderGateway.FindByID(ctx, id)
-		if err != nil {
+// List returns one page of orders with their product lines
+func (u *UseCases) List(ctx context.Context, query entity.ListQuery) (entity.Page, error) {
+	query, err := query.Normalize()
+	if err != nil {
+		return entity.Page{}, err
+	}
+
+	after, err := query.After()
+	if err != nil {
+		return entity.Page{}, err
+	}
+
+	// One extra row tells whether there is a next page without a second query
+	orders, total, err := u.orderGateway.List(ctx, query, after, query.Limit+1)
+	if err != nil {
+		return entity.Page{}, err
+	}
+
+	page := entity.NewPage(orders, total, query)
+	page.Orders, err = u.withItems(ctx, page.Orders)
+	if err != nil {
+		return entity.Page{}, err
+	}
+	return page, nil
+}
+
+// GetWithItems returns a single order together with its product lines
+func (u *UseCases) GetWithItems(ctx context.Context, id string) (entity.Order, error) {
+	order, err := u.orderGateway.FindByID(ctx, id)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	orders, err := u.withItems(ctx, []entity.Order{order})
+	if err != nil {
+		return entity.Order{}, err
+	}
+	return orders[0], nil
+}
+
+// withItems loads the product lines of every order in two queries and attaches them as items
+func (u *UseCases) withItems(ctx context.Context, orders []entity.Order) ([]entity.Order, error) {
+	if len(orders) == 0 {
+		return orders, nil
+	}
+
+	orderIDs := make([]string, 0, len(orders))
+	for _, order := range orders {
+		orderIDs = append(orderIDs, order.ID)
+	}
+
+	lines, err := u.productOrderService.FindByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	linesByOrder := make(map[string][]productorderentity.ProductOrder, len(orders))
+	productIDs := make([]string, 0, len(lines))
+	seen := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		linesByOrder[line.OrderID] = append(linesByOrder[line.OrderID], line)
+		if !seen[line.ProductID] {
+			seen[line.ProductID] = true
+			productIDs = append(productIDs, line.ProductID)
+		}
+	}
+
+	var products []productentity.Product
+	if len(productIDs) > 0 {
+		// Lines of archived products still show their name and category
+		products, err = u.productService.FindByIDsWithArchived(ctx, productIDs)
+		var notFoundErr *apperror.NotFoundError
+		if err != nil && !errors.As(err, &notFoundErr) {
 			return nil, err
 		}
-		return []entity.Order{order}, nil
 	}
-	return u.orderGateway.GetAll(ctx)
+
+	for i := range orders {
+		orders[i].Items = entity.BuildItems(linesByOrder[orders[i].ID], products)
+	}
+	return orders, nil
 }
 
 func (u *UseCases) GetPanel(ctx context.Context) ([]entity.Order, error) {
@@ -119,6 +333,246 @@ func (u *UseCases) FindByID(ctx context.Context, id string) (entity.Order, error
 	return u.orderGateway.FindByID(ctx, id)
 }
 
-func (u *UseCases) Update(ctx context.Context, order entity.Order) (entity.Order, error) {
-	return u.orderGateway.Update(ctx, order)
+// Update persists the order after validating its status transition and records
+// the change in the status history on behalf of actor.
+func (u *UseCases) Update(ctx context.Context, order entity.Order, actor entity.Actor) (entity.Order, error) {
+	current, err := u.orderGateway.FindByID(ctx, order.ID)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	if err := current.ValidateTransition(order.Status); err != nil {
+		return entity.Order{}, err
+	}
+
+	order.UpdatedAt = time.Now()
+
+	// The order row, its history entry and the notifications to other services are committed together
+	var updated entity.Order
+	txErr := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var updateErr error
+		updated, updateErr = u.orderGateway.Update(ctx, order)
+		if updateErr != nil {
+			return updateErr
+		}
+
+		if current.Status == updated.Status {
+			return nil
+		}
+		change := entity.NewStatusChange(updated.ID, current.Status, updated.Status, actor)
+		if err := u.orderGateway.CreateStatusChange(ctx, change); err != nil {
+			return err
+		}
+
+		// Coupons and stock held by orders that were never fulfilled can be used again; the stock
+		// of a completed order has left the shelf
+		switch updated.Status {
+		case enum.OrderStatusCancelled, enum.OrderStatusExpired:
+			if err := u.promotionService.Release(ctx, updated.ID); err != nil {
+				return err
+			}
+			if err := u.inventoryService.Release(ctx, updated.ID); err != nil {
+				return err
+			}
+		case enum.OrderStatusCompleted:
+			if err := u.inventoryService.Consume(ctx, updated.ID); err != nil {
+				return err
+			}
+		}
+
+		if voidsPendingCharge(current, updated) {
+			if err := u.enqueueOrderEvent(ctx, outboxenum.EventTypeCancelPayment, updated); err != nil {
+				return err
+			}
+		}
+
+		if !updated.Status.NotifiesOperation() {
+			return nil
+		}
+		return u.enqueueOrderEvent(ctx, outboxenum.EventTypeNotifyOperation, updated)
+	})
+	if txErr != nil {
+		return entity.Order{}, txErr
+	}
+
+	// Screens only hear about the change once it is committed
+	if current.Status != updated.Status {
+		u.panelPublisher.Publish(entity.PanelEvent{Order: updated, FromStatus: current.Status})
+	}
+
+	return updated, nil
+}
+
+func (u *UseCases) GetStatusHistory(ctx context.Context, id string) (entity.Order, []entity.StatusChange, error) {
+	order, err := u.orderGateway.FindByID(ctx, id)
+	if err != nil {
+		return entity.Order{}, nil, err
+	}
+
+	history, err := u.orderGateway.FindStatusHistory(ctx, id)
+	if err != nil {
+		return entity.Order{}, nil, err
+	}
+
+	return order, history, nil
+}
+
+// Cancel cancels an order. Customers may only cancel their own orders before preparation
+// starts; admins, webhooks and the system may cancel any order the state machine allows.
+func (u *UseCases) Cancel(ctx context.Context, id string, reason enum.CancellationReason, actor entity.Actor) (entity.Order, error) {
+	order, err := u.orderGateway.FindByID(ctx, id)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	if actor.Source == enum.StatusChangeSourceCustomer {
+		if order.CustomerID != actor.ID {
+			return entity.Order{}, &apperror.UnauthorizedError{Msg: "order does not belong to the customer"}
+		}
+		if !order.IsCancellableByCustomer() {
+			return entity.Order{}, &apperror.ValidationError{Msg: "order is already being prepared and can only be cancelled by an admin"}
+		}
+	}
+
+	cancelled, err := order.Cancel(reason)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	return u.Update(ctx, cancelled, actor)
+}
+
+// Reorder places a new order for customerID with the products of a previous order at current prices.
+// Products that left the catalog are dropped; the plan tells the caller what was dropped or re-priced.
+func (u *UseCases) Reorder(ctx context.Context, id string, customerID string) (entity.Order, entity.ReorderPlan, error) {
+	previous, err := u.orderGateway.FindByID(ctx, id)
+	if err != nil {
+		return entity.Order{}, entity.ReorderPlan{}, err
+	}
+	if previous.CustomerID != customerID {
+		return entity.Order{}, entity.ReorderPlan{}, &apperror.UnauthorizedError{Msg: "order does not belong to the customer"}
+	}
+
+	lines, err := u.productOrderService.FindByOrderID(ctx, previous.ID)
+	if err != nil {
+		return entity.Order{}, entity.ReorderPlan{}, err
+	}
+
+	productIDs := make([]string, 0, len(lines))
+	var comboIDs []string
+	for _, line := range lines {
+		productIDs = append(productIDs, line.ProductID)
+		if line.ComboID != "" {
+			comboIDs = append(comboIDs, line.ComboID)
+		}
+	}
+
+	var products []productentity.Product
+	if len(productIDs) > 0 {
+		products, err = u.productService.FindByIDs(ctx, productIDs)
+		var notFoundErr *apperror.NotFoundError
+		if err != nil && !errors.As(err, &notFoundErr) {
+			return entity.Order{}, entity.ReorderPlan{}, err
+		}
+		// Products off the menu right now are reported as unavailable like those that left the catalog
+		products = productentity.AvailableAt(products, time.Now().In(u.numbering.Location))
+	}
+
+	var combos []comboentity.Combo
+	if len(comboIDs) > 0 {
+		combos, err = u.comboService.FindByIDs(ctx, comboIDs)
+		if err != nil {
+			return entity.Order{}, entity.ReorderPlan{}, err
+		}
+	}
+
+	plan := entity.PlanReorder(lines, products, combos)
+	if len(plan.Products) == 0 && len(plan.Combos) == 0 {
+		return entity.Order{}, plan, &apperror.ValidationError{Msg: "none of the products of this order are available anymore"}
+	}
+
+	orderDTO := dto.CreateOrderDTO{CustomerID: customerID}
+	for _, product := range plan.Products {
+		orderDTO.Products = append(orderDTO.Products, dto.OrderProductInfo{
+			ProductID: product.ProductID,
+			Quantity:  product.Quantity,
+			Modifiers: product.Modifiers,
+			Notes:     product.Notes,
+		})
+	}
+	for _, combo := range plan.Combos {
+		comboInfo := dto.OrderComboInfo{ComboID: combo.ComboID, Quantity: combo.Quantity}
+		for _, selection := range combo.Selections {
+			comboInfo.Selections = append(comboInfo.Selections, dto.ComboSelectionInfo{
+				SlotID:    selection.SlotID,
+				ProductID: selection.ProductID,
+				Modifiers: selection.Modifiers,
+				Notes:     selection.Notes,
+			})
+		}
+		orderDTO.Combos = append(orderDTO.Combos, comboInfo)
+	}
+
+	created, err := u.CreateCompleteOrder(ctx, orderDTO)
+	if err != nil {
+		return entity.Order{}, plan, err
+	}
+
+	withItems, err := u.withItems(ctx, []entity.Order{created})
+	if err != nil {
+		return entity.Order{}, plan, err
+	}
+	return withItems[0], plan, nil
+}
+
+// ExpireStaleOrders expires every order that has been awaiting payment for longer than window
+// through Update, which queues the payment void. Failures on a single order are logged and do not stop the sweep.
+func (u *UseCases) ExpireStaleOrders(ctx context.Context, window time.Duration) ([]entity.Order, error) {
+	stale, err := u.orderGateway.FindByStatusCreatedBefore(ctx, enum.OrderStatusAwaitingPayment, time.Now().Add(-window))
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []entity.Order
+	for _, order := range stale {
+		expiredOrder, expireErr := order.Expire()
+		if expireErr != nil {
+			log.Printf("Failed to expire order %s: %v", order.ID, expireErr)
+			continue
+		}
+
+		updated, updateErr := u.Update(ctx, expiredOrder, entity.Actor{Source: enum.StatusChangeSourceSystem})
+		if updateErr != nil {
+			log.Printf("Failed to expire order %s: %v", order.ID, updateErr)
+			continue
+		}
+
+		expired = append(expired, updated)
+	}
+
+	return expired, nil
+}
+
+// voidsPendingCharge reports whether an order closed before its payment was confirmed.
+// A rejected payment is already closed on the payment side and needs no void.
+func voidsPendingCharge(previous, current entity.Order) bool {
+	if previous.Status != enum.OrderStatusAwaitingPayment {
+		return false
+	}
+	if current.Status != enum.OrderStatusCancelled && current.Status != enum.OrderStatusExpired {
+		return false
+	}
+	return current.CancellationReason != enum.CancellationReasonPaymentRejected
+}
+
+func (u *UseCases) enqueueOrderEvent(ctx context.Context, eventType outboxenum.EventType, order entity.Order) error {
+	event, err := outboxentity.NewOrderEvent(eventType, outboxentity.OrderEventPayload{
+		OrderID: order.ID,
+		Status:  order.Status.String(),
+		Reason:  order.CancellationReason.String(),
+	})
+	if err != nil {
+		return &apperror.InternalError{Msg: err.Error()}
+	}
+	return u.outboxService.Enqueue(ctx, event)
 }
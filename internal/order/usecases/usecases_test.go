@@ -0,0 +1,261 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fiap-161/tc-golunch-core-service/database"
+	inventoryentity "github.com/fiap-161/tc-golunch-core-service/internal/inventory/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/entity"
+	orderdatasource "github.com/fiap-161/tc-golunch-core-service/internal/order/external/datasource"
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/gateway"
+	productentity "github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	productorderdatasource "github.com/fiap-161/tc-golunch-core-service/internal/productorder/external/datasource"
+	productordergateway "github.com/fiap-161/tc-golunch-core-service/internal/productorder/gateway"
+	productorderusecases "github.com/fiap-161/tc-golunch-core-service/internal/productorder/usecases"
+	promotionentity "github.com/fiap-161/tc-golunch-core-service/internal/promotion/entity"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// recordingConnector is a database/sql driver that answers every statement without a server.
+// It records the statements run inside each transaction and how the transaction ended, and
+// fails the statements touching failTable.
+type recordingConnector struct {
+	failTable  string
+	statements []recordedStatement
+	commits    int
+	rollbacks  int
+}
+
+type recordedStatement struct {
+	query string
+	inTx  bool
+}
+
+type recordingConn struct {
+	connector *recordingConnector
+	inTx      bool
+}
+
+type recordingTx struct {
+	conn *recordingConn
+}
+
+type recordingRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (c *recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return &recordingConn{connector: c}, nil
+}
+
+func (c *recordingConnector) Driver() driver.Driver { return nil }
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *recordingConn) Close() error { return nil }
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *recordingConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	c.inTx = true
+	return &recordingTx{conn: c}, nil
+}
+
+func (c *recordingConn) record(query string) error {
+	c.connector.statements = append(c.connector.statements, recordedStatement{query: query, inTx: c.inTx})
+	if strings.Contains(query, c.connector.failTable) {
+		return errors.New("insert into " + c.connector.failTable + " failed")
+	}
+	return nil
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if err := c.record(query); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *recordingConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if err := c.record(query); err != nil {
+		return nil, err
+	}
+	// The order number sequence is the only query whose answer is read
+	if strings.Contains(query, "RETURNING last_number") {
+		return &recordingRows{columns: []string{"last_number"}, values: [][]driver.Value{{int64(1)}}}, nil
+	}
+	return &recordingRows{}, nil
+}
+
+func (t *recordingTx) Commit() error {
+	t.conn.inTx = false
+	t.conn.connector.commits++
+	return nil
+}
+
+func (t *recordingTx) Rollback() error {
+	t.conn.inTx = false
+	t.conn.connector.rollbacks++
+	return nil
+}
+
+func (r *recordingRows) Columns() []string { return r.columns }
+
+func (r *recordingRows) Close() error { return nil }
+
+func (r *recordingRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// menuProducts finds every product among a fixed menu whose categories are all active
+type menuProducts []productentity.Product
+
+func (m menuProducts) FindByIDs(_ context.Context, ids []string) ([]productentity.Product, error) {
+	var found []productentity.Product
+	for _, product := range m {
+		for _, id := range ids {
+			if product.Id == id {
+				found = append(found, product)
+			}
+		}
+	}
+	return found, nil
+}
+
+func (m menuProducts) FindByIDsWithArchived(ctx context.Context, ids []string) ([]productentity.Product, error) {
+	return m.FindByIDs(ctx, ids)
+}
+
+func (m menuProducts) InActiveCategories(_ context.Context, products []productentity.Product) ([]productentity.Product, error) {
+	return products, nil
+}
+
+// inactiveCategory is a menu where the products of category cannot be ordered
+type inactiveCategory struct {
+	menuProducts
+	category productenum.Category
+}
+
+func (m inactiveCategory) InActiveCategories(_ context.Context, products []productentity.Product) ([]productentity.Product, error) {
+	var active []productentity.Product
+	for _, product := range products {
+		if product.Category != m.category {
+			active = append(active, product)
+		}
+	}
+	return active, nil
+}
+
+// untrackedStock has every product available
+type untrackedStock struct{}
+
+func (untrackedStock) Check(context.Context, []inventoryentity.Request) error { return nil }
+
+func (untrackedStock) Reserve(context.Context, string, []inventoryentity.Request) error { return nil }
+
+func (untrackedStock) Release(context.Context, string) error { return nil }
+
+func (untrackedStock) Consume(context.Context, string) error { return nil }
+
+// noPromotions never gives a discount
+type noPromotions struct{}
+
+func (noPromotions) Evaluate(context.Context, promotionentity.Basket) ([]promotionentity.Discount, error) {
+	return nil, nil
+}
+
+func (noPromotions) Redeem(context.Context, string, string, []promotionentity.Discount) error {
+	return nil
+}
+
+func (noPromotions) Release(context.Context, string) error { return nil }
+
+func TestUseCases_CreateCompleteOrder(t *testing.T) {
+	t.Run("Given the product lines fail to be saved, when creating an order, then the order row is rolled back with them", func(t *testing.T) {
+		connector := &recordingConnector{failTable: "product_order_daos"}
+		sqlDB := sql.OpenDB(connector)
+		db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+		assert.NoError(t, err)
+
+		numbering, err := entity.NewNumbering("main", string(entity.NumberResetDaily), 0, "UTC")
+		assert.NoError(t, err)
+		burger := productentity.Product{Id: "6f1c2a4e-8a7b-4a55-9b39-3c2c7d1f0a11", Name: "Burger", Price: money.FromCents(2500), Category: "MEAL", Available: true}
+		productOrderService := productorderusecases.Build(*productordergateway.Build(productorderdatasource.New(db)))
+		u := Build(
+			gateway.Build(orderdatasource.New(db)),
+			menuProducts{burger},
+			nil,
+			noPromotions{},
+			untrackedStock{},
+			productOrderService,
+			database.NewUnitOfWork(db),
+			nil,
+			nil,
+			numbering,
+		)
+
+		_, err = u.CreateCompleteOrder(context.Background(), dto.CreateOrderDTO{
+			CustomerID: "customer-1",
+			Products:   []dto.OrderProductInfo{{ProductID: burger.Id, Quantity: 1}},
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, connector.commits)
+		assert.Equal(t, 1, connector.rollbacks)
+
+		var orderInsert, linesInsert *recordedStatement
+		for i, statement := range connector.statements {
+			switch {
+			case strings.HasPrefix(statement.query, `INSERT INTO "order_daos"`):
+				orderInsert = &connector.statements[i]
+			case strings.HasPrefix(statement.query, `INSERT INTO "product_order_daos"`):
+				linesInsert = &connector.statements[i]
+			}
+		}
+		if assert.NotNil(t, orderInsert) && assert.NotNil(t, linesInsert) {
+			assert.True(t, orderInsert.inTx, "the order is inserted in the transaction that was rolled back")
+			assert.True(t, linesInsert.inTx)
+		}
+	})
+}
+
+func TestUseCases_Quote(t *testing.T) {
+	t.Run("Given a product whose category was deactivated, when quoting an order with it, then it is not available", func(t *testing.T) {
+		numbering, err := entity.NewNumbering("main", string(entity.NumberResetDaily), 0, "UTC")
+		assert.NoError(t, err)
+		burger := productentity.Product{Id: "6f1c2a4e-8a7b-4a55-9b39-3c2c7d1f0a11", Name: "Burger", Price: money.FromCents(2500), Category: "MEAL", Available: true}
+		u := Build(nil, inactiveCategory{menuProducts{burger}, "MEAL"}, nil, noPromotions{}, untrackedStock{}, nil, nil, nil, nil, numbering)
+
+		_, err = u.Quote(context.Background(), dto.CreateOrderDTO{
+			CustomerID: "customer-1",
+			Products:   []dto.OrderProductInfo{{ProductID: burger.Id, Quantity: 1}},
+		})
+
+		var validationErr *apperror.ValidationError
+		if assert.True(t, errors.As(err, &validationErr)) {
+			assert.Equal(t, "Burger is not available right now", validationErr.Msg)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/order/controller"
+)
+
+// ExpirySweeper periodically expires orders that stayed awaiting payment longer than the configured window
+type ExpirySweeper struct {
+	controller *controller.Controller
+	window     time.Duration
+	interval   time.Duration
+}
+
+func NewExpirySweeper(controller *controller.Controller, window, interval time.Duration) *ExpirySweeper {
+	return &ExpirySweeper{
+		controller: controller,
+		window:     window,
+		interval:   interval,
+	}
+}
+
+// Start runs the sweep on every tick until ctx is cancelled
+func (s *ExpirySweeper) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	log.Printf("Order expiry sweeper started (window %s, interval %s)", s.window, s.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweep(ctx)
+		}
+	}
+}
+
+func (s *ExpirySweeper) sweep(ctx context.Context) {
+	expired, err := s.controller.ExpireStaleOrders(ctx, s.window)
+	if err != nil {
+		log.Printf("Failed to sweep expired orders: %v", err)
+		return
+	}
+	if expired > 0 {
+		log.Printf("Expired %d orders awaiting payment for more than %s", expired, s.window)
+	}
+}
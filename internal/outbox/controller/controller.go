@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/presenter"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/usecases"
+)
+
+type Controller struct {
+	outboxUseCase *usecases.UseCases
+}
+
+func Build(outboxUseCase *usecases.UseCases) *Controller {
+	return &Controller{
+		outboxUseCase: outboxUseCase,
+	}
+}
+
+func (c *Controller) ListByStatus(ctx context.Context, status string) (dto.OutboxEventListResponseDTO, error) {
+	presenter := presenter.Build()
+
+	events, err := c.outboxUseCase.ListByStatus(ctx, status)
+	if err != nil {
+		return dto.OutboxEventListResponseDTO{}, err
+	}
+
+	return presenter.FromEntityListToListResponseDTO(events), nil
+}
+
+func (c *Controller) Replay(ctx context.Context, id string) (dto.OutboxEventResponseDTO, error) {
+	presenter := presenter.Build()
+
+	event, err := c.outboxUseCase.Replay(ctx, id)
+	if err != nil {
+		return dto.OutboxEventResponseDTO{}, err
+	}
+
+	return presenter.FromEntityToResponseDTO(event), nil
+}
+
+func (c *Controller) RelayDue(ctx context.Context, batchSize int) (int, error) {
+	return c.outboxUseCase.RelayDue(ctx, batchSize)
+}
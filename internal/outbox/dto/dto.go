@@ -0,0 +1,92 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity/enum"
+)
+
+type OutboxEventDAO struct {
+	ID            string           `json:"id" gorm:"type:uuid;primaryKey"`
+	AggregateID   string           `json:"aggregate_id" gorm:"type:uuid;index"`
+	Type          enum.EventType   `json:"type" gorm:"type:varchar(40)"`
+	Payload       string           `json:"payload" gorm:"type:jsonb"`
+	Status        enum.EventStatus `json:"status" gorm:"type:varchar(20);index:idx_outbox_events_status_next_attempt"`
+	Attempts      int              `json:"attempts"`
+	NextAttemptAt time.Time        `json:"next_attempt_at" gorm:"index:idx_outbox_events_status_next_attempt"`
+	LastError     string           `json:"last_error" gorm:"type:text"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+	DeliveredAt   *time.Time       `json:"delivered_at"`
+}
+
+func (OutboxEventDAO) TableName() string {
+	return "outbox_events"
+}
+
+type OutboxEventResponseDTO struct {
+	ID            string     `json:"id"`
+	AggregateID   string     `json:"aggregate_id"`
+	Type          string     `json:"type"`
+	Payload       string     `json:"payload"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+}
+
+type OutboxEventListResponseDTO struct {
+	Total  uint                     `json:"total"`
+	Events []OutboxEventResponseDTO `json:"events"`
+}
+
+func ToOutboxEventDAO(e entity.Event) OutboxEventDAO {
+	return OutboxEventDAO{
+		ID:            e.ID,
+		AggregateID:   e.AggregateID,
+		Type:          e.Type,
+		Payload:       e.Payload,
+		Status:        e.Status,
+		Attempts:      e.Attempts,
+		NextAttemptAt: e.NextAttemptAt,
+		LastError:     e.LastError,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+		DeliveredAt:   e.DeliveredAt,
+	}
+}
+
+func FromOutboxEventDAO(dao OutboxEventDAO) entity.Event {
+	return entity.Event{
+		ID:            dao.ID,
+		AggregateID:   dao.AggregateID,
+		Type:          dao.Type,
+		Payload:       dao.Payload,
+		Status:        dao.Status,
+		Attempts:      dao.Attempts,
+		NextAttemptAt: dao.NextAttemptAt,
+		LastError:     dao.LastError,
+		CreatedAt:     dao.CreatedAt,
+		UpdatedAt:     dao.UpdatedAt,
+		DeliveredAt:   dao.DeliveredAt,
+	}
+}
+
+func ToListOutboxEventDAO(events []entity.Event) []OutboxEventDAO {
+	daos := make([]OutboxEventDAO, 0, len(events))
+	for _, event := range events {
+		daos = append(daos, ToOutboxEventDAO(event))
+	}
+	return daos
+}
+
+func EntityListFromDAOList(daoList []OutboxEventDAO) []entity.Event {
+	events := make([]entity.Event, 0, len(daoList))
+	for _, dao := range daoList {
+		events = append(events, FromOutboxEventDAO(dao))
+	}
+	return events
+}
@@ -0,0 +1,19 @@
+package enum
+
+type EventStatus string
+
+const (
+	EventStatusPending   EventStatus = "pending"
+	EventStatusDelivered EventStatus = "delivered"
+	EventStatusDead      EventStatus = "dead"
+)
+
+var StatusMapper = map[string]EventStatus{
+	EventStatusPending.String():   EventStatusPending,
+	EventStatusDelivered.String(): EventStatusDelivered,
+	EventStatusDead.String():      EventStatusDead,
+}
+
+func (e EventStatus) String() string {
+	return string(e)
+}
@@ -0,0 +1,13 @@
+package enum
+
+type EventType string
+
+const (
+	EventTypeCreatePayment   EventType = "payment.create"
+	EventTypeCancelPayment   EventType = "payment.cancel"
+	EventTypeNotifyOperation EventType = "operation.notify"
+)
+
+func (e EventType) String() string {
+	return string(e)
+}
@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity/enum"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxAttempts is how many deliveries are tried before an event is dead-lettered
+	MaxAttempts = 8
+	baseBackoff = 5 * time.Second
+	maxBackoff  = 10 * time.Minute
+)
+
+// Event is a message to another service, stored in the same transaction as the change that produced it
+type Event struct {
+	ID            string
+	AggregateID   string
+	Type          enum.EventType
+	Payload       string
+	Status        enum.EventStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeliveredAt   *time.Time
+}
+
+// OrderEventPayload is the payload of every order related event
+type OrderEventPayload struct {
+	OrderID string `json:"order_id"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+func NewOrderEvent(eventType enum.EventType, payload OrderEventPayload) (Event, error) {
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+
+	now := time.Now()
+	return Event{
+		ID:            uuid.NewString(),
+		AggregateID:   payload.OrderID,
+		Type:          eventType,
+		Payload:       string(rawPayload),
+		Status:        enum.EventStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+func (e Event) OrderPayload() (OrderEventPayload, error) {
+	var payload OrderEventPayload
+	if err := json.Unmarshal([]byte(e.Payload), &payload); err != nil {
+		return OrderEventPayload{}, err
+	}
+	return payload, nil
+}
+
+func (e Event) MarkDelivered(now time.Time) Event {
+	e.Status = enum.EventStatusDelivered
+	e.Attempts++
+	e.LastError = ""
+	e.DeliveredAt = &now
+	e.UpdatedAt = now
+	return e
+}
+
+// MarkFailed schedules the next attempt with exponential backoff, or dead-letters the event
+// once MaxAttempts is reached.
+func (e Event) MarkFailed(cause error, now time.Time) Event {
+	e.Attempts++
+	e.LastError = cause.Error()
+	e.UpdatedAt = now
+
+	if e.Attempts >= MaxAttempts {
+		e.Status = enum.EventStatusDead
+		return e
+	}
+
+	e.NextAttemptAt = now.Add(Backoff(e.Attempts))
+	return e
+}
+
+// Replay puts a dead event back in the queue with a fresh attempt budget
+func (e Event) Replay(now time.Time) (Event, error) {
+	if e.Status != enum.EventStatusDead {
+		return Event{}, &apperror.ValidationError{Msg: "only dead events can be replayed"}
+	}
+
+	e.Status = enum.EventStatusPending
+	e.Attempts = 0
+	e.NextAttemptAt = now
+	e.UpdatedAt = now
+	return e, nil
+}
+
+// Backoff returns the delay before the next attempt after the given number of failed attempts
+func Backoff(attempts int) time.Duration {
+	delay := baseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
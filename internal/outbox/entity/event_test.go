@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity/enum"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 5 * time.Second},
+		{attempts: 2, want: 10 * time.Second},
+		{attempts: 4, want: 40 * time.Second},
+		{attempts: 20, want: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Backoff(tt.attempts))
+	}
+}
+
+func TestEvent_MarkFailed(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	event, err := NewOrderEvent(enum.EventTypeCreatePayment, OrderEventPayload{OrderID: "order-1", Status: "awaiting_payment"})
+	assert.NoError(t, err)
+
+	t.Run("Given a pending event, when delivery fails, then the next attempt is scheduled", func(t *testing.T) {
+		failed := event.MarkFailed(errors.New("connection refused"), now)
+
+		assert.Equal(t, enum.EventStatusPending, failed.Status)
+		assert.Equal(t, 1, failed.Attempts)
+		assert.Equal(t, "connection refused", failed.LastError)
+		assert.Equal(t, now.Add(5*time.Second), failed.NextAttemptAt)
+	})
+
+	t.Run("Given an event on its last attempt, when delivery fails, then it is dead-lettered", func(t *testing.T) {
+		event.Attempts = MaxAttempts - 1
+		failed := event.MarkFailed(errors.New("timeout"), now)
+
+		assert.Equal(t, enum.EventStatusDead, failed.Status)
+		assert.Equal(t, MaxAttempts, failed.Attempts)
+	})
+}
+
+func TestEvent_Replay(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	replayed, err := Event{Status: enum.EventStatusDead, Attempts: MaxAttempts}.Replay(now)
+	assert.NoError(t, err)
+	assert.Equal(t, enum.EventStatusPending, replayed.Status)
+	assert.Equal(t, 0, replayed.Attempts)
+	assert.Equal(t, now, replayed.NextAttemptAt)
+
+	_, err = Event{Status: enum.EventStatusDelivered}.Replay(now)
+	assert.Equal(t, &apperror.ValidationError{Msg: "only dead events can be replayed"}, err)
+}
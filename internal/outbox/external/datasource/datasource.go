@@ -0,0 +1,17 @@
+package datasource
+
+import (
+	"context"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/dto"
+)
+
+type DataSource interface {
+	CreateBulk(ctx context.Context, events []dto.OutboxEventDAO) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]dto.OutboxEventDAO, error)
+	Update(ctx context.Context, event dto.OutboxEventDAO) error
+	Complete(ctx context.Context, event dto.OutboxEventDAO, claimedUntil time.Time) (bool, error)
+	FindByID(ctx context.Context, id string) (dto.OutboxEventDAO, error)
+	FindByStatus(ctx context.Context, status string, limit int) ([]dto.OutboxEventDAO, error)
+}
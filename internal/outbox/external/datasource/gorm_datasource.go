@@ -0,0 +1,142 @@
+package datasource
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/database"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity/enum"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DB interface defines the database operations needed
+type DB interface {
+	Create(value any) *gorm.DB
+	Where(query any, args ...any) *gorm.DB
+	First(dest any, conds ...any) *gorm.DB
+	Find(dest any, conds ...any) *gorm.DB
+	Model(value any) *gorm.DB
+	Save(value any) *gorm.DB
+	Order(value any) *gorm.DB
+	Clauses(conds ...clause.Expression) *gorm.DB
+	Transaction(fc func(tx *gorm.DB) error, opts ...*sql.TxOptions) error
+}
+
+type GormDataSource struct {
+	db DB
+}
+
+func New(db DB) DataSource {
+	return &GormDataSource{
+		db: db,
+	}
+}
+
+func (g *GormDataSource) CreateBulk(ctx context.Context, events []dto.OutboxEventDAO) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return database.Conn(ctx, g.db).Create(&events).Error
+}
+
+// ClaimDue locks the pending events that are due and pushes their next attempt forward by lease,
+// so concurrent relays (other replicas) skip them while they are being delivered. The events come
+// back with that next attempt, which Complete checks to tell the claim still holds.
+// Events of an aggregate are delivered in the order they were created: only the oldest pending
+// one can be claimed, so a later event waits while an earlier one is being retried. Dead-lettered
+// events do not hold the others back.
+func (g *GormDataSource) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]dto.OutboxEventDAO, error) {
+	var events []dto.OutboxEventDAO
+
+	err := g.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", enum.EventStatusPending, now).
+			Where(`NOT EXISTS (
+				SELECT 1 FROM outbox_events earlier
+				WHERE earlier.aggregate_id = outbox_events.aggregate_id
+				AND earlier.status = ?
+				AND (earlier.created_at, earlier.id) < (outbox_events.created_at, outbox_events.id)
+			)`, enum.EventStatusPending).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&events).Error; err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		// Postgres keeps microseconds, so the claim is compared exactly as it was stored
+		claimedUntil := now.Add(lease).Truncate(time.Microsecond)
+		ids := make([]string, 0, len(events))
+		for i := range events {
+			ids = append(ids, events[i].ID)
+			events[i].NextAttemptAt = claimedUntil
+		}
+		return tx.Model(&dto.OutboxEventDAO{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", claimedUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (g *GormDataSource) Update(ctx context.Context, event dto.OutboxEventDAO) error {
+	return database.Conn(ctx, g.db).Save(&event).Error
+}
+
+// Complete saves the outcome of a delivery if the event is still claimed until claimedUntil.
+// It reports false when the claim lapsed and another relay took the event over.
+func (g *GormDataSource) Complete(ctx context.Context, event dto.OutboxEventDAO, claimedUntil time.Time) (bool, error) {
+	tx := database.Conn(ctx, g.db).Model(&dto.OutboxEventDAO{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", event.ID, enum.EventStatusPending, claimedUntil).
+		Updates(map[string]any{
+			"status":          event.Status,
+			"attempts":        event.Attempts,
+			"next_attempt_at": event.NextAttemptAt,
+			"last_error":      event.LastError,
+			"updated_at":      event.UpdatedAt,
+			"delivered_at":    event.DeliveredAt,
+		})
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	return tx.RowsAffected > 0, nil
+}
+
+func (g *GormDataSource) FindByID(ctx context.Context, id string) (dto.OutboxEventDAO, error) {
+	var event dto.OutboxEventDAO
+
+	if err := database.Conn(ctx, g.db).First(&event, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.OutboxEventDAO{}, &apperror.NotFoundError{Msg: "Outbox event not found"}
+		}
+		return dto.OutboxEventDAO{}, err
+	}
+
+	return event, nil
+}
+
+func (g *GormDataSource) FindByStatus(ctx context.Context, status string, limit int) ([]dto.OutboxEventDAO, error) {
+	var events []dto.OutboxEventDAO
+
+	if err := database.Conn(ctx, g.db).
+		Where("status = ?", status).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
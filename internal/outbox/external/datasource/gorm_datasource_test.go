@@ -0,0 +1,132 @@
+package datasource
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity/enum"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// testDB opens TEST_DATABASE_URL inside a transaction rolled back at the end of the test, so
+// ClaimDue runs its locking query on Postgres without leaving rows behind
+func testDB(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("could not connect to the test database: %v", err)
+	}
+	tx := db.Begin()
+	t.Cleanup(func() { tx.Rollback() })
+
+	if err := tx.AutoMigrate(&dto.OutboxEventDAO{}); err != nil {
+		t.Fatalf("could not migrate outbox_events: %v", err)
+	}
+	return tx
+}
+
+func TestGormDataSource_ClaimDue(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	event := func(aggregateID string, eventType enum.EventType, status enum.EventStatus, createdAt, nextAttemptAt time.Time) dto.OutboxEventDAO {
+		return dto.OutboxEventDAO{
+			ID:            uuid.NewString(),
+			AggregateID:   aggregateID,
+			Type:          eventType,
+			Payload:       "{}",
+			Status:        status,
+			NextAttemptAt: nextAttemptAt,
+			CreatedAt:     createdAt,
+			UpdatedAt:     createdAt,
+		}
+	}
+	claimedOf := func(claimed []dto.OutboxEventDAO, aggregateID string) []string {
+		var ids []string
+		for _, e := range claimed {
+			if e.AggregateID == aggregateID {
+				ids = append(ids, e.ID)
+			}
+		}
+		return ids
+	}
+
+	t.Run("Given an order whose payment creation is backing off, when claiming, then its cancellation waits for it", func(t *testing.T) {
+		ds := New(testDB(t))
+		orderID := uuid.NewString()
+		create := event(orderID, enum.EventTypeCreatePayment, enum.EventStatusPending, now.Add(-time.Minute), now.Add(time.Minute))
+		cancel := event(orderID, enum.EventTypeCancelPayment, enum.EventStatusPending, now.Add(-30*time.Second), now.Add(-30*time.Second))
+		assert.NoError(t, ds.CreateBulk(context.Background(), []dto.OutboxEventDAO{create, cancel}))
+
+		claimed, err := ds.ClaimDue(context.Background(), now, time.Minute, 100)
+
+		assert.NoError(t, err)
+		assert.Empty(t, claimedOf(claimed, orderID))
+	})
+
+	t.Run("Given an order with two due events, when claiming, then only the oldest one is claimed", func(t *testing.T) {
+		ds := New(testDB(t))
+		orderID := uuid.NewString()
+		create := event(orderID, enum.EventTypeCreatePayment, enum.EventStatusPending, now.Add(-time.Minute), now.Add(-time.Minute))
+		cancel := event(orderID, enum.EventTypeCancelPayment, enum.EventStatusPending, now.Add(-30*time.Second), now.Add(-30*time.Second))
+		assert.NoError(t, ds.CreateBulk(context.Background(), []dto.OutboxEventDAO{create, cancel}))
+
+		claimed, err := ds.ClaimDue(context.Background(), now, time.Minute, 100)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{create.ID}, claimedOf(claimed, orderID))
+	})
+
+	t.Run("Given an order whose earlier events were delivered or dead-lettered, when claiming, then its next event is claimed", func(t *testing.T) {
+		ds := New(testDB(t))
+		orderID := uuid.NewString()
+		create := event(orderID, enum.EventTypeCreatePayment, enum.EventStatusDelivered, now.Add(-2*time.Minute), now.Add(-2*time.Minute))
+		dead := event(orderID, enum.EventTypeCancelPayment, enum.EventStatusDead, now.Add(-time.Minute), now.Add(-time.Minute))
+		cancel := event(orderID, enum.EventTypeCancelPayment, enum.EventStatusPending, now.Add(-30*time.Second), now.Add(-30*time.Second))
+		assert.NoError(t, ds.CreateBulk(context.Background(), []dto.OutboxEventDAO{create, dead, cancel}))
+
+		claimed, err := ds.ClaimDue(context.Background(), now, time.Minute, 100)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{cancel.ID}, claimedOf(claimed, orderID))
+	})
+
+	t.Run("Given an event claimed again after its lease lapsed, when the first relay completes it, then nothing is saved", func(t *testing.T) {
+		ds := New(testDB(t))
+		orderID := uuid.NewString()
+		create := event(orderID, enum.EventTypeCreatePayment, enum.EventStatusPending, now.Add(-time.Minute), now.Add(-time.Minute))
+		assert.NoError(t, ds.CreateBulk(context.Background(), []dto.OutboxEventDAO{create}))
+
+		first, err := ds.ClaimDue(context.Background(), now, time.Minute, 100)
+		assert.NoError(t, err)
+		firstClaim := claimedOf(first, orderID)
+		assert.Equal(t, []string{create.ID}, firstClaim)
+		second, err := ds.ClaimDue(context.Background(), now.Add(2*time.Minute), time.Minute, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{create.ID}, claimedOf(second, orderID))
+
+		var claimed dto.OutboxEventDAO
+		for _, e := range first {
+			if e.ID == create.ID {
+				claimed = e
+			}
+		}
+		claimedUntil := claimed.NextAttemptAt
+		claimed.Status = enum.EventStatusDelivered
+		saved, err := ds.Complete(context.Background(), claimed, claimedUntil)
+
+		assert.NoError(t, err)
+		assert.False(t, saved)
+		stored, err := ds.FindByID(context.Background(), create.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, enum.EventStatusPending, stored.Status)
+	})
+}
@@ -0,0 +1,74 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/external/datasource"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+)
+
+type Gateway struct {
+	datasource datasource.DataSource
+}
+
+func Build(datasource datasource.DataSource) *Gateway {
+	return &Gateway{
+		datasource: datasource,
+	}
+}
+
+func (g *Gateway) CreateBulk(ctx context.Context, events []entity.Event) error {
+	if err := g.datasource.CreateBulk(ctx, dto.ToListOutboxEventDAO(events)); err != nil {
+		return &apperror.InternalError{Msg: err.Error()}
+	}
+	return nil
+}
+
+func (g *Gateway) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entity.Event, error) {
+	claimed, err := g.datasource.ClaimDue(ctx, now, lease, limit)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.EntityListFromDAOList(claimed), nil
+}
+
+func (g *Gateway) Update(ctx context.Context, event entity.Event) error {
+	if err := g.datasource.Update(ctx, dto.ToOutboxEventDAO(event)); err != nil {
+		return &apperror.InternalError{Msg: err.Error()}
+	}
+	return nil
+}
+
+// Complete saves the outcome of a delivery unless the claim on the event lapsed, and tells which
+func (g *Gateway) Complete(ctx context.Context, event entity.Event, claimedUntil time.Time) (bool, error) {
+	saved, err := g.datasource.Complete(ctx, dto.ToOutboxEventDAO(event), claimedUntil)
+	if err != nil {
+		return false, &apperror.InternalError{Msg: err.Error()}
+	}
+	return saved, nil
+}
+
+func (g *Gateway) FindByID(ctx context.Context, id string) (entity.Event, error) {
+	found, err := g.datasource.FindByID(ctx, id)
+	if err != nil {
+		var notFoundErr *apperror.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return entity.Event{}, notFoundErr
+		}
+		return entity.Event{}, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.FromOutboxEventDAO(found), nil
+}
+
+func (g *Gateway) FindByStatus(ctx context.Context, status enum.EventStatus, limit int) ([]entity.Event, error) {
+	found, err := g.datasource.FindByStatus(ctx, status.String(), limit)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.EntityListFromDAOList(found), nil
+}
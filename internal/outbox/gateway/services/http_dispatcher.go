@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/interfaces"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/httpclient"
+)
+
+// HTTPDispatcher delivers outbox events to the payment and operation services over HTTP
+type HTTPDispatcher struct {
+	paymentClient   *httpclient.PaymentServiceClient
+	operationClient *httpclient.OperationServiceClient
+}
+
+func NewHTTPDispatcher() interfaces.Dispatcher {
+	return &HTTPDispatcher{
+		paymentClient:   httpclient.NewPaymentServiceClient(),
+		operationClient: httpclient.NewOperationServiceClient(),
+	}
+}
+
+func (d *HTTPDispatcher) Dispatch(ctx context.Context, event entity.Event) error {
+	payload, err := event.OrderPayload()
+	if err != nil {
+		return fmt.Errorf("invalid payload: %w", err)
+	}
+
+	switch event.Type {
+	case enum.EventTypeCreatePayment:
+		_, err := d.paymentClient.CreatePayment(ctx, payload.OrderID)
+		return err
+	case enum.EventTypeCancelPayment:
+		return d.paymentClient.CancelPayment(ctx, payload.OrderID, payload.Reason)
+	case enum.EventTypeNotifyOperation:
+		return d.operationClient.NotifyNewOrder(ctx, payload.OrderID, payload.Status)
+	default:
+		return fmt.Errorf("unknown event type %s", event.Type)
+	}
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/controller"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/helper"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	controller *controller.Controller
+}
+
+func New(controller *controller.Controller) *Handler {
+	return &Handler{controller: controller}
+}
+
+// List Outbox Events godoc
+// @Summary      List outbox events
+// @Description  List the most recent outbox events with the given status. Defaults to dead-lettered events.
+// @Tags         Outbox
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        status query string false "Event status (pending, delivered, dead)"
+// @Success      200  {object}  dto.OutboxEventListResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/outbox [get]
+func (h *Handler) List(c *gin.Context) {
+	status := c.DefaultQuery("status", enum.EventStatusDead.String())
+
+	events, err := h.controller.ListByStatus(context.Background(), status)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// Replay Outbox Event godoc
+// @Summary      Replay outbox event
+// @Description  Put a dead-lettered outbox event back in the delivery queue
+// @Tags         Outbox
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Outbox event ID"
+// @Success      200  {object}  dto.OutboxEventResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/outbox/{id}/replay [post]
+func (h *Handler) Replay(c *gin.Context) {
+	id := c.Param("id")
+
+	event, err := h.controller.Replay(context.Background(), id)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
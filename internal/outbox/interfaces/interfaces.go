@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity"
+)
+
+// Dispatcher delivers an outbox event to the service it is addressed to
+type Dispatcher interface {
+	Dispatch(ctx context.Context, event entity.Event) error
+}
@@ -0,0 +1,39 @@
+package presenter
+
+import (
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity"
+)
+
+type Presenter struct{}
+
+func Build() *Presenter {
+	return &Presenter{}
+}
+
+func (p *Presenter) FromEntityToResponseDTO(event entity.Event) dto.OutboxEventResponseDTO {
+	return dto.OutboxEventResponseDTO{
+		ID:            event.ID,
+		AggregateID:   event.AggregateID,
+		Type:          event.Type.String(),
+		Payload:       event.Payload,
+		Status:        event.Status.String(),
+		Attempts:      event.Attempts,
+		NextAttemptAt: event.NextAttemptAt,
+		LastError:     event.LastError,
+		CreatedAt:     event.CreatedAt,
+		DeliveredAt:   event.DeliveredAt,
+	}
+}
+
+func (p *Presenter) FromEntityListToListResponseDTO(events []entity.Event) dto.OutboxEventListResponseDTO {
+	eventsDTO := make([]dto.OutboxEventResponseDTO, 0, len(events))
+	for _, event := range events {
+		eventsDTO = append(eventsDTO, p.FromEntityToResponseDTO(event))
+	}
+
+	return dto.OutboxEventListResponseDTO{
+		Total:  uint(len(eventsDTO)),
+		Events: eventsDTO,
+	}
+}
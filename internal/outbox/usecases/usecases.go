@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/gateway"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/interfaces"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+)
+
+const (
+	listLimit   = 100
+	deliveryTTL = 30 * time.Second
+	// claimMargin is added to the time a batch may take to be delivered, see claimLease
+	claimMargin = time.Minute
+)
+
+// claimLease is how long claimed events are hidden from other relays: long enough for every
+// event of the batch to be delivered one after the other, each taking up to deliveryTTL
+func claimLease(batchSize int) time.Duration {
+	return time.Duration(batchSize)*deliveryTTL + claimMargin
+}
+
+type UseCases struct {
+	outboxGateway gateway.Gateway
+	dispatcher    interfaces.Dispatcher
+}
+
+func Build(outboxGateway gateway.Gateway, dispatcher interfaces.Dispatcher) *UseCases {
+	return &UseCases{
+		outboxGateway: outboxGateway,
+		dispatcher:    dispatcher,
+	}
+}
+
+// Enqueue stores events for later delivery. Called inside a unit of work, the events
+// are committed together with the change that produced them.
+func (u *UseCases) Enqueue(ctx context.Context, events ...entity.Event) error {
+	return u.outboxGateway.CreateBulk(ctx, events)
+}
+
+// RelayDue delivers up to batchSize due events and returns how many were delivered.
+// Failed deliveries are rescheduled with backoff and dead-lettered after entity.MaxAttempts.
+// An outcome is only saved while the claim on the event holds, so a relay that took the event
+// over after the lease lapsed is not overwritten.
+func (u *UseCases) RelayDue(ctx context.Context, batchSize int) (int, error) {
+	events, err := u.outboxGateway.ClaimDue(ctx, time.Now(), claimLease(batchSize), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, event := range events {
+		claimedUntil := event.NextAttemptAt
+		deliveryCtx, cancel := context.WithTimeout(ctx, deliveryTTL)
+		dispatchErr := u.dispatcher.Dispatch(deliveryCtx, event)
+		cancel()
+
+		if dispatchErr != nil {
+			event = event.MarkFailed(dispatchErr, time.Now())
+			if event.Status == enum.EventStatusDead {
+				log.Printf("Outbox event %s (%s) dead-lettered after %d attempts: %v", event.ID, event.Type, event.Attempts, dispatchErr)
+			}
+		} else {
+			event = event.MarkDelivered(time.Now())
+			delivered++
+		}
+
+		saved, err := u.outboxGateway.Complete(ctx, event, claimedUntil)
+		if err != nil {
+			log.Printf("Failed to save outbox event %s: %v", event.ID, err)
+		} else if !saved {
+			log.Printf("Outbox event %s was claimed again after its lease lapsed, its outcome is left to that relay", event.ID)
+		}
+	}
+
+	return delivered, nil
+}
+
+func (u *UseCases) ListByStatus(ctx context.Context, status string) ([]entity.Event, error) {
+	eventStatus, ok := enum.StatusMapper[status]
+	if !ok {
+		return nil, &apperror.ValidationError{Msg: "invalid outbox status: " + status}
+	}
+	return u.outboxGateway.FindByStatus(ctx, eventStatus, listLimit)
+}
+
+func (u *UseCases) Replay(ctx context.Context, id string) (entity.Event, error) {
+	event, err := u.outboxGateway.FindByID(ctx, id)
+	if err != nil {
+		return entity.Event{}, err
+	}
+
+	replayed, err := event.Replay(time.Now())
+	if err != nil {
+		return entity.Event{}, err
+	}
+
+	if err := u.outboxGateway.Update(ctx, replayed); err != nil {
+		return entity.Event{}, err
+	}
+
+	return replayed, nil
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/external/datasource"
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/gateway"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryOutboxDataSource claims and completes events the way the persisted datasource does;
+// the methods the tests do not reach are left to the embedded interface
+type memoryOutboxDataSource struct {
+	datasource.DataSource
+	events map[string]dto.OutboxEventDAO
+	lease  time.Duration
+}
+
+func (d *memoryOutboxDataSource) ClaimDue(_ context.Context, now time.Time, lease time.Duration, limit int) ([]dto.OutboxEventDAO, error) {
+	d.lease = lease
+	claimedUntil := now.Add(lease).Truncate(time.Microsecond)
+
+	var claimed []dto.OutboxEventDAO
+	for id, event := range d.events {
+		if len(claimed) == limit || event.Status != enum.EventStatusPending || event.NextAttemptAt.After(now) {
+			continue
+		}
+		event.NextAttemptAt = claimedUntil
+		d.events[id] = event
+		claimed = append(claimed, event)
+	}
+	return claimed, nil
+}
+
+func (d *memoryOutboxDataSource) Complete(_ context.Context, event dto.OutboxEventDAO, claimedUntil time.Time) (bool, error) {
+	current := d.events[event.ID]
+	if current.Status != enum.EventStatusPending || !current.NextAttemptAt.Equal(claimedUntil) {
+		return false, nil
+	}
+	d.events[event.ID] = event
+	return true, nil
+}
+
+// dispatcherFunc delivers events with a function
+type dispatcherFunc func(ctx context.Context, event entity.Event) error
+
+func (f dispatcherFunc) Dispatch(ctx context.Context, event entity.Event) error {
+	return f(ctx, event)
+}
+
+func TestUseCases_RelayDue(t *testing.T) {
+	due := func(id string) dto.OutboxEventDAO {
+		createdAt := time.Now().Add(-time.Minute)
+		return dto.OutboxEventDAO{
+			ID:            id,
+			AggregateID:   "order-" + id,
+			Type:          enum.EventTypeCreatePayment,
+			Payload:       "{}",
+			Status:        enum.EventStatusPending,
+			NextAttemptAt: createdAt,
+			CreatedAt:     createdAt,
+			UpdatedAt:     createdAt,
+		}
+	}
+
+	t.Run("Given a batch, when relaying, then the events stay claimed for as long as delivering every one of them may take", func(t *testing.T) {
+		outboxDataSource := &memoryOutboxDataSource{events: map[string]dto.OutboxEventDAO{"event-1": due("event-1")}}
+		u := Build(*gateway.Build(outboxDataSource), dispatcherFunc(func(context.Context, entity.Event) error { return nil }))
+
+		delivered, err := u.RelayDue(context.Background(), 50)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.GreaterOrEqual(t, outboxDataSource.lease, 50*deliveryTTL)
+		assert.Equal(t, enum.EventStatusDelivered, outboxDataSource.events["event-1"].Status)
+	})
+
+	t.Run("Given another relay claimed an event after its lease lapsed, when the delivery ends, then its outcome does not overwrite that relay's claim", func(t *testing.T) {
+		outboxDataSource := &memoryOutboxDataSource{events: map[string]dto.OutboxEventDAO{"event-1": due("event-1")}}
+		var reclaimedUntil time.Time
+		u := Build(*gateway.Build(outboxDataSource), dispatcherFunc(func(_ context.Context, event entity.Event) error {
+			// The lease lapses while delivering and another relay claims the event again
+			reclaimed := outboxDataSource.events[event.ID]
+			reclaimedUntil = reclaimed.NextAttemptAt.Add(time.Hour)
+			reclaimed.NextAttemptAt = reclaimedUntil
+			outboxDataSource.events[event.ID] = reclaimed
+			return nil
+		}))
+
+		_, err := u.RelayDue(context.Background(), 50)
+
+		assert.NoError(t, err)
+		event := outboxDataSource.events["event-1"]
+		assert.Equal(t, enum.EventStatusPending, event.Status)
+		assert.Equal(t, 0, event.Attempts)
+		assert.True(t, event.NextAttemptAt.Equal(reclaimedUntil))
+	})
+}
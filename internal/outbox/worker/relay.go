@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/outbox/controller"
+)
+
+const batchSize = 50
+
+// Relay periodically delivers the due outbox events to the downstream services
+type Relay struct {
+	controller *controller.Controller
+	interval   time.Duration
+}
+
+func NewRelay(controller *controller.Controller, interval time.Duration) *Relay {
+	return &Relay{
+		controller: controller,
+		interval:   interval,
+	}
+}
+
+// Start relays on every tick until ctx is cancelled. A full batch is followed
+// by another one right away so a backlog drains without waiting for the next tick.
+func (r *Relay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	log.Printf("Outbox relay started (interval %s)", r.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.drain(ctx)
+		}
+	}
+}
+
+func (r *Relay) drain(ctx context.Context) {
+	for {
+		delivered, err := r.controller.RelayDue(ctx, batchSize)
+		if err != nil {
+			log.Printf("Failed to relay outbox events: %v", err)
+			return
+		}
+		if delivered < batchSize {
+			return
+		}
+	}
+}
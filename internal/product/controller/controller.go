@@ -3,12 +3,13 @@ package controller
 import (
 	"context"
 	"mime/multipart"
+	"time"
 
 	"github.com/fiap-161/tc-golunch-core-service/internal/product/dto"
-	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
 	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
 	"github.com/fiap-161/tc-golunch-core-service/internal/product/external/datasource"
 	"github.com/fiap-161/tc-golunch-core-service/internal/product/gateway"
+	"github.com/fiap-161/tc-golunch-core-service/internal/product/interfaces"
 	"github.com/fiap-161/tc-golunch-core-service/internal/product/presenter"
 	"github.com/fiap-161/tc-golunch-core-service/internal/product/usecases"
 )
@@ -17,22 +18,34 @@ import (
 
 // INFO: controllers Criam gateways e requisitam usecases
 type Controller struct {
-	productDatasource datasource.DataSource
+	productDatasource   datasource.DataSource
+	location            *time.Location
+	unitOfWork          interfaces.UnitOfWork
+	categoryService     interfaces.CategoryService
+	productOrderService interfaces.ProductOrderService
+	imageStorage        interfaces.ImageStorage
 }
 
-func Build(productDataSource datasource.DataSource) *Controller {
+func Build(productDataSource datasource.DataSource, location *time.Location, unitOfWork interfaces.UnitOfWork, categoryService interfaces.CategoryService, productOrderService interfaces.ProductOrderService, imageStorage interfaces.ImageStorage) *Controller {
 	return &Controller{
-		productDatasource: productDataSource,
+		productDatasource:   productDataSource,
+		location:            location,
+		unitOfWork:          unitOfWork,
+		categoryService:     categoryService,
+		productOrderService: productOrderService,
+		imageStorage:        imageStorage,
 	}
 }
 
 func (c *Controller) Create(ctx context.Context, productDTO dto.ProductRequestDTO) (dto.ProductResponseDTO, error) {
 	productGateway := gateway.Build(c.productDatasource)
-	useCase := usecases.Build(*productGateway)
+	useCase := usecases.Build(*productGateway, c.location, c.unitOfWork, c.categoryService, c.productOrderService, c.imageStorage)
 	presenter := presenter.Build()
 
-	var product entity.Product
-	product = dto.FromRequestDTO(productDTO)
+	product, err := dto.FromRequestDTO(productDTO)
+	if err != nil {
+		return dto.ProductResponseDTO{}, err
+	}
 	createdProduct, createErr := useCase.CreateProduct(ctx, product)
 	if createErr != nil {
 		return dto.ProductResponseDTO{}, createErr
@@ -41,25 +54,50 @@ func (c *Controller) Create(ctx context.Context, productDTO dto.ProductRequestDT
 	return presenter.FromEntityToResponseDTO(createdProduct), nil
 }
 
-func (c *Controller) ListCategories(ctx context.Context) []enum.Category {
+func (c *Controller) ListCategories(ctx context.Context) ([]enum.Category, error) {
 	productGateway := gateway.Build(c.productDatasource)
-	useCase := usecases.Build(*productGateway)
+	useCase := usecases.Build(*productGateway, c.location, c.unitOfWork, c.categoryService, c.productOrderService, c.imageStorage)
 	return useCase.ListCategories(ctx)
 }
 
 func (c *Controller) UploadImage(ctx context.Context, fileHeader *multipart.FileHeader) (string, error) {
 	productGateway := gateway.Build(c.productDatasource)
-	useCase := usecases.Build(*productGateway)
+	useCase := usecases.Build(*productGateway, c.location, c.unitOfWork, c.categoryService, c.productOrderService, c.imageStorage)
 	return useCase.UploadImage(ctx, fileHeader)
 }
 
-func (c *Controller) GetAllByCategory(ctx context.Context, category string) (dto.ProductListResponseDTO, error) {
+func (c *Controller) PresignImageUpload(ctx context.Context, request dto.ImageUploadRequestDTO) (dto.ImageUploadResponseDTO, error) {
 	productGateway := gateway.Build(c.productDatasource)
-	useCase := usecases.Build(*productGateway)
+	useCase := usecases.Build(*productGateway, c.location, c.unitOfWork, c.categoryService, c.productOrderService, c.imageStorage)
+
+	upload, err := useCase.PresignImageUpload(ctx, request.ContentType)
+	if err != nil {
+		return dto.ImageUploadResponseDTO{}, err
+	}
+
+	return dto.ToImageUploadResponseDTO(upload), nil
+}
+
+func (c *Controller) GetAllByCategory(ctx context.Context, category string, availableOnly bool) (dto.ProductListResponseDTO, error) {
+	productGateway := gateway.Build(c.productDatasource)
+	useCase := usecases.Build(*productGateway, c.location, c.unitOfWork, c.categoryService, c.productOrderService, c.imageStorage)
 	presenter := presenter.Build()
 
-	result, err := useCase.GetAllByCategory(ctx, category)
+	result, err := useCase.GetAllByCategory(ctx, category, availableOnly)
+
+	if err != nil {
+		return dto.ProductListResponseDTO{}, err
+	}
+
+	return presenter.FromEntityListToProductListResponseDTO(result), nil
+}
+
+func (c *Controller) Search(ctx context.Context, query dto.ProductSearchQueryDTO) (dto.ProductListResponseDTO, error) {
+	productGateway := gateway.Build(c.productDatasource)
+	useCase := usecases.Build(*productGateway, c.location, c.unitOfWork, c.categoryService, c.productOrderService, c.imageStorage)
+	presenter := presenter.Build()
 
+	result, err := useCase.Search(ctx, dto.ToSearchQuery(query))
 	if err != nil {
 		return dto.ProductListResponseDTO{}, err
 	}
@@ -67,12 +105,15 @@ func (c *Controller) GetAllByCategory(ctx context.Context, category string) (dto
 	return presenter.FromEntityListToProductListResponseDTO(result), nil
 }
 
-func (c *Controller) Update(ctx context.Context, productId string, productDTO dto.ProductRequestUpdateDTO) (dto.ProductResponseDTO, error) {
+func (c *Controller) Update(ctx context.Context, productId string, productDTO dto.ProductRequestUpdateDTO, expectedVersion *uint) (dto.ProductResponseDTO, error) {
 	productGateway := gateway.Build(c.productDatasource)
-	useCase := usecases.Build(*productGateway)
+	useCase := usecases.Build(*productGateway, c.location, c.unitOfWork, c.categoryService, c.productOrderService, c.imageStorage)
 	presenter := presenter.Build()
 
 	product := dto.FromUpdateDTO(productDTO)
+	if expectedVersion != nil {
+		product.Version = *expectedVersion
+	}
 	result, err := useCase.Update(ctx, productId, product)
 	if err != nil {
 		return dto.ProductResponseDTO{}, err
@@ -83,7 +124,7 @@ func (c *Controller) Update(ctx context.Context, productId string, productDTO dt
 
 func (c *Controller) FindByID(ctx context.Context, productId string) (dto.ProductResponseDTO, error) {
 	productGateway := gateway.Build(c.productDatasource)
-	useCase := usecases.Build(*productGateway)
+	useCase := usecases.Build(*productGateway, c.location, c.unitOfWork, c.categoryService, c.productOrderService, c.imageStorage)
 	presenter := presenter.Build()
 
 	result, err := useCase.FindByID(ctx, productId)
@@ -94,9 +135,27 @@ func (c *Controller) FindByID(ctx context.Context, productId string) (dto.Produc
 	return presenter.FromEntityToResponseDTO(result), nil
 }
 
+func (c *Controller) SetAvailability(ctx context.Context, productId string, request dto.ProductAvailabilityRequestDTO) (dto.ProductResponseDTO, error) {
+	productGateway := gateway.Build(c.productDatasource)
+	useCase := usecases.Build(*productGateway, c.location, c.unitOfWork, c.categoryService, c.productOrderService, c.imageStorage)
+	presenter := presenter.Build()
+
+	schedule, err := dto.FromMenuScheduleDTO(request.Schedule)
+	if err != nil {
+		return dto.ProductResponseDTO{}, err
+	}
+
+	result, err := useCase.SetAvailability(ctx, productId, *request.Available, schedule)
+	if err != nil {
+		return dto.ProductResponseDTO{}, err
+	}
+
+	return presenter.FromEntityToResponseDTO(result), nil
+}
+
 func (c *Controller) Delete(ctx context.Context, productId string) error {
 	productGateway := gateway.Build(c.productDatasource)
-	useCase := usecases.Build(*productGateway)
+	useCase := usecases.Build(*productGateway, c.location, c.unitOfWork, c.categoryService, c.productOrderService, c.imageStorage)
 
 	err := useCase.Delete(ctx, productId)
 	if err != nil {
@@ -106,9 +165,16 @@ func (c *Controller) Delete(ctx context.Context, productId string) error {
 	return nil
 }
 
+func (c *Controller) Purge(ctx context.Context, productId string) error {
+	productGateway := gateway.Build(c.productDatasource)
+	useCase := usecases.Build(*productGateway, c.location, c.unitOfWork, c.categoryService, c.productOrderService, c.imageStorage)
+
+	return useCase.Purge(ctx, productId)
+}
+
 func (c *Controller) FindByIDs(ctx context.Context, productIdList []string) ([]dto.ProductResponseDTO, error) {
 	productGateway := gateway.Build(c.productDatasource)
-	useCase := usecases.Build(*productGateway)
+	useCase := usecases.Build(*productGateway, c.location, c.unitOfWork, c.categoryService, c.productOrderService, c.imageStorage)
 	presenter := presenter.Build()
 
 	result, err := useCase.FindByIDs(ctx, productIdList)
@@ -118,3 +184,42 @@ func (c *Controller) FindByIDs(ctx context.Context, productIdList []string) ([]d
 
 	return presenter.FromEntityListToProductListResponseDTO(result).List, nil
 }
+
+func (c *Controller) ListArchived(ctx context.Context) (dto.ProductListResponseDTO, error) {
+	productGateway := gateway.Build(c.productDatasource)
+	useCase := usecases.Build(*productGateway, c.location, c.unitOfWork, c.categoryService, c.productOrderService, c.imageStorage)
+	presenter := presenter.Build()
+
+	result, err := useCase.ListArchived(ctx)
+	if err != nil {
+		return dto.ProductListResponseDTO{}, err
+	}
+
+	return presenter.FromEntityListToProductListResponseDTO(result), nil
+}
+
+func (c *Controller) Restore(ctx context.Context, productId string) (dto.ProductResponseDTO, error) {
+	productGateway := gateway.Build(c.productDatasource)
+	useCase := usecases.Build(*productGateway, c.location, c.unitOfWork, c.categoryService, c.productOrderService, c.imageStorage)
+	presenter := presenter.Build()
+
+	result, err := useCase.Restore(ctx, productId)
+	if err != nil {
+		return dto.ProductResponseDTO{}, err
+	}
+
+	return presenter.FromEntityToResponseDTO(result), nil
+}
+
+func (c *Controller) History(ctx context.Context, productId string) (dto.ProductHistoryResponseDTO, error) {
+	productGateway := gateway.Build(c.productDatasource)
+	useCase := usecases.Build(*productGateway, c.location, c.unitOfWork, c.categoryService, c.productOrderService, c.imageStorage)
+	presenter := presenter.Build()
+
+	revisions, err := useCase.History(ctx, productId)
+	if err != nil {
+		return dto.ProductHistoryResponseDTO{}, err
+	}
+
+	return presenter.FromRevisionsToHistoryDTO(productId, revisions), nil
+}
@@ -1,32 +1,47 @@
 package dto
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
 	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
 	coreentity "github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type ProductRequestDTO struct {
-	Name          string        `json:"name" binding:"required"`
-	Price         float64       `json:"price" binding:"required"`
-	Description   string        `json:"description" binding:"required"`
-	PreparingTime uint          `json:"preparing_time" binding:"required"`
-	Category      enum.Category `json:"category" binding:"required"`
-	ImageURL      string        `json:"image_url" binding:"required,url"`
+	Name           string             `json:"name" binding:"required"`
+	Price          money.Money        `json:"price"`
+	Description    string             `json:"description" binding:"required"`
+	PreparingTime  uint               `json:"preparing_time" binding:"required"`
+	Category       enum.Category      `json:"category" binding:"required"`
+	ImageURL       string             `json:"image_url" binding:"required,url"`
+	ModifierGroups []ModifierGroupDTO `json:"modifier_groups"`
+	// Available defaults to true
+	Available *bool            `json:"available"`
+	Schedule  *MenuScheduleDTO `json:"schedule"`
 }
 
 type ProductResponseDTO struct {
-	ID            string        `json:"id"`
-	Name          string        `json:"name"`
-	Price         float64       `json:"price"`
-	Description   string        `json:"description"`
-	PreparingTime uint          `json:"preparing_time"`
-	Category      enum.Category `json:"category"`
-	ImageURL      string        `json:"image_url"`
+	ID             string             `json:"id"`
+	Name           string             `json:"name"`
+	Price          money.Money        `json:"price"`
+	Description    string             `json:"description"`
+	PreparingTime  uint               `json:"preparing_time"`
+	Category       enum.Category      `json:"category"`
+	ImageURL       string             `json:"image_url"`
+	ModifierGroups []ModifierGroupDTO `json:"modifier_groups"`
+	Available      bool               `json:"available"`
+	Schedule       *MenuScheduleDTO   `json:"schedule,omitempty"`
+	ArchivedAt     *time.Time         `json:"archived_at,omitempty"`
+	Version        uint               `json:"version"`
 }
 
 type ProductListResponseDTO struct {
@@ -36,25 +51,139 @@ type ProductListResponseDTO struct {
 
 type ProductRequestUpdateDTO struct {
 	Name          string        `json:"name"`
-	Price         float64       `json:"price"`
+	Price         money.Money   `json:"price"`
 	Description   string        `json:"description"`
 	PreparingTime uint          `json:"preparing_time"`
 	Category      enum.Category `json:"category"`
 	ImageURL      string        `json:"image_url"`
+	// ModifierGroups replaces every group of the product when present, an empty list removes them
+	ModifierGroups []ModifierGroupDTO `json:"modifier_groups"`
+}
+
+// ModifierGroupDTO is also the JSON stored in the modifier_groups column of products.
+// Groups and options sent without an ID get one when the product is saved.
+type ModifierGroupDTO struct {
+	ID            string              `json:"id"`
+	Name          string              `json:"name"`
+	Required      bool                `json:"required"`
+	MinSelections int                 `json:"min_selections"`
+	MaxSelections int                 `json:"max_selections"`
+	Options       []ModifierOptionDTO `json:"options"`
+}
+
+type ModifierOptionDTO struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	PriceDelta money.Money `json:"price_delta"`
+}
+
+// ProductAvailabilityRequestDTO switches a product on or off and replaces its menu schedule; a
+// missing schedule puts the product on the menu at every hour
+type ProductAvailabilityRequestDTO struct {
+	Available *bool            `json:"available" binding:"required"`
+	Schedule  *MenuScheduleDTO `json:"schedule"`
+}
+
+// MenuScheduleDTO is a daily window in the store timezone. Weekdays go from 0 (Sunday) to
+// 6 (Saturday) and none means every day. It is also the JSON stored in the schedule column of
+// products.
+type MenuScheduleDTO struct {
+	Weekdays []int  `json:"weekdays"`
+	Start    string `json:"start" example:"06:00"`
+	End      string `json:"end" example:"11:00"`
+}
+
+type ProductHistoryResponseDTO struct {
+	ProductID string               `json:"product_id"`
+	Revisions []ProductRevisionDTO `json:"revisions"`
+}
+
+// ProductRevisionDTO is a version of the product, newest first. PreviousPrice is set when the
+// version changed the price.
+type ProductRevisionDTO struct {
+	Version       uint          `json:"version"`
+	Name          string        `json:"name"`
+	Category      enum.Category `json:"category"`
+	Price         money.Money   `json:"price"`
+	PreviousPrice *money.Money  `json:"previous_price,omitempty"`
+	Available     bool          `json:"available"`
+	ChangedAt     time.Time     `json:"changed_at"`
+}
+
+// ProductSearchQueryDTO holds the query string of GET /product/search
+type ProductSearchQueryDTO struct {
+	Q                string       `form:"q"`
+	Category         string       `form:"category"`
+	MinPrice         *money.Money `form:"min_price"`
+	MaxPrice         *money.Money `form:"max_price"`
+	MaxPreparingTime *uint        `form:"max_preparing_time"`
+	Sort             string       `form:"sort"`
+	Order            string       `form:"order"`
+	Limit            int          `form:"limit"`
+}
+
+// ProductSearchQuery is the search as seen by the datasource. Categories limits the products to
+// those filed under one of them.
+type ProductSearchQuery struct {
+	Text             string
+	Categories       []string
+	MinPrice         *int64
+	MaxPrice         *int64
+	MaxPreparingTime *uint
+	AvailableOnly    bool
+	SortBy           string
+	Descending       bool
+	Limit            int
 }
 
 type ImageURLDTO struct {
 	ImageURL string `json:"url"`
 }
 
+type ImageUploadRequestDTO struct {
+	ContentType string `json:"content_type" binding:"required" example:"image/png"`
+}
+
+// ImageUploadResponseDTO tells the client to PUT the image to upload_url with the headers, then
+// to use image_url as the image of a product
+type ImageUploadResponseDTO struct {
+	UploadURL string            `json:"upload_url"`
+	Method    string            `json:"method" example:"PUT"`
+	Headers   map[string]string `json:"headers"`
+	ImageURL  string            `json:"image_url"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
 type ProductDAO struct {
 	coreentity.Entity
-	Name          string        `json:"name"`
-	Price         float64       `json:"price" gorm:"type:decimal(10,2)"`
-	Description   string        `json:"description" gorm:"type:text"`
-	PreparingTime uint          `json:"preparing_time" gorm:"type:integer"`
-	Category      enum.Category `json:"category"`
-	ImageURL      string        `json:"image_url" gorm:"type:varchar(255)"`
+	Name           string        `json:"name"`
+	Price          money.Money   `json:"price" gorm:"embedded;embeddedPrefix:price_"`
+	Description    string        `json:"description" gorm:"type:text"`
+	PreparingTime  uint          `json:"preparing_time" gorm:"type:integer;index"`
+	Category       enum.Category `json:"category" gorm:"index"`
+	ImageURL       string        `json:"image_url" gorm:"type:varchar(255)"`
+	ModifierGroups string        `json:"modifier_groups" gorm:"type:jsonb;not null;default:'[]'"`
+	// Available is a pointer so that creating an unavailable product does not fall back to the default
+	Available *bool  `json:"available" gorm:"not null;default:true"`
+	Schedule  string `json:"schedule" gorm:"type:jsonb;not null;default:'null'"`
+	Version   uint   `json:"version" gorm:"not null;default:1"`
+	// DeletedAt archives the product: GORM leaves it out of every query that is not Unscoped
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+type ProductRevisionDAO struct {
+	ID        string        `gorm:"type:uuid;primaryKey"`
+	ProductID string        `gorm:"type:uuid;uniqueIndex:idx_product_revisions_version"`
+	Version   uint          `gorm:"not null;uniqueIndex:idx_product_revisions_version"`
+	Name      string        `gorm:"type:varchar(255)"`
+	Category  enum.Category `gorm:"type:varchar(20)"`
+	Price     money.Money   `gorm:"embedded;embeddedPrefix:price_"`
+	Available bool          `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+func (ProductRevisionDAO) TableName() string {
+	return "product_revisions"
 }
 
 // Convert entity entity to DAO
@@ -65,12 +194,16 @@ func ToProductDAO(p entity.Product) ProductDAO {
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		},
-		Name:          p.Name,
-		Price:         p.Price,
-		Description:   p.Description,
-		PreparingTime: p.PreparingTime,
-		Category:      p.Category,
-		ImageURL:      p.ImageURL,
+		Name:           p.Name,
+		Price:          p.Price,
+		Description:    p.Description,
+		PreparingTime:  p.PreparingTime,
+		Category:       p.Category,
+		ImageURL:       p.ImageURL,
+		ModifierGroups: encodeModifierGroups(p.ModifierGroups),
+		Available:      &p.Available,
+		Schedule:       EncodeMenuSchedule(p.Schedule),
+		Version:        p.Version,
 	}
 }
 
@@ -78,39 +211,59 @@ func ToProductDAO(p entity.Product) ProductDAO {
 func FromProductDAO(dao ProductDAO) entity.Product {
 	category := strings.ToUpper(string(dao.Category))
 	return entity.Product{
-		Id:            dao.ID,
-		Name:          dao.Name,
-		Price:         dao.Price,
-		Description:   dao.Description,
-		PreparingTime: dao.PreparingTime,
-		Category:      enum.Category(category),
-		ImageURL:      dao.ImageURL,
+		Id:             dao.ID,
+		Name:           dao.Name,
+		Price:          dao.Price,
+		Description:    dao.Description,
+		PreparingTime:  dao.PreparingTime,
+		Category:       enum.Category(category),
+		ImageURL:       dao.ImageURL,
+		ModifierGroups: decodeModifierGroups(dao.ModifierGroups),
+		Available:      dao.Available == nil || *dao.Available,
+		Schedule:       decodeMenuSchedule(dao.Schedule),
+		ArchivedAt:     archivedAt(dao.DeletedAt),
+		Version:        dao.Version,
+	}
+}
+
+func archivedAt(deletedAt gorm.DeletedAt) *time.Time {
+	if !deletedAt.Valid {
+		return nil
 	}
+	return &deletedAt.Time
 }
 
 // Convert request DTO to entity entity
-func FromRequestDTO(dto ProductRequestDTO) entity.Product {
+func FromRequestDTO(dto ProductRequestDTO) (entity.Product, error) {
 	category := strings.ToUpper(string(dto.Category))
-	return entity.Product{
-		Name:          dto.Name,
-		Price:         dto.Price,
-		Description:   dto.Description,
-		PreparingTime: dto.PreparingTime,
-		Category:      enum.Category(category),
-		ImageURL:      dto.ImageURL,
+	schedule, err := FromMenuScheduleDTO(dto.Schedule)
+	if err != nil {
+		return entity.Product{}, err
 	}
+	return entity.Product{
+		Name:           dto.Name,
+		Price:          dto.Price,
+		Description:    dto.Description,
+		PreparingTime:  dto.PreparingTime,
+		Category:       enum.Category(category),
+		ImageURL:       dto.ImageURL,
+		ModifierGroups: FromModifierGroupDTOList(dto.ModifierGroups),
+		Available:      dto.Available == nil || *dto.Available,
+		Schedule:       schedule,
+	}, nil
 }
 
 // Convert update request DTO to entity entity
 func FromUpdateDTO(dto ProductRequestUpdateDTO) entity.Product {
 	category := strings.ToUpper(string(dto.Category))
 	return entity.Product{
-		Name:          dto.Name,
-		Price:         dto.Price,
-		Description:   dto.Description,
-		PreparingTime: dto.PreparingTime,
-		Category:      enum.Category(category),
-		ImageURL:      dto.ImageURL,
+		Name:           dto.Name,
+		Price:          dto.Price,
+		Description:    dto.Description,
+		PreparingTime:  dto.PreparingTime,
+		Category:       enum.Category(category),
+		ImageURL:       dto.ImageURL,
+		ModifierGroups: FromModifierGroupDTOList(dto.ModifierGroups),
 	}
 }
 
@@ -121,3 +274,209 @@ func EntityListFromDAOList(daoList []ProductDAO) []entity.Product {
 	}
 	return products
 }
+
+func ToProductRevisionDAO(revision entity.Revision) ProductRevisionDAO {
+	return ProductRevisionDAO{
+		ID:        revision.ID,
+		ProductID: revision.ProductID,
+		Version:   revision.Version,
+		Name:      revision.Name,
+		Category:  revision.Category,
+		Price:     revision.Price,
+		Available: revision.Available,
+		CreatedAt: revision.CreatedAt,
+	}
+}
+
+func RevisionListFromDAOList(daoList []ProductRevisionDAO) []entity.Revision {
+	revisions := make([]entity.Revision, 0, len(daoList))
+	for _, dao := range daoList {
+		revisions = append(revisions, entity.Revision{
+			ID:        dao.ID,
+			ProductID: dao.ProductID,
+			Version:   dao.Version,
+			Name:      dao.Name,
+			Category:  dao.Category,
+			Price:     dao.Price,
+			Available: dao.Available,
+			CreatedAt: dao.CreatedAt,
+		})
+	}
+	return revisions
+}
+
+func ToModifierGroupDTOList(groups []entity.ModifierGroup) []ModifierGroupDTO {
+	if groups == nil {
+		return nil
+	}
+	result := make([]ModifierGroupDTO, 0, len(groups))
+	for _, group := range groups {
+		options := make([]ModifierOptionDTO, 0, len(group.Options))
+		for _, option := range group.Options {
+			options = append(options, ModifierOptionDTO{ID: option.ID, Name: option.Name, PriceDelta: option.PriceDelta})
+		}
+		result = append(result, ModifierGroupDTO{
+			ID:            group.ID,
+			Name:          group.Name,
+			Required:      group.Required,
+			MinSelections: group.MinSelections,
+			MaxSelections: group.MaxSelections,
+			Options:       options,
+		})
+	}
+	return result
+}
+
+// FromModifierGroupDTOList keeps a missing list nil, so an update can tell it apart from an empty one
+func FromModifierGroupDTOList(groups []ModifierGroupDTO) []entity.ModifierGroup {
+	if groups == nil {
+		return nil
+	}
+	result := make([]entity.ModifierGroup, 0, len(groups))
+	for _, group := range groups {
+		options := make([]entity.ModifierOption, 0, len(group.Options))
+		for _, option := range group.Options {
+			options = append(options, entity.ModifierOption{ID: option.ID, Name: option.Name, PriceDelta: option.PriceDelta})
+		}
+		result = append(result, entity.ModifierGroup{
+			ID:            group.ID,
+			Name:          group.Name,
+			Required:      group.Required,
+			MinSelections: group.MinSelections,
+			MaxSelections: group.MaxSelections,
+			Options:       options,
+		})
+	}
+	return result
+}
+
+// encodeModifierGroups leaves nil groups empty, which keeps the column default on create and the
+// stored groups on update
+func encodeModifierGroups(groups []entity.ModifierGroup) string {
+	if groups == nil {
+		return ""
+	}
+	raw, _ := json.Marshal(ToModifierGroupDTOList(groups))
+	return string(raw)
+}
+
+func decodeModifierGroups(raw string) []entity.ModifierGroup {
+	var groups []ModifierGroupDTO
+	if raw == "" || json.Unmarshal([]byte(raw), &groups) != nil {
+		return nil
+	}
+	return FromModifierGroupDTOList(groups)
+}
+
+func ToMenuScheduleDTO(schedule *entity.MenuSchedule) *MenuScheduleDTO {
+	if schedule == nil {
+		return nil
+	}
+	weekdays := make([]int, 0, len(schedule.Weekdays))
+	for _, weekday := range schedule.Weekdays {
+		weekdays = append(weekdays, int(weekday))
+	}
+	return &MenuScheduleDTO{
+		Weekdays: weekdays,
+		Start:    formatMinute(schedule.StartMinute),
+		End:      formatMinute(schedule.EndMinute),
+	}
+}
+
+func FromMenuScheduleDTO(schedule *MenuScheduleDTO) (*entity.MenuSchedule, error) {
+	if schedule == nil {
+		return nil, nil
+	}
+	start, err := parseMinute(schedule.Start)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseMinute(schedule.End)
+	if err != nil {
+		return nil, err
+	}
+	var weekdays []time.Weekday
+	for _, weekday := range schedule.Weekdays {
+		weekdays = append(weekdays, time.Weekday(weekday))
+	}
+	return &entity.MenuSchedule{Weekdays: weekdays, StartMinute: start, EndMinute: end}, nil
+}
+
+// EncodeMenuSchedule is the JSON stored in the schedule column, null for a product on the menu at every hour
+func EncodeMenuSchedule(schedule *entity.MenuSchedule) string {
+	raw, _ := json.Marshal(ToMenuScheduleDTO(schedule))
+	return string(raw)
+}
+
+func decodeMenuSchedule(raw string) *entity.MenuSchedule {
+	var schedule *MenuScheduleDTO
+	if raw == "" || json.Unmarshal([]byte(raw), &schedule) != nil {
+		return nil
+	}
+	// Schedules are validated before they are stored
+	parsed, _ := FromMenuScheduleDTO(schedule)
+	return parsed
+}
+
+// parseMinute reads a "15:04" time of day as minutes since midnight
+func parseMinute(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, &apperror.ValidationError{Msg: fmt.Sprintf("invalid time of day %q, expected HH:MM", value)}
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+func formatMinute(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+func ToSearchQuery(q ProductSearchQueryDTO) entity.SearchQuery {
+	return entity.SearchQuery{
+		Text:             q.Q,
+		Category:         enum.Category(q.Category),
+		MinPrice:         q.MinPrice,
+		MaxPrice:         q.MaxPrice,
+		MaxPreparingTime: q.MaxPreparingTime,
+		SortBy:           entity.SearchSort(q.Sort),
+		Order:            entity.SortOrder(q.Order),
+		Limit:            q.Limit,
+	}
+}
+
+func ToProductSearchQuery(query entity.SearchQuery, categories []enum.Category, availableOnly bool) ProductSearchQuery {
+	codes := make([]string, 0, len(categories))
+	for _, category := range categories {
+		codes = append(codes, string(category))
+	}
+
+	return ProductSearchQuery{
+		Text:             query.Text,
+		Categories:       codes,
+		MinPrice:         amountOf(query.MinPrice),
+		MaxPrice:         amountOf(query.MaxPrice),
+		MaxPreparingTime: query.MaxPreparingTime,
+		AvailableOnly:    availableOnly,
+		SortBy:           string(query.SortBy),
+		Descending:       query.Order == entity.SortDescending,
+		Limit:            query.Limit,
+	}
+}
+
+// amountOf compares prices in cents, all products of a store share its currency
+func amountOf(price *money.Money) *int64 {
+	if price == nil {
+		return nil
+	}
+	return &price.Amount
+}
+
+func ToImageUploadResponseDTO(upload entity.ImageUpload) ImageUploadResponseDTO {
+	return ImageUploadResponseDTO{
+		UploadURL: upload.UploadURL,
+		Method:    http.MethodPut,
+		Headers:   upload.Headers,
+		ImageURL:  upload.ImageURL,
+		ExpiresAt: upload.ExpiresAt,
+	}
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+)
+
+// MenuSchedule limits the hours a product is on the menu, such as breakfast until 11:00 or a
+// weekday special. Minutes are counted from midnight in the store timezone and a window ending
+// before it starts crosses midnight. No weekdays means every day.
+type MenuSchedule struct {
+	Weekdays    []time.Weekday
+	StartMinute int
+	EndMinute   int
+}
+
+const minutesPerDay = 24 * 60
+
+func (s MenuSchedule) Validate() error {
+	if s.StartMinute < 0 || s.StartMinute >= minutesPerDay || s.EndMinute < 0 || s.EndMinute >= minutesPerDay {
+		return &apperror.ValidationError{Msg: "Menu schedule times must be between 00:00 and 23:59"}
+	}
+	if s.StartMinute == s.EndMinute {
+		return &apperror.ValidationError{Msg: "Menu schedule must end at a different time than it starts"}
+	}
+	for _, weekday := range s.Weekdays {
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return &apperror.ValidationError{Msg: fmt.Sprintf("Invalid weekday %d", weekday)}
+		}
+	}
+	return nil
+}
+
+// Contains reports whether at falls in the window. at must already be in the store timezone.
+func (s MenuSchedule) Contains(at time.Time) bool {
+	if len(s.Weekdays) > 0 && !slices.Contains(s.Weekdays, at.Weekday()) {
+		return false
+	}
+
+	minute := at.Hour()*60 + at.Minute()
+	if s.StartMinute < s.EndMinute {
+		return minute >= s.StartMinute && minute < s.EndMinute
+	}
+	return minute >= s.StartMinute || minute < s.EndMinute
+}
+
+// IsAvailableAt reports whether customers can order the product at, which must already be in
+// the store timezone. Admins see and edit products regardless.
+func (p Product) IsAvailableAt(at time.Time) bool {
+	if !p.Available {
+		return false
+	}
+	return p.Schedule == nil || p.Schedule.Contains(at)
+}
+
+// AvailableAt keeps the products customers can order at
+func AvailableAt(products []Product, at time.Time) []Product {
+	available := make([]Product, 0, len(products))
+	for _, product := range products {
+		if product.IsAvailableAt(at) {
+			available = append(available, product)
+		}
+	}
+	return available
+}
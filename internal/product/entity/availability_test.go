@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Monday 2026-03-16
+func monday(hour, minute int) time.Time {
+	return time.Date(2026, time.March, 16, hour, minute, 0, 0, time.UTC)
+}
+
+func TestIsAvailableAt(t *testing.T) {
+	breakfast := Product{Name: "Pão na chapa", Available: true, Schedule: &MenuSchedule{StartMinute: 6 * 60, EndMinute: 11 * 60}}
+
+	t.Run("Given a breakfast item, when it is ordered in the morning and after 11:00, then it is only available in the morning", func(t *testing.T) {
+		assert.True(t, breakfast.IsAvailableAt(monday(8, 0)))
+		assert.False(t, breakfast.IsAvailableAt(monday(11, 0)))
+	})
+
+	t.Run("Given a weekday special, when it is ordered on a Monday and on a Sunday, then it is only available on the Monday", func(t *testing.T) {
+		special := Product{Available: true, Schedule: &MenuSchedule{
+			Weekdays:    []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+			StartMinute: 11 * 60,
+			EndMinute:   15 * 60,
+		}}
+
+		assert.True(t, special.IsAvailableAt(monday(12, 0)))
+		assert.False(t, special.IsAvailableAt(monday(12, 0).AddDate(0, 0, -1)))
+	})
+
+	t.Run("Given a product switched off, when it is ordered inside its schedule, then it is not available", func(t *testing.T) {
+		off := breakfast
+		off.Available = false
+
+		assert.False(t, off.IsAvailableAt(monday(8, 0)))
+	})
+
+	t.Run("Given products with and without a schedule, when they are filtered, then only those available are kept", func(t *testing.T) {
+		allDay := Product{Name: "Água", Available: true}
+
+		assert.Equal(t, []Product{allDay}, AvailableAt([]Product{breakfast, allDay}, monday(20, 0)))
+	})
+}
@@ -1,7 +1,7 @@
 package enum
 
-import "strings"
-
+// Category is the code of a category the product is filed under. Categories are managed by
+// admins; these are the ones the menu starts with.
 type Category string
 
 const (
@@ -10,17 +10,3 @@ const (
 	Drink   Category = "DRINK"
 	Dessert Category = "DESSERT"
 )
-
-func IsValidCategory(category string) bool {
-	category = strings.ToUpper(category)
-	switch Category(category) {
-	case Meal, Side, Drink, Dessert:
-		return true
-	default:
-		return false
-	}
-}
-
-func GetAllCategories() []Category {
-	return []Category{Meal, Side, Drink, Dessert}
-}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/google/uuid"
+)
+
+const (
+	MaxImageSize = 5 << 20
+	// ImageUploadExpiry is how long a presigned upload URL stays valid
+	ImageUploadExpiry = 15 * time.Minute
+)
+
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+// ImageUpload lets a client send an image straight to the storage: it PUTs the file to UploadURL
+// with Headers before ExpiresAt, then sets ImageURL as the image of a product.
+type ImageUpload struct {
+	UploadURL string
+	Headers   map[string]string
+	ImageURL  string
+	ExpiresAt time.Time
+}
+
+// NewImageKey names a new image in the storage, rejecting the types products cannot show
+func NewImageKey(contentType string) (string, error) {
+	extension, ok := imageExtensions[contentType]
+	if !ok {
+		return "", &apperror.ValidationError{Msg: "Only JPEG and PNG images are allowed"}
+	}
+	return fmt.Sprintf("products/%s%s", uuid.NewString(), extension), nil
+}
@@ -0,0 +1,145 @@
+package entity
+
+import (
+	"fmt"
+
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+	"github.com/google/uuid"
+)
+
+// ModifierGroup is a set of choices offered with a product, such as "Extras" or "Remove".
+// A customer picks between MinSelections and MaxSelections options of the group, a required
+// group asks for at least one. MaxSelections zero means any number of options.
+type ModifierGroup struct {
+	ID            string
+	Name          string
+	Required      bool
+	MinSelections int
+	MaxSelections int
+	Options       []ModifierOption
+}
+
+// ModifierOption is one choice of a group, PriceDelta is added to the unit price of the line
+type ModifierOption struct {
+	ID         string
+	Name       string
+	PriceDelta money.Money
+}
+
+// SelectedModifier is an option picked for an order line, along with the group it belongs to
+type SelectedModifier struct {
+	GroupID    string
+	GroupName  string
+	OptionID   string
+	Name       string
+	PriceDelta money.Money
+}
+
+func (g ModifierGroup) minimum() int {
+	if g.Required && g.MinSelections < 1 {
+		return 1
+	}
+	return g.MinSelections
+}
+
+func (g ModifierGroup) maximum() int {
+	if g.MaxSelections == 0 {
+		return len(g.Options)
+	}
+	return g.MaxSelections
+}
+
+// WithIDs gives an ID to the groups and options created without one
+func (g ModifierGroup) WithIDs() ModifierGroup {
+	if g.ID == "" {
+		g.ID = uuid.NewString()
+	}
+	options := make([]ModifierOption, 0, len(g.Options))
+	for _, option := range g.Options {
+		if option.ID == "" {
+			option.ID = uuid.NewString()
+		}
+		options = append(options, option)
+	}
+	g.Options = options
+	return g
+}
+
+func (g ModifierGroup) Validate() error {
+	if g.Name == "" {
+		return &apperror.ValidationError{Msg: "Modifier group name is required"}
+	}
+	if len(g.Options) == 0 {
+		return &apperror.ValidationError{Msg: fmt.Sprintf("Modifier group %s has no options", g.Name)}
+	}
+	if g.MinSelections < 0 || g.MaxSelections < 0 {
+		return &apperror.ValidationError{Msg: fmt.Sprintf("Modifier group %s cannot have negative selections", g.Name)}
+	}
+	if g.minimum() > g.maximum() || g.maximum() > len(g.Options) {
+		return &apperror.ValidationError{Msg: fmt.Sprintf("Modifier group %s asks for more selections than it has options", g.Name)}
+	}
+	for _, option := range g.Options {
+		if option.Name == "" {
+			return &apperror.ValidationError{Msg: fmt.Sprintf("Modifier group %s has an option without name", g.Name)}
+		}
+	}
+	return nil
+}
+
+// SelectModifiers resolves the option IDs chosen for an order line of the product. Every option
+// must belong to the product and be picked once, and every group must get between its minimum
+// and maximum selections.
+func (p Product) SelectModifiers(optionIDs []string) ([]SelectedModifier, error) {
+	wanted := make(map[string]bool, len(optionIDs))
+	for _, id := range optionIDs {
+		if wanted[id] {
+			return nil, &apperror.ValidationError{Msg: fmt.Sprintf("Modifier %s was selected more than once for %s", id, p.Name)}
+		}
+		wanted[id] = true
+	}
+
+	selected := make([]SelectedModifier, 0, len(optionIDs))
+	for _, group := range p.ModifierGroups {
+		count := 0
+		for _, option := range group.Options {
+			if !wanted[option.ID] {
+				continue
+			}
+			delete(wanted, option.ID)
+			count++
+			selected = append(selected, SelectedModifier{
+				GroupID:    group.ID,
+				GroupName:  group.Name,
+				OptionID:   option.ID,
+				Name:       option.Name,
+				PriceDelta: option.PriceDelta,
+			})
+		}
+
+		if count < group.minimum() {
+			return nil, &apperror.ValidationError{Msg: fmt.Sprintf("%s requires at least %d selection(s) in %s", p.Name, group.minimum(), group.Name)}
+		}
+		if count > group.maximum() {
+			return nil, &apperror.ValidationError{Msg: fmt.Sprintf("%s allows at most %d selection(s) in %s", p.Name, group.maximum(), group.Name)}
+		}
+	}
+
+	for id := range wanted {
+		return nil, &apperror.ValidationError{Msg: fmt.Sprintf("Modifier %s is not offered with %s", id, p.Name)}
+	}
+	return selected, nil
+}
+
+// UnitPriceWith is the price of one unit of the product with the given modifiers
+func (p Product) UnitPriceWith(modifiers []SelectedModifier) (money.Money, error) {
+	price := p.Price
+	for _, modifier := range modifiers {
+		var err error
+		price, err = price.Add(modifier.PriceDelta)
+		if err != nil {
+			return money.Money{}, err
+		}
+	}
+	return price, nil
+}
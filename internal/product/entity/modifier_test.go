@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"testing"
+
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func burgerWithModifiers() Product {
+	return Product{
+		Id:    "burger",
+		Name:  "X-Burger",
+		Price: money.FromCents(3000),
+		ModifierGroups: []ModifierGroup{
+			{
+				ID:       "bread",
+				Name:     "Bread",
+				Required: true,
+				Options: []ModifierOption{
+					{ID: "brioche", Name: "Brioche", PriceDelta: money.FromCents(200)},
+					{ID: "sesame", Name: "Sesame"},
+				},
+				MaxSelections: 1,
+			},
+			{
+				ID:   "extras",
+				Name: "Extras",
+				Options: []ModifierOption{
+					{ID: "cheese", Name: "Extra cheese", PriceDelta: money.FromCents(350)},
+					{ID: "no-onion", Name: "No onion"},
+				},
+			},
+		},
+	}
+}
+
+func TestSelectModifiers(t *testing.T) {
+	product := burgerWithModifiers()
+
+	t.Run("Given valid options, when they are selected, then their price deltas are added to the unit price", func(t *testing.T) {
+		selected, err := product.SelectModifiers([]string{"cheese", "brioche", "no-onion"})
+		assert.NoError(t, err)
+		assert.Len(t, selected, 3)
+		assert.Equal(t, "Bread", selected[0].GroupName)
+
+		price, err := product.UnitPriceWith(selected)
+		assert.NoError(t, err)
+		assert.Equal(t, money.FromCents(3550), price)
+	})
+
+	tests := []struct {
+		name      string
+		optionIDs []string
+	}{
+		{name: "Given no option of a required group, when they are selected, then it is rejected", optionIDs: []string{"cheese"}},
+		{name: "Given more options than a group allows, when they are selected, then it is rejected", optionIDs: []string{"brioche", "sesame"}},
+		{name: "Given an option the product does not offer, when they are selected, then it is rejected", optionIDs: []string{"sesame", "bacon"}},
+		{name: "Given the same option twice, when they are selected, then it is rejected", optionIDs: []string{"sesame", "cheese", "cheese"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := product.SelectModifiers(tt.optionIDs)
+
+			var validationErr *apperror.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+		})
+	}
+}
+
+func TestModifierGroupValidate(t *testing.T) {
+	t.Run("Given a well formed group, when it is validated, then it passes", func(t *testing.T) {
+		assert.NoError(t, burgerWithModifiers().ModifierGroups[0].Validate())
+	})
+
+	tests := []struct {
+		name  string
+		group ModifierGroup
+	}{
+		{name: "Given a group without options, when it is validated, then it is rejected", group: ModifierGroup{Name: "Extras"}},
+		{name: "Given a minimum above the maximum, when it is validated, then it is rejected", group: ModifierGroup{Name: "Extras", MinSelections: 2, MaxSelections: 1, Options: []ModifierOption{{Name: "Cheese"}, {Name: "Bacon"}}}},
+		{name: "Given a minimum above the number of options, when it is validated, then it is rejected", group: ModifierGroup{Name: "Extras", MinSelections: 2, Options: []ModifierOption{{Name: "Cheese"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErr *apperror.ValidationError
+			assert.ErrorAs(t, tt.group.Validate(), &validationErr)
+		})
+	}
+}
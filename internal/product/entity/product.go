@@ -1,29 +1,45 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
 	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
 )
 
 type Product struct {
-	Id            string
-	Name          string
-	Price         float64
-	Description   string
-	PreparingTime uint
-	Category      enum.Category
-	ImageURL      string
+	Id             string
+	Name           string
+	Price          money.Money
+	Description    string
+	PreparingTime  uint
+	Category       enum.Category
+	ImageURL       string
+	ModifierGroups []ModifierGroup
+	// Available is switched off when the product runs out or is taken off the menu for a while
+	Available bool
+	// Schedule limits the hours the product is on the menu, every hour when nil
+	Schedule *MenuSchedule
+	// ArchivedAt is set on deleted products, which leave the menu but still name past order lines
+	ArchivedAt *time.Time
+	Version    uint
 }
 
 func (p Product) Build() Product {
 	return Product{
-		Id:            p.Id,
-		Name:          p.Name,
-		Price:         p.Price,
-		Description:   p.Description,
-		PreparingTime: p.PreparingTime,
-		Category:      p.Category,
-		ImageURL:      p.ImageURL,
+		Id:             p.Id,
+		Name:           p.Name,
+		Price:          p.Price,
+		Description:    p.Description,
+		PreparingTime:  p.PreparingTime,
+		Category:       p.Category,
+		ImageURL:       p.ImageURL,
+		ModifierGroups: p.ModifierGroups,
+		Available:      p.Available,
+		Schedule:       p.Schedule,
+		ArchivedAt:     p.ArchivedAt,
+		Version:        p.Version,
 	}
 }
 
@@ -31,11 +47,19 @@ func (p Product) Validate() error {
 	if p.Name == "" {
 		return &apperror.ValidationError{Msg: "Name is required"}
 	}
-	if p.Price < 0 {
+	if !p.Price.IsPositive() {
 		return &apperror.ValidationError{Msg: "Price must be positive"}
 	}
 	if p.Category == "" {
 		return &apperror.ValidationError{Msg: "Category is required"}
 	}
+	for _, group := range p.ModifierGroups {
+		if err := group.Validate(); err != nil {
+			return err
+		}
+	}
+	if p.Schedule != nil {
+		return p.Schedule.Validate()
+	}
 	return nil
 }
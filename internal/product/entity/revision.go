@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+	"github.com/google/uuid"
+)
+
+// Revision is a version of a product as it was saved. Every change that bumps the version of a
+// product records one, so past prices can still be told after the product changed.
+type Revision struct {
+	ID        string
+	ProductID string
+	Version   uint
+	Name      string
+	Category  enum.Category
+	Price     money.Money
+	Available bool
+	CreatedAt time.Time
+}
+
+func NewRevision(product Product) Revision {
+	return Revision{
+		ID:        uuid.NewString(),
+		ProductID: product.Id,
+		Version:   product.Version,
+		Name:      product.Name,
+		Category:  product.Category,
+		Price:     product.Price,
+		Available: product.Available,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+)
+
+const (
+	DefaultSearchLimit  = 20
+	MaxSearchLimit      = 100
+	MaxSearchTextLength = 100
+)
+
+type SearchSort string
+
+const (
+	// SortByRelevance puts the best matches of the text first and ignores the sort order
+	SortByRelevance     SearchSort = "relevance"
+	SortByName          SearchSort = "name"
+	SortByPrice         SearchSort = "price"
+	SortByPreparingTime SearchSort = "preparing_time"
+)
+
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+// SearchQuery filters the catalog. Text matches the name and description of the products
+// regardless of accents and word endings, such as "pao de queijo" finding "Pães de queijo".
+type SearchQuery struct {
+	Text             string
+	Category         enum.Category
+	MinPrice         *money.Money
+	MaxPrice         *money.Money
+	MaxPreparingTime *uint
+	SortBy           SearchSort
+	Order            SortOrder
+	Limit            int
+}
+
+// Normalize fills the defaults of the query and rejects inconsistent filters. Searches for a
+// text are sorted by relevance by default, the others by name.
+func (q SearchQuery) Normalize() (SearchQuery, error) {
+	q.Text = strings.TrimSpace(q.Text)
+	q.Category = enum.Category(strings.ToUpper(strings.TrimSpace(string(q.Category))))
+	if q.SortBy == "" {
+		q.SortBy = SortByName
+		if q.Text != "" {
+			q.SortBy = SortByRelevance
+		}
+	}
+	if q.Order == "" {
+		q.Order = SortAscending
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultSearchLimit
+	}
+
+	switch q.SortBy {
+	case SortByName, SortByPrice, SortByPreparingTime:
+	case SortByRelevance:
+		if q.Text == "" {
+			return SearchQuery{}, &apperror.ValidationError{Msg: "sorting by relevance needs a search text"}
+		}
+	default:
+		return SearchQuery{}, &apperror.ValidationError{Msg: fmt.Sprintf("cannot sort products by %s", q.SortBy)}
+	}
+	if q.Order != SortAscending && q.Order != SortDescending {
+		return SearchQuery{}, &apperror.ValidationError{Msg: "sort order must be asc or desc"}
+	}
+	if q.Limit < 0 || q.Limit > MaxSearchLimit {
+		return SearchQuery{}, &apperror.ValidationError{Msg: fmt.Sprintf("limit must be between 1 and %d", MaxSearchLimit)}
+	}
+	if utf8.RuneCountInString(q.Text) > MaxSearchTextLength {
+		return SearchQuery{}, &apperror.ValidationError{Msg: fmt.Sprintf("search text must be at most %d characters", MaxSearchTextLength)}
+	}
+	if q.MinPrice != nil && q.MinPrice.IsNegative() {
+		return SearchQuery{}, &apperror.ValidationError{Msg: "min_price cannot be negative"}
+	}
+	if q.MinPrice != nil && q.MaxPrice != nil && q.MinPrice.Amount > q.MaxPrice.Amount {
+		return SearchQuery{}, &apperror.ValidationError{Msg: "min_price must not be greater than max_price"}
+	}
+
+	return q, nil
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchQueryNormalize(t *testing.T) {
+	t.Run("Given a search text, when the query is normalized, then the best matches come first", func(t *testing.T) {
+		query, err := SearchQuery{Text: "  pão de queijo ", Category: "drink"}.Normalize()
+
+		assert.NoError(t, err)
+		assert.Equal(t, SearchQuery{
+			Text:     "pão de queijo",
+			Category: enum.Drink,
+			SortBy:   SortByRelevance,
+			Order:    SortAscending,
+			Limit:    DefaultSearchLimit,
+		}, query)
+	})
+
+	t.Run("Given no search text, when the query is normalized, then the products are sorted by name", func(t *testing.T) {
+		query, err := SearchQuery{}.Normalize()
+
+		assert.NoError(t, err)
+		assert.Equal(t, SortByName, query.SortBy)
+	})
+
+	minPrice, maxPrice := money.FromCents(5000), money.FromCents(1000)
+
+	tests := []struct {
+		name  string
+		query SearchQuery
+	}{
+		{name: "Given an unknown sort column, when it is normalized, then it is rejected", query: SearchQuery{SortBy: "category"}},
+		{name: "Given a sort by relevance without text, when it is normalized, then it is rejected", query: SearchQuery{SortBy: SortByRelevance}},
+		{name: "Given an unknown sort order, when it is normalized, then it is rejected", query: SearchQuery{Order: "up"}},
+		{name: "Given a limit above the maximum, when it is normalized, then it is rejected", query: SearchQuery{Limit: MaxSearchLimit + 1}},
+		{name: "Given an inverted price range, when it is normalized, then it is rejected", query: SearchQuery{MinPrice: &minPrice, MaxPrice: &maxPrice}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.query.Normalize()
+
+			var validationErr *apperror.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+		})
+	}
+}
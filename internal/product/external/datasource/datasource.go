@@ -10,8 +10,18 @@ import (
 type DataSource interface {
 	Create(context.Context, dto.ProductDAO) (dto.ProductDAO, error)
 	GetAllByCategory(context.Context, string) ([]dto.ProductDAO, error)
+	Search(context.Context, dto.ProductSearchQuery) ([]dto.ProductDAO, error)
 	Update(context.Context, string, dto.ProductDAO) (dto.ProductDAO, error)
 	FindByID(context.Context, string) (dto.ProductDAO, error)
 	FindByIDs(context.Context, []string) ([]dto.ProductDAO, error)
-	Delete(context.Context, string) error
+	FindByIDsWithArchived(context.Context, []string) ([]dto.ProductDAO, error)
+	ListArchived(context.Context) ([]dto.ProductDAO, error)
+	CountByCategory(context.Context, string) (int64, error)
+	CountByImageURL(context.Context, string) (int64, error)
+	Restore(context.Context, string) (dto.ProductDAO, error)
+	SetAvailability(ctx context.Context, id string, available bool, schedule string) (dto.ProductDAO, error)
+	Delete(context.Context, string) (dto.ProductDAO, error)
+	Purge(context.Context, string) (dto.ProductDAO, error)
+	CreateRevision(ctx context.Context, revision dto.ProductRevisionDAO) error
+	ListRevisions(ctx context.Context, productID string) ([]dto.ProductRevisionDAO, error)
 }
@@ -2,10 +2,15 @@ package datasource
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/fiap-161/tc-golunch-core-service/database"
 	"github.com/fiap-161/tc-golunch-core-service/internal/product/dto"
 	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type DB interface {
@@ -16,6 +21,8 @@ type DB interface {
 	Delete(value any, conds ...any) *gorm.DB
 	Model(value any) *gorm.DB
 	Updates(values any) *gorm.DB
+	Unscoped() *gorm.DB
+	Order(value any) *gorm.DB
 }
 
 type GormDataSource struct {
@@ -28,8 +35,8 @@ func New(db DB) *GormDataSource {
 	}
 }
 
-func (r *GormDataSource) Create(_ context.Context, productDAO dto.ProductDAO) (dto.ProductDAO, error) {
-	tx := r.db.Create(&productDAO)
+func (r *GormDataSource) Create(ctx context.Context, productDAO dto.ProductDAO) (dto.ProductDAO, error) {
+	tx := database.Conn(ctx, r.db).Create(&productDAO)
 	if tx.Error != nil {
 		return dto.ProductDAO{}, tx.Error
 	}
@@ -37,9 +44,9 @@ func (r *GormDataSource) Create(_ context.Context, productDAO dto.ProductDAO) (d
 	return productDAO, nil
 }
 
-func (r *GormDataSource) GetAllByCategory(_ context.Context, category string) ([]dto.ProductDAO, error) {
+func (r *GormDataSource) GetAllByCategory(ctx context.Context, category string) ([]dto.ProductDAO, error) {
 	var products []dto.ProductDAO
-	query := r.db
+	query := database.Conn(ctx, r.db)
 	if category != "" {
 		query = query.Where("category = ?", category)
 	}
@@ -51,6 +58,58 @@ func (r *GormDataSource) GetAllByCategory(_ context.Context, category string) ([
 	return products, nil
 }
 
+// Search matches the text against the search vector of the products, see database.MigrateTextSearch.
+// websearch_to_tsquery accepts whatever customers type, quotes and a leading minus included.
+func (r *GormDataSource) Search(ctx context.Context, query dto.ProductSearchQuery) ([]dto.ProductDAO, error) {
+	search := database.Conn(ctx, r.db).Model(&dto.ProductDAO{})
+	tsquery := clause.Expr{SQL: "websearch_to_tsquery(?, ?)", Vars: []any{database.SearchConfiguration, query.Text}}
+
+	if query.Text != "" {
+		search = search.Where("? @@ ?", clause.Column{Name: database.SearchVectorColumn}, tsquery)
+	}
+	if len(query.Categories) > 0 {
+		search = search.Where("category IN ?", query.Categories)
+	}
+	if query.MinPrice != nil {
+		search = search.Where("price_amount >= ?", *query.MinPrice)
+	}
+	if query.MaxPrice != nil {
+		search = search.Where("price_amount <= ?", *query.MaxPrice)
+	}
+	if query.MaxPreparingTime != nil {
+		search = search.Where("preparing_time <= ?", *query.MaxPreparingTime)
+	}
+	if query.AvailableOnly {
+		search = search.Where("available = ?", true)
+	}
+
+	// Ties are broken by name, then ID so that equal rows keep their place between searches
+	direction := "ASC"
+	if query.Descending {
+		direction = "DESC"
+	}
+	switch query.SortBy {
+	case "relevance":
+		search = search.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank(?, ?) DESC, name, id",
+			Vars: []any{clause.Column{Name: database.SearchVectorColumn}, tsquery},
+		}})
+	case "price":
+		search = search.Order(fmt.Sprintf("price_amount %s, name, id", direction))
+	case "preparing_time":
+		search = search.Order(fmt.Sprintf("preparing_time %s, name, id", direction))
+	default:
+		search = search.Order(fmt.Sprintf("name %s, id", direction))
+	}
+
+	var products []dto.ProductDAO
+	if err := search.Limit(query.Limit).Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
 func (r *GormDataSource) Update(ctx context.Context, id string, updated dto.ProductDAO) (dto.ProductDAO, error) {
 	existing, err := r.FindByID(ctx, id)
 	if err != nil {
@@ -67,8 +126,9 @@ func (r *GormDataSource) Update(ctx context.Context, id string, updated dto.Prod
 	if updated.ImageURL != "" {
 		updates["image_url"] = updated.ImageURL
 	}
-	if updated.Price != 0 {
-		updates["price"] = updated.Price
+	if !updated.Price.IsZero() {
+		updates["price_amount"] = updated.Price.Amount
+		updates["price_currency"] = updated.Price.Currency
 	}
 	if updated.PreparingTime != 0 {
 		updates["preparing_time"] = updated.PreparingTime
@@ -76,26 +136,71 @@ func (r *GormDataSource) Update(ctx context.Context, id string, updated dto.Prod
 	if updated.Category != "" {
 		updates["category"] = updated.Category
 	}
+	if updated.ModifierGroups != "" {
+		updates["modifier_groups"] = updated.ModifierGroups
+	}
+
+	// Without an expected version the update applies to the version just read, which still
+	// catches a write landing between that read and this update
+	expectedVersion := existing.Version
+	if updated.Version != 0 {
+		expectedVersion = updated.Version
+	}
+	if expectedVersion != existing.Version {
+		return dto.ProductDAO{}, &apperror.ConflictError{Msg: "product was changed by another request, reload it and try again"}
+	}
 
 	if len(updates) == 0 {
 		return existing, nil
 	}
+	updates["version"] = expectedVersion + 1
 
-	if err := r.db.Model(&dto.ProductDAO{}).Where("id = @id", map[string]any{"id": id}).Updates(updates).Error; err != nil {
+	tx := database.Conn(ctx, r.db).Model(&dto.ProductDAO{}).
+		Where("id = @id AND version = @version", map[string]any{"id": id, "version": expectedVersion}).
+		Updates(updates)
+	if tx.Error != nil {
+		return dto.ProductDAO{}, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return dto.ProductDAO{}, &apperror.ConflictError{Msg: "product was changed by another request, reload it and try again"}
+	}
+
+	var updatedProduct dto.ProductDAO
+	if err := database.Conn(ctx, r.db).Where("id = @id", map[string]any{"id": id}).First(&updatedProduct).Error; err != nil {
 		return dto.ProductDAO{}, err
 	}
 
+	return updatedProduct, nil
+}
+
+// SetAvailability replaces the availability and menu schedule of the product as a new version
+func (r *GormDataSource) SetAvailability(ctx context.Context, id string, available bool, schedule string) (dto.ProductDAO, error) {
+	tx := database.Conn(ctx, r.db).Model(&dto.ProductDAO{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"available":  available,
+			"schedule":   schedule,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now(),
+		})
+	if tx.Error != nil {
+		return dto.ProductDAO{}, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return dto.ProductDAO{}, &apperror.NotFoundError{Msg: "Product not found"}
+	}
+
 	var updatedProduct dto.ProductDAO
-	if err := r.db.Where("id = @id", map[string]any{"id": id}).First(&updatedProduct).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Where("id = ?", id).First(&updatedProduct).Error; err != nil {
 		return dto.ProductDAO{}, err
 	}
 
 	return updatedProduct, nil
 }
 
-func (r *GormDataSource) FindByID(_ context.Context, id string) (dto.ProductDAO, error) {
+func (r *GormDataSource) FindByID(ctx context.Context, id string) (dto.ProductDAO, error) {
 	var product dto.ProductDAO
-	if err := r.db.First(&product, "id = ?", id).Error; err != nil {
+	if err := database.Conn(ctx, r.db).First(&product, "id = ?", id).Error; err != nil {
 		if err.Error() == "record not found" {
 			return dto.ProductDAO{}, &apperror.NotFoundError{Msg: "Product not found"}
 		}
@@ -105,10 +210,10 @@ func (r *GormDataSource) FindByID(_ context.Context, id string) (dto.ProductDAO,
 	return product, nil
 }
 
-func (r *GormDataSource) FindByIDs(_ context.Context, ids []string) ([]dto.ProductDAO, error) {
+func (r *GormDataSource) FindByIDs(ctx context.Context, ids []string) ([]dto.ProductDAO, error) {
 	var products []dto.ProductDAO
 
-	if err := r.db.Where("id IN ?", ids).Find(&products).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Where("id IN ?", ids).Find(&products).Error; err != nil {
 		return nil, err
 	}
 
@@ -119,19 +224,128 @@ func (r *GormDataSource) FindByIDs(_ context.Context, ids []string) ([]dto.Produ
 	return products, nil
 }
 
-func (r *GormDataSource) Delete(_ context.Context, id string) error {
-	var product dto.ProductDAO
+// FindByIDsWithArchived also finds archived products, for views of past orders
+func (r *GormDataSource) FindByIDsWithArchived(ctx context.Context, ids []string) ([]dto.ProductDAO, error) {
+	var products []dto.ProductDAO
 
-	if err := r.db.First(&product, "id = ?", id).Error; err != nil {
-		if err.Error() == "record not found" {
-			return &apperror.NotFoundError{Msg: "Product not found"}
+	if err := database.Conn(ctx, r.db).Unscoped().Where("id IN ?", ids).Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	if len(products) == 0 {
+		return nil, &apperror.NotFoundError{Msg: "No products found"}
+	}
+
+	return products, nil
+}
+
+func (r *GormDataSource) ListArchived(ctx context.Context) ([]dto.ProductDAO, error) {
+	var products []dto.ProductDAO
+
+	if err := database.Conn(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// CountByCategory counts the products with category, archived ones included since they keep
+// their category and can be restored
+func (r *GormDataSource) CountByCategory(ctx context.Context, category string) (int64, error) {
+	var count int64
+	err := database.Conn(ctx, r.db).Unscoped().Model(&dto.ProductDAO{}).Where("category = ?", category).Count(&count).Error
+	return count, err
+}
+
+// CountByImageURL counts the products showing the image, archived ones included since they
+// can be restored
+func (r *GormDataSource) CountByImageURL(ctx context.Context, imageURL string) (int64, error) {
+	var count int64
+	err := database.Conn(ctx, r.db).Unscoped().Model(&dto.ProductDAO{}).Where("image_url = ?", imageURL).Count(&count).Error
+	return count, err
+}
+
+// Restore puts an archived product back in the catalog
+func (r *GormDataSource) Restore(ctx context.Context, id string) (dto.ProductDAO, error) {
+	tx := database.Conn(ctx, r.db).Unscoped().Model(&dto.ProductDAO{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]any{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now(),
+		})
+	if tx.Error != nil {
+		return dto.ProductDAO{}, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return dto.ProductDAO{}, &apperror.NotFoundError{Msg: "Archived product not found"}
+	}
+
+	var restored dto.ProductDAO
+	if err := database.Conn(ctx, r.db).Where("id = ?", id).First(&restored).Error; err != nil {
+		return dto.ProductDAO{}, err
+	}
+
+	return restored, nil
+}
+
+// Delete archives the product as a new version. Its row stays so that past order lines keep
+// resolving it.
+func (r *GormDataSource) Delete(ctx context.Context, id string) (dto.ProductDAO, error) {
+	now := time.Now()
+	tx := database.Conn(ctx, r.db).Model(&dto.ProductDAO{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"deleted_at": now,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": now,
+		})
+	if tx.Error != nil {
+		return dto.ProductDAO{}, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return dto.ProductDAO{}, &apperror.NotFoundError{Msg: "Product not found"}
+	}
+
+	var archived dto.ProductDAO
+	if err := database.Conn(ctx, r.db).Unscoped().Where("id = ?", id).First(&archived).Error; err != nil {
+		return dto.ProductDAO{}, err
+	}
+
+	return archived, nil
+}
+
+// Purge removes an archived product and its history for good and returns it as it was. The
+// caller makes sure no order line shows it anymore.
+func (r *GormDataSource) Purge(ctx context.Context, id string) (dto.ProductDAO, error) {
+	var purged dto.ProductDAO
+	if err := database.Conn(ctx, r.db).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&purged).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.ProductDAO{}, &apperror.NotFoundError{Msg: "Archived product not found"}
 		}
-		return err
+		return dto.ProductDAO{}, err
 	}
 
-	if err := r.db.Delete(&product).Error; err != nil {
-		return err
+	if err := database.Conn(ctx, r.db).Where("product_id = ?", id).Delete(&dto.ProductRevisionDAO{}).Error; err != nil {
+		return dto.ProductDAO{}, err
+	}
+	if err := database.Conn(ctx, r.db).Unscoped().Where("id = ?", id).Delete(&dto.ProductDAO{}).Error; err != nil {
+		return dto.ProductDAO{}, err
+	}
+
+	return purged, nil
+}
+
+func (r *GormDataSource) CreateRevision(ctx context.Context, revision dto.ProductRevisionDAO) error {
+	return database.Conn(ctx, r.db).Create(&revision).Error
+}
+
+func (r *GormDataSource) ListRevisions(ctx context.Context, productID string) ([]dto.ProductRevisionDAO, error) {
+	var revisions []dto.ProductRevisionDAO
+
+	if err := database.Conn(ctx, r.db).Where("product_id = ?", productID).Order("version DESC").Find(&revisions).Error; err != nil {
+		return nil, err
 	}
 
-	return nil
+	return revisions, nil
 }
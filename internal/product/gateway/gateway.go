@@ -6,6 +6,7 @@ import (
 
 	"github.com/fiap-161/tc-golunch-core-service/internal/product/dto"
 	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
 	"github.com/fiap-161/tc-golunch-core-service/internal/product/external/datasource"
 	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
 )
@@ -52,6 +53,28 @@ func (g *Gateway) Update(c context.Context, productId string, product entity.Pro
 	updated, err := g.datasource.Update(c, productId, productDAO)
 
 	if err != nil {
+		var conflictErr *apperror.ConflictError
+		if errors.As(err, &conflictErr) {
+			return entity.Product{}, conflictErr
+		}
+		var notFoundErr *apperror.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return entity.Product{}, notFoundErr
+		}
+		return entity.Product{}, &apperror.InternalError{Msg: err.Error()}
+	}
+
+	return dto.FromProductDAO(updated), nil
+}
+
+func (g *Gateway) SetAvailability(c context.Context, productId string, available bool, schedule *entity.MenuSchedule) (entity.Product, error) {
+	updated, err := g.datasource.SetAvailability(c, productId, available, dto.EncodeMenuSchedule(schedule))
+
+	if err != nil {
+		var notFoundErr *apperror.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return entity.Product{}, notFoundErr
+		}
 		return entity.Product{}, &apperror.InternalError{Msg: err.Error()}
 	}
 
@@ -72,18 +95,32 @@ func (g *Gateway) FindByID(c context.Context, productId string) (entity.Product,
 	return dto.FromProductDAO(found), nil
 }
 
-func (g *Gateway) Delete(c context.Context, productId string) error {
-	err := g.datasource.Delete(c, productId)
+func (g *Gateway) Delete(c context.Context, productId string) (entity.Product, error) {
+	archived, err := g.datasource.Delete(c, productId)
 
 	if err != nil {
 		var notFoundErr *apperror.NotFoundError
 		if errors.As(err, &notFoundErr) {
-			return notFoundErr
+			return entity.Product{}, notFoundErr
 		}
-		return &apperror.InternalError{Msg: "Unexpected error"}
+		return entity.Product{}, &apperror.InternalError{Msg: "Unexpected error"}
 	}
 
-	return nil
+	return dto.FromProductDAO(archived), nil
+}
+
+func (g *Gateway) Purge(c context.Context, productId string) (entity.Product, error) {
+	purged, err := g.datasource.Purge(c, productId)
+
+	if err != nil {
+		var notFoundErr *apperror.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return entity.Product{}, notFoundErr
+		}
+		return entity.Product{}, &apperror.InternalError{Msg: err.Error()}
+	}
+
+	return dto.FromProductDAO(purged), nil
 }
 
 func (g *Gateway) FindByIDs(c context.Context, productIdList []string) ([]entity.Product, error) {
@@ -99,3 +136,86 @@ func (g *Gateway) FindByIDs(c context.Context, productIdList []string) ([]entity
 
 	return dto.EntityListFromDAOList(foundList), nil
 }
+
+func (g *Gateway) FindByIDsWithArchived(c context.Context, productIdList []string) ([]entity.Product, error) {
+	foundList, err := g.datasource.FindByIDsWithArchived(c, productIdList)
+
+	if err != nil {
+		var notFoundErr *apperror.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return []entity.Product{}, notFoundErr
+		}
+		return []entity.Product{}, &apperror.InternalError{Msg: "Unexpected error"}
+	}
+
+	return dto.EntityListFromDAOList(foundList), nil
+}
+
+func (g *Gateway) ListArchived(c context.Context) ([]entity.Product, error) {
+	foundList, err := g.datasource.ListArchived(c)
+
+	if err != nil {
+		return []entity.Product{}, &apperror.InternalError{Msg: err.Error()}
+	}
+
+	return dto.EntityListFromDAOList(foundList), nil
+}
+
+func (g *Gateway) Search(c context.Context, query entity.SearchQuery, categories []enum.Category, availableOnly bool) ([]entity.Product, error) {
+	foundList, err := g.datasource.Search(c, dto.ToProductSearchQuery(query, categories, availableOnly))
+
+	if err != nil {
+		return []entity.Product{}, &apperror.InternalError{Msg: err.Error()}
+	}
+
+	return dto.EntityListFromDAOList(foundList), nil
+}
+
+func (g *Gateway) CountByCategory(c context.Context, category enum.Category) (int64, error) {
+	count, err := g.datasource.CountByCategory(c, string(category))
+
+	if err != nil {
+		return 0, &apperror.InternalError{Msg: err.Error()}
+	}
+
+	return count, nil
+}
+
+func (g *Gateway) CountByImageURL(c context.Context, imageURL string) (int64, error) {
+	count, err := g.datasource.CountByImageURL(c, imageURL)
+
+	if err != nil {
+		return 0, &apperror.InternalError{Msg: err.Error()}
+	}
+
+	return count, nil
+}
+
+func (g *Gateway) Restore(c context.Context, productId string) (entity.Product, error) {
+	restored, err := g.datasource.Restore(c, productId)
+
+	if err != nil {
+		var notFoundErr *apperror.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return entity.Product{}, notFoundErr
+		}
+		return entity.Product{}, &apperror.InternalError{Msg: err.Error()}
+	}
+
+	return dto.FromProductDAO(restored), nil
+}
+
+func (g *Gateway) CreateRevision(c context.Context, revision entity.Revision) error {
+	if err := g.datasource.CreateRevision(c, dto.ToProductRevisionDAO(revision)); err != nil {
+		return &apperror.InternalError{Msg: err.Error()}
+	}
+	return nil
+}
+
+func (g *Gateway) ListRevisions(c context.Context, productId string) ([]entity.Revision, error) {
+	found, err := g.datasource.ListRevisions(c, productId)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.RevisionListFromDAOList(found), nil
+}
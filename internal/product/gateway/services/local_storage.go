@@ -0,0 +1,178 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+)
+
+// LocalStorage keeps the images in a directory of the replica, served under publicURL by
+// ServeHTTP. It only suits a single replica; use S3Storage when there are more.
+type LocalStorage struct {
+	dir        string
+	publicURL  string
+	signingKey []byte
+	now        func() time.Time
+}
+
+// NewLocalStorage stores images in dir, served from publicURL. signingKey signs the presigned
+// upload URLs; without one a random key is drawn, so the URLs do not outlive a restart.
+func NewLocalStorage(dir string, publicURL string, signingKey string) (*LocalStorage, error) {
+	key := []byte(signingKey)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return &LocalStorage{
+		dir:        dir,
+		publicURL:  strings.TrimSuffix(publicURL, "/"),
+		signingKey: key,
+		now:        time.Now,
+	}, nil
+}
+
+func (s *LocalStorage) Save(_ context.Context, key string, _ string, body io.Reader) (string, error) {
+	if err := s.write(key, body); err != nil {
+		return "", fmt.Errorf("error saving image: %w", err)
+	}
+	return s.publicURL + "/" + key, nil
+}
+
+// PresignUpload returns a URL of ServeHTTP that accepts a PUT of the image until it expires
+func (s *LocalStorage) PresignUpload(_ context.Context, key string, contentType string, expires time.Duration) (entity.ImageUpload, error) {
+	expiresAt := s.now().Add(expires)
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("signature", s.signature(key, contentType, expiresAt.Unix()))
+
+	return entity.ImageUpload{
+		UploadURL: s.publicURL + "/" + key + "?" + query.Encode(),
+		Headers:   map[string]string{"Content-Type": contentType},
+		ImageURL:  s.publicURL + "/" + key,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *LocalStorage) Delete(_ context.Context, url string) error {
+	key, ok := strings.CutPrefix(url, s.publicURL+"/")
+	if !ok || !filepath.IsLocal(key) {
+		return nil
+	}
+
+	if err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key))); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error deleting image: %w", err)
+	}
+	return nil
+}
+
+// ServeHTTP serves the stored images and takes the uploads of presigned URLs. It expects the
+// path without the prefix of publicURL.
+func (s *LocalStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		// Images are public one by one, the listing of the directory is not
+		if strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		http.FileServer(http.Dir(s.dir)).ServeHTTP(w, r)
+	case http.MethodPut:
+		s.upload(w, r)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *LocalStorage) upload(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	contentType := r.Header.Get("Content-Type")
+	expires, err := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+	if err != nil || !filepath.IsLocal(key) {
+		http.Error(w, "invalid upload URL", http.StatusBadRequest)
+		return
+	}
+	if s.now().Unix() > expires {
+		http.Error(w, "upload URL expired", http.StatusForbidden)
+		return
+	}
+	if !hmac.Equal([]byte(r.URL.Query().Get("signature")), []byte(s.signature(key, contentType, expires))) {
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+
+	// The type was signed, the content still has to match it
+	body := http.MaxBytesReader(w, r.Body, entity.MaxImageSize)
+	head := make([]byte, 512)
+	n, err := io.ReadFull(body, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		http.Error(w, "invalid image", http.StatusBadRequest)
+		return
+	}
+	if http.DetectContentType(head[:n]) != contentType {
+		http.Error(w, "image does not match its content type", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.write(key, io.MultiReader(bytes.NewReader(head[:n]), body)); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "image too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "error saving image", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// write stores the image through a temporary file, so a failed upload leaves nothing behind
+func (s *LocalStorage) write(key string, body io.Reader) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalStorage) signature(key string, contentType string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	fmt.Fprintf(mac, "PUT\n%s\n%s\n%d", key, contentType, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
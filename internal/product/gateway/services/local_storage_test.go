@@ -0,0 +1,81 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	dir := t.TempDir()
+	storage, err := NewLocalStorage(dir, "http://localhost:8081/uploads", "secret")
+	assert.NoError(t, err)
+
+	var pngImage bytes.Buffer
+	assert.NoError(t, png.Encode(&pngImage, image.NewRGBA(image.Rect(0, 0, 1, 1))))
+
+	put := func(upload string, contentType string, body []byte) *httptest.ResponseRecorder {
+		target, _ := url.Parse(upload)
+		req := httptest.NewRequest(http.MethodPut, strings.TrimPrefix(target.Path, "/uploads")+"?"+target.RawQuery, bytes.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		recorder := httptest.NewRecorder()
+		storage.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Given a presigned upload, when the image is sent, then it is served from its URL", func(t *testing.T) {
+		upload, err := storage.PresignUpload(context.Background(), "products/burger.png", "image/png", time.Minute)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, put(upload.UploadURL, "image/png", pngImage.Bytes()).Code)
+
+		recorder := httptest.NewRecorder()
+		storage.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/products/burger.png", nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "http://localhost:8081/uploads/products/burger.png", upload.ImageURL)
+	})
+
+	t.Run("Given a presigned upload, when another content type is sent, then it is refused", func(t *testing.T) {
+		upload, _ := storage.PresignUpload(context.Background(), "products/fries.png", "image/png", time.Minute)
+
+		assert.Equal(t, http.StatusForbidden, put(upload.UploadURL, "image/jpeg", pngImage.Bytes()).Code)
+	})
+
+	t.Run("Given an expired upload, when the image is sent, then it is refused", func(t *testing.T) {
+		upload, _ := storage.PresignUpload(context.Background(), "products/soda.png", "image/png", -time.Minute)
+
+		assert.Equal(t, http.StatusForbidden, put(upload.UploadURL, "image/png", pngImage.Bytes()).Code)
+	})
+
+	t.Run("Given a stored image, when its URL is deleted, then the file is removed", func(t *testing.T) {
+		imageURL, err := storage.Save(context.Background(), "products/pie.png", "image/png", bytes.NewReader(pngImage.Bytes()))
+		assert.NoError(t, err)
+
+		assert.NoError(t, storage.Delete(context.Background(), imageURL))
+
+		_, statErr := os.Stat(filepath.Join(dir, "products", "pie.png"))
+		assert.True(t, os.IsNotExist(statErr))
+	})
+
+	t.Run("Given a URL escaping the upload directory, when it is deleted, then nothing is removed", func(t *testing.T) {
+		outside := filepath.Join(filepath.Dir(dir), "keep.txt")
+		assert.NoError(t, os.WriteFile(outside, []byte("keep"), 0644))
+		defer os.Remove(outside)
+
+		assert.NoError(t, storage.Delete(context.Background(), "http://localhost:8081/uploads/../keep.txt"))
+
+		_, statErr := os.Stat(outside)
+		assert.NoError(t, statErr)
+	})
+}
@@ -21,3 +21,11 @@ func NewProductServiceGateway(productUseCase *usecases.UseCases) interfaces.Prod
 func (a *ProductServiceGateway) FindByIDs(ctx context.Context, productIDs []string) ([]entity.Product, error) {
 	return a.productUseCase.FindByIDs(ctx, productIDs)
 }
+
+func (a *ProductServiceGateway) FindByIDsWithArchived(ctx context.Context, productIDs []string) ([]entity.Product, error) {
+	return a.productUseCase.FindByIDsWithArchived(ctx, productIDs)
+}
+
+func (a *ProductServiceGateway) InActiveCategories(ctx context.Context, products []entity.Product) ([]entity.Product, error) {
+	return a.productUseCase.InActiveCategories(ctx, products)
+}
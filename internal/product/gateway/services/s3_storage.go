@@ -0,0 +1,135 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+)
+
+// S3Config points S3Storage at a bucket. Endpoint is set for S3 compatible stores such as MinIO,
+// which are addressed as Endpoint/Bucket; without it the bucket is reached on AWS. PublicURL is
+// where the images are served from, the bucket itself by default, such as a CDN in front of it.
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	PublicURL       string
+}
+
+// S3Storage keeps the images in an S3 bucket shared by every replica
+type S3Storage struct {
+	client    *http.Client
+	signer    signer
+	bucketURL string
+	publicURL string
+	now       func() time.Time
+}
+
+func NewS3Storage(config S3Config) (*S3Storage, error) {
+	if config.Bucket == "" || config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, fmt.Errorf("the S3 storage needs a bucket and credentials")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+
+	bucketURL := fmt.Sprintf("https://%s.s3.%s.amazonaws.com", config.Bucket, config.Region)
+	if config.Endpoint != "" {
+		bucketURL = strings.TrimSuffix(config.Endpoint, "/") + "/" + config.Bucket
+	}
+	publicURL := bucketURL
+	if config.PublicURL != "" {
+		publicURL = strings.TrimSuffix(config.PublicURL, "/")
+	}
+
+	return &S3Storage{
+		client:    &http.Client{Timeout: 30 * time.Second},
+		signer:    signer{accessKeyID: config.AccessKeyID, secretAccessKey: config.SecretAccessKey, region: config.Region},
+		bucketURL: bucketURL,
+		publicURL: publicURL,
+		now:       time.Now,
+	}, nil
+}
+
+func (s *S3Storage) Save(ctx context.Context, key string, contentType string, body io.Reader) (string, error) {
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return "", fmt.Errorf("error reading image: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), bytes.NewReader(content))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+	s.signer.sign(req, hashHex(content), s.now())
+
+	if err := s.do(req, http.StatusOK); err != nil {
+		return "", fmt.Errorf("error saving image: %w", err)
+	}
+	return s.publicURL + "/" + key, nil
+}
+
+func (s *S3Storage) PresignUpload(_ context.Context, key string, contentType string, expires time.Duration) (entity.ImageUpload, error) {
+	now := s.now()
+	headers := map[string]string{"Content-Type": contentType}
+
+	uploadURL, err := s.signer.presign(http.MethodPut, s.objectURL(key), headers, expires, now)
+	if err != nil {
+		return entity.ImageUpload{}, err
+	}
+
+	return entity.ImageUpload{
+		UploadURL: uploadURL,
+		Headers:   headers,
+		ImageURL:  s.publicURL + "/" + key,
+		ExpiresAt: now.Add(expires),
+	}, nil
+}
+
+// Delete removes the object behind url. S3 answers a delete of a missing object with success.
+func (s *S3Storage) Delete(ctx context.Context, url string) error {
+	key, ok := strings.CutPrefix(url, s.publicURL+"/")
+	if !ok || key == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+	s.signer.sign(req, hashHex(nil), s.now())
+
+	if err := s.do(req, http.StatusNoContent, http.StatusOK); err != nil {
+		return fmt.Errorf("error deleting image: %w", err)
+	}
+	return nil
+}
+
+func (s *S3Storage) objectURL(key string) string {
+	return s.bucketURL + "/" + key
+}
+
+func (s *S3Storage) do(req *http.Request, expected ...int) error {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	for _, status := range expected {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("storage returned %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/product/interfaces"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared"
+)
+
+const (
+	StorageLocal = "local"
+	StorageS3    = "s3"
+)
+
+// NewImageStorage builds the image storage chosen by IMAGE_STORAGE: local (default) keeps the
+// images in UPLOAD_DIR, s3 in an S3 compatible bucket configured by the S3_* variables
+func NewImageStorage() (interfaces.ImageStorage, error) {
+	switch backend := shared.StringFromEnv("IMAGE_STORAGE", StorageLocal); backend {
+	case StorageLocal:
+		storage, err := NewLocalStorage(
+			shared.StringFromEnv("UPLOAD_DIR", "./uploads"),
+			shared.StringFromEnv("PUBLIC_URL", "")+"/uploads",
+			shared.StringFromEnv("UPLOAD_SIGNING_KEY", ""),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return storage, nil
+	case StorageS3:
+		storage, err := NewS3Storage(S3Config{
+			Endpoint:        shared.StringFromEnv("S3_ENDPOINT", ""),
+			Region:          shared.StringFromEnv("S3_REGION", "us-east-1"),
+			Bucket:          shared.StringFromEnv("S3_BUCKET", ""),
+			AccessKeyID:     shared.StringFromEnv("S3_ACCESS_KEY_ID", ""),
+			SecretAccessKey: shared.StringFromEnv("S3_SECRET_ACCESS_KEY", ""),
+			PublicURL:       shared.StringFromEnv("S3_PUBLIC_URL", ""),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return storage, nil
+	default:
+		return nil, fmt.Errorf("unknown image storage %q, use %s or %s", backend, StorageLocal, StorageS3)
+	}
+}
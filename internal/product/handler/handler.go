@@ -22,7 +22,7 @@ func New(controller *controller.Controller) *Handler {
 
 // ListCategories List Categories godoc
 // @Summary      List Categories
-// @Description  List Categories
+// @Description  List the codes of the active categories in display order, see /category for their names and icons
 // @Tags         Product Domain
 // @Security BearerAuth
 // @Accept       json
@@ -32,7 +32,13 @@ func New(controller *controller.Controller) *Handler {
 // @Router       /product/categories [get]
 func (h *Handler) ListCategories(c *gin.Context) {
 	ctx := context.Background()
-	c.JSON(http.StatusOK, h.controller.ListCategories(ctx))
+	categories, err := h.controller.ListCategories(ctx)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
 }
 
 // UploadImage godoc
@@ -67,6 +73,37 @@ func (h *Handler) UploadImage(c *gin.Context) {
 	c.JSON(http.StatusCreated, dto.ImageURLDTO{ImageURL: imageURL})
 }
 
+// PresignImageUpload godoc
+// @Summary      Presign product image upload
+// @Description  Returns a URL the client PUTs a JPEG or PNG image to with the returned headers, straight to the image storage, and the URL to use as image_url of the product. The upload URL expires after 15 minutes.
+// @Tags         Product Domain
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ImageUploadRequestDTO  true  "Type of the image"
+// @Success      201  {object}  dto.ImageUploadResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/product/upload/presigned [post]
+func (h *Handler) PresignImageUpload(c *gin.Context) {
+	var request dto.ImageUploadRequestDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "Invalid request body",
+			MessageError: err.Error(),
+		})
+		return
+	}
+
+	upload, err := h.controller.PresignImageUpload(context.Background(), request)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, upload)
+}
+
 // Create Product godoc
 // @Summary      Create Product
 // @Description  Create a new product
@@ -101,7 +138,7 @@ func (h *Handler) Create(c *gin.Context) {
 
 // GetAll Get All Products by Category godoc
 // @Summary      Get all products by category
-// @Description  Returns all products. Optionally, filter by category using query param. Categories must match those returned from [GET] /product/categories.
+// @Description  Returns the products customers can order right now: those switched off or outside their menu schedule are left out. Optionally, filter by category using query param. Categories must match those returned from [GET] /product/categories.
 // @Tags         Product Domain
 // @Security BearerAuth
 // @Accept       json
@@ -112,11 +149,31 @@ func (h *Handler) Create(c *gin.Context) {
 // @Failure      401  {object}  errors.ErrorDTO
 // @Router       /product [get]
 func (h *Handler) GetAllByCategory(c *gin.Context) {
+	h.list(c, true)
+}
+
+// AdminGetAll Get All Products by Category godoc
+// @Summary      Get all products by category, available or not
+// @Description  Returns every product, including those switched off or outside their menu schedule. Optionally, filter by category using query param.
+// @Tags         Product Domain
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        category query string false "Category name (e.g., 'drink', 'meal', 'side', 'dessert')"
+// @Success      200  {object}  dto.ProductListResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/product [get]
+func (h *Handler) AdminGetAllByCategory(c *gin.Context) {
+	h.list(c, false)
+}
+
+func (h *Handler) list(c *gin.Context, availableOnly bool) {
 	query := c.Query("category")
 	query = strings.ToUpper(query)
 	query = strings.ReplaceAll(query, " ", "")
 
-	list, err := h.controller.GetAllByCategory(context.Background(), query)
+	list, err := h.controller.GetAllByCategory(context.Background(), query, availableOnly)
 	if err != nil {
 		helper.HandleError(c, err)
 		return
@@ -125,6 +182,76 @@ func (h *Handler) GetAllByCategory(c *gin.Context) {
 	c.JSON(http.StatusOK, list)
 }
 
+// Search Search Products godoc
+// @Summary      Search products
+// @Description  Search the products customers can order right now by name and description. The text ignores accents and word endings, so "pao de queijo" finds "Pães de Queijo".
+// @Tags         Product Domain
+// @Security BearerAuth
+// @Produce      json
+// @Param        q                   query  string  false  "Search text"
+// @Param        category            query  string  false  "Category code"
+// @Param        min_price           query  string  false  "Minimum price, as a decimal such as 10.50"
+// @Param        max_price           query  string  false  "Maximum price, as a decimal such as 10.50"
+// @Param        max_preparing_time  query  int     false  "Maximum preparing time in minutes"
+// @Param        sort                query  string  false  "Sort: relevance (default with q), name (default without q), price or preparing_time"
+// @Param        order               query  string  false  "Sort order: asc (default) or desc, ignored by relevance"
+// @Param        limit               query  int     false  "Maximum number of products, 20 by default and 100 at most"
+// @Success      200  {object}  dto.ProductListResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /product/search [get]
+func (h *Handler) Search(c *gin.Context) {
+	var query dto.ProductSearchQueryDTO
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "invalid query parameters",
+			MessageError: err.Error(),
+		})
+		return
+	}
+
+	list, err := h.controller.Search(context.Background(), query)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// SetAvailability Set Product Availability godoc
+// @Summary      Set Product Availability
+// @Description  Switch a product on or off the menu and replace its menu schedule, such as breakfast until 11:00 or a weekday special. Without a schedule the product is on the menu at every hour. Times are in the store timezone.
+// @Tags         Product Domain
+// @Security BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                             true  "Product ID"
+// @Param        request  body      dto.ProductAvailabilityRequestDTO  true  "Availability"
+// @Success      200  {object}  dto.ProductResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/product/{id}/availability [put]
+func (h *Handler) SetAvailability(c *gin.Context) {
+	var request dto.ProductAvailabilityRequestDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "Invalid request body",
+			MessageError: err.Error(),
+		})
+		return
+	}
+
+	updated, err := h.controller.SetAvailability(context.Background(), c.Param("id"), request)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.Header("ETag", helper.ETag(updated.Version))
+	c.JSON(http.StatusOK, updated)
+}
+
 // Update Product godoc
 // @Summary      Update Product
 // @Description  Update an existing product by ID
@@ -133,10 +260,12 @@ func (h *Handler) GetAllByCategory(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id       path      string                         true  "Product ID"
+// @Param        If-Match header    string                         false "ETag or version of the product as last read; the update is rejected with 409 if it changed since"
 // @Param        request  body      dto.ProductRequestUpdateDTO true  "Product data to update"
 // @Success      200      {object}  dto.ProductResponseDTO
 // @Failure      400      {object}  errors.ErrorDTO
 // @Failure      401  {object}  errors.ErrorDTO
+// @Failure      409  {object}  errors.ErrorDTO
 // @Router       /product/{id} [put]
 func (h *Handler) Update(c *gin.Context) {
 	id := c.Param("id")
@@ -151,18 +280,25 @@ func (h *Handler) Update(c *gin.Context) {
 		return
 	}
 
-	updated, err := h.controller.Update(context.Background(), id, productUpdateDTO)
+	expectedVersion, err := helper.IfMatchVersion(c)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	updated, err := h.controller.Update(context.Background(), id, productUpdateDTO, expectedVersion)
 	if err != nil {
 		helper.HandleError(c, err)
 		return
 	}
 
+	c.Header("ETag", helper.ETag(updated.Version))
 	c.JSON(http.StatusOK, updated)
 }
 
 // Delete Product godoc
 // @Summary      Delete Product
-// @Description  Delete a product by ID
+// @Description  Archive a product by ID. It leaves the menu and can no longer be ordered, but past orders keep showing it; see [GET] /admin/product/archived, [POST] /admin/product/{id}/restore and [POST] /admin/product/{id}/purge
 // @Tags         Product Domain
 // @Security BearerAuth
 // @Accept       json
@@ -185,6 +321,89 @@ func (h *Handler) Delete(c *gin.Context) {
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// ListArchived List Archived Products godoc
+// @Summary      List Archived Products
+// @Description  List the products that were deleted, most recently archived first
+// @Tags         Product Domain
+// @Security BearerAuth
+// @Produce      json
+// @Success      200  {object}  dto.ProductListResponseDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/product/archived [get]
+func (h *Handler) ListArchived(c *gin.Context) {
+	list, err := h.controller.ListArchived(context.Background())
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// Restore Restore Archived Product godoc
+// @Summary      Restore Archived Product
+// @Description  Put an archived product back on the menu as it was when it was deleted. Fails with 400 while its category does not exist.
+// @Tags         Product Domain
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "Product ID"
+// @Success      200  {object}  dto.ProductResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/product/{id}/restore [post]
+func (h *Handler) Restore(c *gin.Context) {
+	restored, err := h.controller.Restore(context.Background(), c.Param("id"))
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.Header("ETag", helper.ETag(restored.Version))
+	c.JSON(http.StatusOK, restored)
+}
+
+// Purge Purge Archived Product godoc
+// @Summary      Purge Archived Product
+// @Description  Remove an archived product for good, with its history, and delete its image once no other product uses it. Fails with 409 while past orders still show the product.
+// @Tags         Product Domain
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "Product ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Failure      409  {object}  errors.ErrorDTO
+// @Router       /admin/product/{id}/purge [post]
+func (h *Handler) Purge(c *gin.Context) {
+	if err := h.controller.Purge(context.Background(), c.Param("id")); err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// History Product Price History godoc
+// @Summary      Product Price History
+// @Description  List the versions of a product, newest first, with the previous price on those that changed it. Archived products keep their history.
+// @Tags         Product Domain
+// @Security BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "Product ID"
+// @Success      200  {object}  dto.ProductHistoryResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/product/{id}/history [get]
+func (h *Handler) History(c *gin.Context) {
+	history, err := h.controller.History(context.Background(), c.Param("id"))
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
 func (h *Handler) ValidateIfProductExists(c *gin.Context) {
 	id := c.Param("id")
 
@@ -0,0 +1,34 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+	"time"
+
+	categoryentity "github.com/fiap-161/tc-golunch-core-service/internal/category/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+)
+
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type CategoryService interface {
+	Check(ctx context.Context, codes []enum.Category) error
+	List(ctx context.Context, activeOnly bool) ([]categoryentity.Category, error)
+}
+
+// ProductOrderService counts the order lines showing a product, which keep it from being purged
+type ProductOrderService interface {
+	CountByProduct(ctx context.Context, productID string) (int64, error)
+}
+
+// ImageStorage keeps the images of the products under keys such as those of entity.NewImageKey
+// and tells the public URL they are served from
+type ImageStorage interface {
+	Save(ctx context.Context, key string, contentType string, body io.Reader) (string, error)
+	PresignUpload(ctx context.Context, key string, contentType string, expires time.Duration) (entity.ImageUpload, error)
+	// Delete removes the image served at url. URLs of images kept elsewhere are left alone.
+	Delete(ctx context.Context, url string) error
+}
@@ -14,13 +14,18 @@ func Build() *Presenter {
 
 func (p *Presenter) FromEntityToResponseDTO(product entity.Product) dto.ProductResponseDTO {
 	return dto.ProductResponseDTO{
-		ID:            product.Id,
-		Name:          product.Name,
-		Price:         product.Price,
-		Description:   product.Description,
-		PreparingTime: product.PreparingTime,
-		Category:      product.Category,
-		ImageURL:      product.ImageURL,
+		ID:             product.Id,
+		Name:           product.Name,
+		Price:          product.Price,
+		Description:    product.Description,
+		PreparingTime:  product.PreparingTime,
+		Category:       product.Category,
+		ImageURL:       product.ImageURL,
+		ModifierGroups: dto.ToModifierGroupDTOList(product.ModifierGroups),
+		Available:      product.Available,
+		Schedule:       dto.ToMenuScheduleDTO(product.Schedule),
+		ArchivedAt:     product.ArchivedAt,
+		Version:        product.Version,
 	}
 }
 
@@ -36,3 +41,28 @@ func (p *Presenter) FromEntityListToProductListResponseDTO(products []entity.Pro
 		List:  productsDTO,
 	}
 }
+
+// FromRevisionsToHistoryDTO expects the revisions newest first
+func (p *Presenter) FromRevisionsToHistoryDTO(productId string, revisions []entity.Revision) dto.ProductHistoryResponseDTO {
+	history := make([]dto.ProductRevisionDTO, 0, len(revisions))
+	for i, revision := range revisions {
+		item := dto.ProductRevisionDTO{
+			Version:   revision.Version,
+			Name:      revision.Name,
+			Category:  revision.Category,
+			Price:     revision.Price,
+			Available: revision.Available,
+			ChangedAt: revision.CreatedAt,
+		}
+		if i+1 < len(revisions) && revisions[i+1].Price != revision.Price {
+			previous := revisions[i+1].Price
+			item.PreviousPrice = &previous
+		}
+		history = append(history, item)
+	}
+
+	return dto.ProductHistoryResponseDTO{
+		ProductID: productId,
+		Revisions: history,
+	}
+}
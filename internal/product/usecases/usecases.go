@@ -2,55 +2,81 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"io"
+	"log"
 	"mime/multipart"
 	"net/http"
-	"os"
-	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
 	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
 	"github.com/fiap-161/tc-golunch-core-service/internal/product/gateway"
+	"github.com/fiap-161/tc-golunch-core-service/internal/product/interfaces"
 	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
 	"github.com/google/uuid"
 )
 
 type UseCases struct {
-	productGateway gateway.Gateway
+	productGateway      gateway.Gateway
+	location            *time.Location
+	unitOfWork          interfaces.UnitOfWork
+	categoryService     interfaces.CategoryService
+	productOrderService interfaces.ProductOrderService
+	imageStorage        interfaces.ImageStorage
 }
 
-func Build(productGateway gateway.Gateway) *UseCases {
-	return &UseCases{productGateway: productGateway}
+// Build wires the product use cases. location is the store timezone menu schedules are read in;
+// unitOfWork commits every change of a product together with its revision, categoryService
+// holds the categories products may be filed under, productOrderService the order lines that
+// keep archived products from being purged and imageStorage their images.
+func Build(productGateway gateway.Gateway, location *time.Location, unitOfWork interfaces.UnitOfWork, categoryService interfaces.CategoryService, productOrderService interfaces.ProductOrderService, imageStorage interfaces.ImageStorage) *UseCases {
+	return &UseCases{productGateway: productGateway, location: location, unitOfWork: unitOfWork, categoryService: categoryService, productOrderService: productOrderService, imageStorage: imageStorage}
 }
 
 func (u *UseCases) CreateProduct(ctx context.Context, product entity.Product) (entity.Product, error) {
-	isValidCategory := enum.IsValidCategory(string(product.Category))
-	if !isValidCategory {
-		return entity.Product{}, &apperror.ValidationError{Msg: "Invalid category"}
+	if err := u.categoryService.Check(ctx, []enum.Category{product.Category}); err != nil {
+		return entity.Product{}, err
 	}
 
+	product.ModifierGroups = withModifierIDs(product.ModifierGroups)
 	if err := product.Validate(); err != nil {
 		return entity.Product{}, err
 	}
 
-	saved, createErr := u.productGateway.Create(ctx, product)
-	if createErr != nil {
-		return entity.Product{}, &apperror.InternalError{Msg: createErr.Error()}
+	var saved entity.Product
+	txErr := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var createErr error
+		saved, createErr = u.productGateway.Create(ctx, product)
+		if createErr != nil {
+			return createErr
+		}
+		return u.productGateway.CreateRevision(ctx, entity.NewRevision(saved))
+	})
+	if txErr != nil {
+		return entity.Product{}, &apperror.InternalError{Msg: txErr.Error()}
 	}
 
 	return saved, nil
 }
 
-func (u *UseCases) ListCategories(ctx context.Context) []enum.Category {
-	return enum.GetAllCategories()
+// ListCategories returns the codes of the active categories in display order
+func (u *UseCases) ListCategories(ctx context.Context) ([]enum.Category, error) {
+	categories, err := u.categoryService.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]enum.Category, 0, len(categories))
+	for _, category := range categories {
+		codes = append(codes, category.Code)
+	}
+	return codes, nil
 }
 
 func (u *UseCases) UploadImage(ctx context.Context, fileHeader *multipart.FileHeader) (string, error) {
-	uploadDir := os.Getenv("UPLOAD_DIR")
-	publicURL := os.Getenv("PUBLIC_URL")
-
 	file, err := fileHeader.Open()
 	if err != nil {
 		return "", fmt.Errorf("error opening file: %w", err)
@@ -63,13 +89,9 @@ func (u *UseCases) UploadImage(ctx context.Context, fileHeader *multipart.FileHe
 	}
 	contentType := http.DetectContentType(buffer)
 
-	allowedTypes := map[string]bool{
-		"image/jpeg": true,
-		"image/png":  true,
-	}
-
-	if !allowedTypes[contentType] {
-		return "", &apperror.ValidationError{Msg: "Only JPEG and PNG images are allowed"}
+	key, err := entity.NewImageKey(contentType)
+	if err != nil {
+		return "", err
 	}
 
 	_, err = file.Seek(0, 0)
@@ -77,70 +99,177 @@ func (u *UseCases) UploadImage(ctx context.Context, fileHeader *multipart.FileHe
 		return "", fmt.Errorf("error resetting file: %w", err)
 	}
 
-	if _, err := os.Stat(uploadDir); os.IsNotExist(err) {
-		if err := os.MkdirAll(uploadDir, 0755); err != nil {
-			return "", fmt.Errorf("error creating upload dir: %w", err)
-		}
+	return u.imageStorage.Save(ctx, key, contentType, file)
+}
+
+// PresignImageUpload lets the client send an image of contentType straight to the storage, see
+// entity.ImageUpload
+func (u *UseCases) PresignImageUpload(ctx context.Context, contentType string) (entity.ImageUpload, error) {
+	key, err := entity.NewImageKey(contentType)
+	if err != nil {
+		return entity.ImageUpload{}, err
 	}
 
-	filename := fmt.Sprintf("%d_%s", time.Now().UnixNano(), filepath.Base(fileHeader.Filename))
-	fullPath := filepath.Join(uploadDir, filename)
+	return u.imageStorage.PresignUpload(ctx, key, contentType, entity.ImageUploadExpiry)
+}
 
-	if err := saveFile(fileHeader, fullPath); err != nil {
-		return "", fmt.Errorf("error saving file: %w", err)
+// deleteOrphanImage removes an image that was replaced, or whose product was purged, once no
+// product, archived ones included, shows it anymore. The product change is already committed,
+// so a failure is only logged.
+func (u *UseCases) deleteOrphanImage(ctx context.Context, imageURL string) {
+	count, err := u.productGateway.CountByImageURL(ctx, imageURL)
+	if err != nil || count > 0 {
+		return
 	}
 
-	return fmt.Sprintf("%s/uploads/%s", publicURL, filename), nil
+	if err := u.imageStorage.Delete(ctx, imageURL); err != nil {
+		log.Printf("Could not delete the orphaned image %s: %v", imageURL, err)
+	}
 }
 
-func saveFile(fileHeader *multipart.FileHeader, dest string) error {
-	src, err := fileHeader.Open()
-	if err != nil {
-		return err
+// GetAllByCategory lists the products of category, all of them when it is empty. With
+// availableOnly it lists only what customers can order right now, see entity.Product.IsAvailableAt,
+// leaving out the products of inactive categories.
+func (u *UseCases) GetAllByCategory(ctx context.Context, category string, availableOnly bool) ([]entity.Product, error) {
+	category = strings.ToUpper(category)
+	if category != "" {
+		if err := u.categoryService.Check(ctx, []enum.Category{enum.Category(category)}); err != nil {
+			return []entity.Product{}, err
+		}
 	}
-	defer src.Close()
 
-	out, err := os.Create(dest)
+	result, err := u.productGateway.GetAllByCategory(ctx, category)
 	if err != nil {
-		return err
+		return []entity.Product{}, &apperror.InternalError{Msg: err.Error()}
 	}
-	defer out.Close()
 
-	_, err = io.Copy(out, src)
-	return err
+	if !availableOnly {
+		return result, nil
+	}
+
+	orderable, err := u.InActiveCategories(ctx, result)
+	if err != nil {
+		return []entity.Product{}, err
+	}
+	return entity.AvailableAt(orderable, time.Now().In(u.location)), nil
 }
 
-func (u *UseCases) GetAllByCategory(ctx context.Context, category string) ([]entity.Product, error) {
-	isValidCategory := enum.IsValidCategory(category)
-	invalidCategory := !isValidCategory && category != ""
+// Search finds the products customers can order right now matching the query. Products outside
+// their menu schedule are left out after the query, so a search may return fewer than its limit.
+func (u *UseCases) Search(ctx context.Context, query entity.SearchQuery) ([]entity.Product, error) {
+	query, err := query.Normalize()
+	if err != nil {
+		return []entity.Product{}, err
+	}
+	if query.Category != "" {
+		if err := u.categoryService.Check(ctx, []enum.Category{query.Category}); err != nil {
+			return []entity.Product{}, err
+		}
+	}
 
-	if invalidCategory {
-		return []entity.Product{}, &apperror.ValidationError{Msg: "Invalid category"}
+	categories, err := u.ListCategories(ctx)
+	if err != nil {
+		return []entity.Product{}, err
+	}
+	// The products of inactive categories are hidden from customers
+	if query.Category != "" {
+		if !slices.Contains(categories, query.Category) {
+			return []entity.Product{}, nil
+		}
+		categories = []enum.Category{query.Category}
+	}
+	if len(categories) == 0 {
+		return []entity.Product{}, nil
 	}
 
-	result, err := u.productGateway.GetAllByCategory(ctx, category)
+	result, err := u.productGateway.Search(ctx, query, categories, true)
 	if err != nil {
-		return []entity.Product{}, &apperror.InternalError{Msg: err.Error()}
+		return []entity.Product{}, err
 	}
 
-	return result, nil
+	return entity.AvailableAt(result, time.Now().In(u.location)), nil
 }
 
-func (u *UseCases) Update(ctx context.Context, productId string, product entity.Product) (entity.Product, error) {
+// InActiveCategories keeps the products whose category customers can order from, the same way
+// the product listing hides the products of inactive categories
+func (u *UseCases) InActiveCategories(ctx context.Context, products []entity.Product) ([]entity.Product, error) {
+	active, err := u.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return inCategories(products, active), nil
+}
 
-	_, findErr := u.FindByID(ctx, productId)
+func inCategories(products []entity.Product, categories []enum.Category) []entity.Product {
+	wanted := make(map[enum.Category]bool, len(categories))
+	for _, category := range categories {
+		wanted[category] = true
+	}
+
+	result := make([]entity.Product, 0, len(products))
+	for _, product := range products {
+		if wanted[product.Category] {
+			result = append(result, product)
+		}
+	}
+	return result
+}
+
+// Update changes the product and records the new version in its history
+func (u *UseCases) Update(ctx context.Context, productId string, product entity.Product) (entity.Product, error) {
+	current, findErr := u.FindByID(ctx, productId)
 	if findErr != nil {
 		return entity.Product{}, findErr
 	}
+	if product.Category != "" {
+		if err := u.categoryService.Check(ctx, []enum.Category{product.Category}); err != nil {
+			return entity.Product{}, err
+		}
+	}
+
+	product.ModifierGroups = withModifierIDs(product.ModifierGroups)
+	for _, group := range product.ModifierGroups {
+		if err := group.Validate(); err != nil {
+			return entity.Product{}, err
+		}
+	}
 
-	updated, updateErr := u.productGateway.Update(ctx, productId, product)
-	if updateErr != nil {
-		return entity.Product{}, updateErr
+	var updated entity.Product
+	txErr := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var updateErr error
+		updated, updateErr = u.productGateway.Update(ctx, productId, product)
+		if updateErr != nil {
+			return updateErr
+		}
+		// An update without changes keeps the version it read
+		if updated.Version == current.Version {
+			return nil
+		}
+		return u.productGateway.CreateRevision(ctx, entity.NewRevision(updated))
+	})
+	if txErr != nil {
+		return entity.Product{}, txErr
+	}
+
+	if current.ImageURL != "" && updated.ImageURL != current.ImageURL {
+		u.deleteOrphanImage(ctx, current.ImageURL)
 	}
 
 	return updated, nil
 }
 
+// withModifierIDs gives IDs to new groups and options, keeping nil for an update that leaves them alone
+func withModifierIDs(groups []entity.ModifierGroup) []entity.ModifierGroup {
+	if groups == nil {
+		return nil
+	}
+	result := make([]entity.ModifierGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, group.WithIDs())
+	}
+	return result
+}
+
 func (u *UseCases) FindByID(ctx context.Context, productId string) (entity.Product, error) {
 	if _, err := uuid.Parse(productId); err != nil {
 		return entity.Product{}, &apperror.ValidationError{Msg: "Invalid UUID format for product ID"}
@@ -154,18 +283,77 @@ func (u *UseCases) FindByID(ctx context.Context, productId string) (entity.Produ
 	return found, nil
 }
 
+// SetAvailability switches the product on or off the menu and replaces its schedule, nil for every hour
+func (u *UseCases) SetAvailability(ctx context.Context, productId string, available bool, schedule *entity.MenuSchedule) (entity.Product, error) {
+	if _, err := uuid.Parse(productId); err != nil {
+		return entity.Product{}, &apperror.ValidationError{Msg: "Invalid UUID format for product ID"}
+	}
+	if schedule != nil {
+		if err := schedule.Validate(); err != nil {
+			return entity.Product{}, err
+		}
+	}
+
+	var updated entity.Product
+	txErr := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = u.productGateway.SetAvailability(ctx, productId, available, schedule)
+		if err != nil {
+			return err
+		}
+		return u.productGateway.CreateRevision(ctx, entity.NewRevision(updated))
+	})
+	if txErr != nil {
+		return entity.Product{}, txErr
+	}
+
+	return updated, nil
+}
+
+// Delete archives the product: it leaves the menu and can no longer be ordered, but past orders
+// keep showing it and ListArchived and Restore can bring it back. Its image is kept until the
+// product is purged, see Purge.
 func (u *UseCases) Delete(ctx context.Context, productId string) error {
 	if _, err := uuid.Parse(productId); err != nil {
 		return &apperror.ValidationError{Msg: "invalid UUID format for product ID"}
 	}
-	_, findErr := u.FindByID(ctx, productId)
-	if findErr != nil {
-		return findErr
+
+	return u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		archived, err := u.productGateway.Delete(ctx, productId)
+		if err != nil {
+			return err
+		}
+		return u.productGateway.CreateRevision(ctx, entity.NewRevision(archived))
+	})
+}
+
+// Purge removes an archived product for good, with its history, and deletes its image once no
+// other product shows it. Products that order lines still show are never purged, since past
+// orders keep resolving them; those stay archived.
+func (u *UseCases) Purge(ctx context.Context, productId string) error {
+	if _, err := uuid.Parse(productId); err != nil {
+		return &apperror.ValidationError{Msg: "invalid UUID format for product ID"}
 	}
 
-	deleteErr := u.productGateway.Delete(ctx, productId)
-	if deleteErr != nil {
-		return deleteErr
+	var purged entity.Product
+	txErr := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		lines, err := u.productOrderService.CountByProduct(ctx, productId)
+		if err != nil {
+			return err
+		}
+		if lines > 0 {
+			return &apperror.ConflictError{Msg: "Product cannot be purged: past orders still show it"}
+		}
+
+		purged, err = u.productGateway.Purge(ctx, productId)
+		return err
+	})
+	if txErr != nil {
+		return txErr
+	}
+
+	if purged.ImageURL != "" {
+		u.deleteOrphanImage(ctx, purged.ImageURL)
 	}
 
 	return nil
@@ -189,5 +377,66 @@ func (u *UseCases) FindByIDs(ctx context.Context, productIdList []string) ([]ent
 	}
 
 	return products, nil
+}
+
+// FindByIDsWithArchived is FindByIDs including archived products, for views of past orders
+func (u *UseCases) FindByIDsWithArchived(ctx context.Context, productIdList []string) ([]entity.Product, error) {
+	if len(productIdList) == 0 {
+		return nil, &apperror.ValidationError{Msg: "productIdList cannot be empty"}
+	}
+
+	for _, id := range productIdList {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, &apperror.ValidationError{Msg: fmt.Sprintf("Invalid UUID format: %s", id)}
+		}
+	}
+
+	return u.productGateway.FindByIDsWithArchived(ctx, productIdList)
+}
+
+func (u *UseCases) ListArchived(ctx context.Context) ([]entity.Product, error) {
+	return u.productGateway.ListArchived(ctx)
+}
+
+// Restore puts an archived product back on the menu as it was when it was archived. Its
+// category must still exist, otherwise it stays archived until the category is created again.
+func (u *UseCases) Restore(ctx context.Context, productId string) (entity.Product, error) {
+	if _, err := uuid.Parse(productId); err != nil {
+		return entity.Product{}, &apperror.ValidationError{Msg: "Invalid UUID format for product ID"}
+	}
+
+	var restored entity.Product
+	txErr := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		restored, err = u.productGateway.Restore(ctx, productId)
+		if err != nil {
+			return err
+		}
+		if err := u.categoryService.Check(ctx, []enum.Category{restored.Category}); err != nil {
+			var validationErr *apperror.ValidationError
+			if errors.As(err, &validationErr) {
+				return &apperror.ValidationError{Msg: fmt.Sprintf("Product cannot be restored: %s", validationErr.Msg)}
+			}
+			return err
+		}
+		return u.productGateway.CreateRevision(ctx, entity.NewRevision(restored))
+	})
+	if txErr != nil {
+		return entity.Product{}, txErr
+	}
+
+	return restored, nil
+}
+
+// History returns the versions of the product, newest first. Archived products keep their
+// history; products saved before history was kept start at their version at that time.
+func (u *UseCases) History(ctx context.Context, productId string) ([]entity.Revision, error) {
+	if _, err := uuid.Parse(productId); err != nil {
+		return nil, &apperror.ValidationError{Msg: "Invalid UUID format for product ID"}
+	}
+	if _, err := u.productGateway.FindByIDsWithArchived(ctx, []string{productId}); err != nil {
+		return nil, err
+	}
 
+	return u.productGateway.ListRevisions(ctx, productId)
 }
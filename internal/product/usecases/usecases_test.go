@@ -0,0 +1,332 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	categoryentity "github.com/fiap-161/tc-golunch-core-service/internal/category/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/product/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/product/external/datasource"
+	"github.com/fiap-161/tc-golunch-core-service/internal/product/gateway"
+	coreentity "github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// memoryProductDataSource archives and restores products the way the persisted datasource does;
+// the methods the tests do not reach are left to the embedded interface
+type memoryProductDataSource struct {
+	datasource.DataSource
+	products  map[string]dto.ProductDAO
+	revisions []dto.ProductRevisionDAO
+}
+
+func (d *memoryProductDataSource) FindByIDsWithArchived(_ context.Context, ids []string) ([]dto.ProductDAO, error) {
+	var found []dto.ProductDAO
+	for _, id := range ids {
+		if product, ok := d.products[id]; ok {
+			found = append(found, product)
+		}
+	}
+	return found, nil
+}
+
+func (d *memoryProductDataSource) ListArchived(_ context.Context) ([]dto.ProductDAO, error) {
+	var archived []dto.ProductDAO
+	for _, product := range d.products {
+		if product.DeletedAt.Valid {
+			archived = append(archived, product)
+		}
+	}
+	return archived, nil
+}
+
+func (d *memoryProductDataSource) Delete(_ context.Context, id string) (dto.ProductDAO, error) {
+	product, ok := d.products[id]
+	if !ok || product.DeletedAt.Valid {
+		return dto.ProductDAO{}, &apperror.NotFoundError{Msg: "Product not found"}
+	}
+	product.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	product.Version++
+	d.products[id] = product
+	return product, nil
+}
+
+func (d *memoryProductDataSource) Restore(_ context.Context, id string) (dto.ProductDAO, error) {
+	product, ok := d.products[id]
+	if !ok || !product.DeletedAt.Valid {
+		return dto.ProductDAO{}, &apperror.NotFoundError{Msg: "Archived product not found"}
+	}
+	product.DeletedAt = gorm.DeletedAt{}
+	product.Version++
+	d.products[id] = product
+	return product, nil
+}
+
+func (d *memoryProductDataSource) Purge(_ context.Context, id string) (dto.ProductDAO, error) {
+	product, ok := d.products[id]
+	if !ok || !product.DeletedAt.Valid {
+		return dto.ProductDAO{}, &apperror.NotFoundError{Msg: "Archived product not found"}
+	}
+	delete(d.products, id)
+	return product, nil
+}
+
+func (d *memoryProductDataSource) CountByImageURL(_ context.Context, imageURL string) (int64, error) {
+	var count int64
+	for _, product := range d.products {
+		if product.ImageURL == imageURL {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (d *memoryProductDataSource) CreateRevision(_ context.Context, revision dto.ProductRevisionDAO) error {
+	d.revisions = append(d.revisions, revision)
+	return nil
+}
+
+// memoryUnitOfWork puts the datasource back as it was when fn fails
+type memoryUnitOfWork struct {
+	datasource *memoryProductDataSource
+}
+
+func (u memoryUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	products := maps.Clone(u.datasource.products)
+	revisions := slices.Clone(u.datasource.revisions)
+	if err := fn(ctx); err != nil {
+		u.datasource.products = products
+		u.datasource.revisions = revisions
+		return err
+	}
+	return nil
+}
+
+// storedCategories checks codes against a fixed set of categories
+type storedCategories []enum.Category
+
+func (s storedCategories) Check(_ context.Context, codes []enum.Category) error {
+	for _, code := range codes {
+		if !slices.Contains(s, code) {
+			return &apperror.ValidationError{Msg: fmt.Sprintf("Invalid category %s", code)}
+		}
+	}
+	return nil
+}
+
+func (s storedCategories) List(_ context.Context, _ bool) ([]categoryentity.Category, error) {
+	return nil, nil
+}
+
+// orderLines counts the order lines showing each product
+type orderLines map[string]int64
+
+func (o orderLines) CountByProduct(_ context.Context, productID string) (int64, error) {
+	return o[productID], nil
+}
+
+// recordingImageStorage remembers the images it was asked to delete
+type recordingImageStorage struct {
+	deleted []string
+}
+
+func (s *recordingImageStorage) Save(context.Context, string, string, io.Reader) (string, error) {
+	return "", nil
+}
+
+func (s *recordingImageStorage) PresignUpload(context.Context, string, string, time.Duration) (entity.ImageUpload, error) {
+	return entity.ImageUpload{}, nil
+}
+
+func (s *recordingImageStorage) Delete(_ context.Context, url string) error {
+	s.deleted = append(s.deleted, url)
+	return nil
+}
+
+const (
+	burgerID = "6f1c2a4e-8a7b-4a55-9b39-3c2c7d1f0a11"
+	sodaID   = "0b7f5e22-1d4c-4f0e-8f6a-5e2b9c3d4a22"
+)
+
+func TestUseCases_ArchiveAndRestore(t *testing.T) {
+	setup := func(categories storedCategories) (*UseCases, *memoryProductDataSource) {
+		product := func(id, name string, category enum.Category, archived bool) dto.ProductDAO {
+			available := true
+			dao := dto.ProductDAO{
+				Entity:         coreentity.Entity{ID: id},
+				Name:           name,
+				Category:       category,
+				ModifierGroups: "[]",
+				Schedule:       "null",
+				Available:      &available,
+				Version:        1,
+			}
+			if archived {
+				dao.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+			}
+			return dao
+		}
+		productDataSource := &memoryProductDataSource{products: map[string]dto.ProductDAO{
+			burgerID: product(burgerID, "Burger", "MEAL", false),
+			sodaID:   product(sodaID, "Soda", "DRINK", true),
+		}}
+		u := Build(*gateway.Build(productDataSource), time.UTC, memoryUnitOfWork{productDataSource}, categories, orderLines{}, nil)
+		return u, productDataSource
+	}
+	all := storedCategories{"MEAL", "DRINK"}
+
+	t.Run("Given a product, when archiving it, then it is archived as a new version with its revision", func(t *testing.T) {
+		u, productDataSource := setup(all)
+
+		err := u.Delete(context.Background(), burgerID)
+
+		assert.NoError(t, err)
+		archived := productDataSource.products[burgerID]
+		assert.True(t, archived.DeletedAt.Valid)
+		assert.Equal(t, uint(2), archived.Version)
+		assert.Len(t, productDataSource.revisions, 1)
+		assert.Equal(t, burgerID, productDataSource.revisions[0].ProductID)
+		assert.Equal(t, uint(2), productDataSource.revisions[0].Version)
+	})
+
+	t.Run("Given an archived product, when archiving it again, then it is not found and nothing is recorded", func(t *testing.T) {
+		u, productDataSource := setup(all)
+
+		err := u.Delete(context.Background(), sodaID)
+
+		var notFoundErr *apperror.NotFoundError
+		assert.True(t, errors.As(err, &notFoundErr))
+		assert.Empty(t, productDataSource.revisions)
+	})
+
+	t.Run("Given archived products, when listing them, then only those are returned", func(t *testing.T) {
+		u, _ := setup(all)
+
+		archived, err := u.ListArchived(context.Background())
+
+		assert.NoError(t, err)
+		assert.Len(t, archived, 1)
+		assert.Equal(t, sodaID, archived[0].Id)
+		assert.NotNil(t, archived[0].ArchivedAt)
+	})
+
+	t.Run("Given an archived product, when restoring it, then it is back as a new version with its revision", func(t *testing.T) {
+		u, productDataSource := setup(all)
+
+		restored, err := u.Restore(context.Background(), sodaID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, restored.ArchivedAt)
+		assert.Equal(t, uint(2), restored.Version)
+		assert.False(t, productDataSource.products[sodaID].DeletedAt.Valid)
+		assert.Len(t, productDataSource.revisions, 1)
+		assert.Equal(t, uint(2), productDataSource.revisions[0].Version)
+	})
+
+	t.Run("Given a product that is not archived, when restoring it, then it is not found", func(t *testing.T) {
+		u, productDataSource := setup(all)
+
+		_, err := u.Restore(context.Background(), burgerID)
+
+		var notFoundErr *apperror.NotFoundError
+		assert.True(t, errors.As(err, &notFoundErr))
+		assert.Empty(t, productDataSource.revisions)
+	})
+
+	t.Run("Given an archived product whose category was deleted, when restoring it, then it is a validation error and it stays archived", func(t *testing.T) {
+		u, productDataSource := setup(storedCategories{"MEAL"})
+
+		_, err := u.Restore(context.Background(), sodaID)
+
+		var validationErr *apperror.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.True(t, productDataSource.products[sodaID].DeletedAt.Valid)
+		assert.Equal(t, uint(1), productDataSource.products[sodaID].Version)
+		assert.Empty(t, productDataSource.revisions)
+	})
+}
+
+func TestUseCases_Purge(t *testing.T) {
+	setup := func(lines orderLines, sharedImage bool) (*UseCases, *memoryProductDataSource, *recordingImageStorage) {
+		available := true
+		product := func(id, name, imageURL string, archived bool) dto.ProductDAO {
+			dao := dto.ProductDAO{
+				Entity:         coreentity.Entity{ID: id},
+				Name:           name,
+				Category:       "MEAL",
+				ImageURL:       imageURL,
+				ModifierGroups: "[]",
+				Schedule:       "null",
+				Available:      &available,
+				Version:        2,
+			}
+			if archived {
+				dao.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+			}
+			return dao
+		}
+		sodaImage := "/uploads/products/soda.png"
+		if sharedImage {
+			sodaImage = "/uploads/products/burger.png"
+		}
+		productDataSource := &memoryProductDataSource{products: map[string]dto.ProductDAO{
+			burgerID: product(burgerID, "Burger", "/uploads/products/burger.png", true),
+			sodaID:   product(sodaID, "Soda", sodaImage, false),
+		}}
+		imageStorage := &recordingImageStorage{}
+		u := Build(*gateway.Build(productDataSource), time.UTC, memoryUnitOfWork{productDataSource}, storedCategories{"MEAL"}, lines, imageStorage)
+		return u, productDataSource, imageStorage
+	}
+
+	t.Run("Given an archived product no order shows, when purging it, then it is removed with its image", func(t *testing.T) {
+		u, productDataSource, imageStorage := setup(orderLines{}, false)
+
+		err := u.Purge(context.Background(), burgerID)
+
+		assert.NoError(t, err)
+		assert.NotContains(t, productDataSource.products, burgerID)
+		assert.Equal(t, []string{"/uploads/products/burger.png"}, imageStorage.deleted)
+	})
+
+	t.Run("Given an archived product whose image another product shows, when purging it, then the image is kept", func(t *testing.T) {
+		u, productDataSource, imageStorage := setup(orderLines{}, true)
+
+		err := u.Purge(context.Background(), burgerID)
+
+		assert.NoError(t, err)
+		assert.NotContains(t, productDataSource.products, burgerID)
+		assert.Empty(t, imageStorage.deleted)
+	})
+
+	t.Run("Given an archived product a past order shows, when purging it, then it is a conflict and it stays archived", func(t *testing.T) {
+		u, productDataSource, imageStorage := setup(orderLines{burgerID: 1}, false)
+
+		err := u.Purge(context.Background(), burgerID)
+
+		var conflictErr *apperror.ConflictError
+		assert.True(t, errors.As(err, &conflictErr))
+		assert.True(t, productDataSource.products[burgerID].DeletedAt.Valid)
+		assert.Empty(t, imageStorage.deleted)
+	})
+
+	t.Run("Given a product that is not archived, when purging it, then it is not found", func(t *testing.T) {
+		u, productDataSource, imageStorage := setup(orderLines{}, false)
+
+		err := u.Purge(context.Background(), sodaID)
+
+		var notFoundErr *apperror.NotFoundError
+		assert.True(t, errors.As(err, &notFoundErr))
+		assert.Contains(t, productDataSource.products, sodaID)
+		assert.Empty(t, imageStorage.deleted)
+	})
+}
@@ -64,10 +64,12 @@ func (c *Controller) BuildBulkFromOrderAndProducts(
 		for _, item := range orderProductInfo {
 			if product.ID == item.ProductID {
 				result = append(result, dto.ProductOrderRequestDTO{
-					OrderID:   orderID,
-					ProductID: product.ID,
-					Quantity:  item.Quantity,
-					UnitPrice: product.Price,
+					OrderID:     orderID,
+					ProductID:   product.ID,
+					ProductName: product.Name,
+					Category:    string(product.Category),
+					Quantity:    item.Quantity,
+					UnitPrice:   product.Price,
 				})
 			}
 		}
@@ -1,34 +1,66 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
 	"github.com/fiap-161/tc-golunch-core-service/internal/productorder/entity"
 	coreentity "github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
 	"github.com/google/uuid"
 )
 
 type ProductOrderDAO struct {
 	coreentity.Entity
-	ProductID string  `json:"product_id"`
-	OrderID   string  `json:"order_id"`
-	Quantity  int     `json:"quantity"`
-	UnitPrice float64 `json:"unit_price"`
+	ProductID   string      `json:"product_id"`
+	ProductName string      `json:"product_name" gorm:"type:varchar(255)"`
+	Category    string      `json:"category" gorm:"type:varchar(20)"`
+	OrderID     string      `json:"order_id"`
+	Quantity    int         `json:"quantity"`
+	UnitPrice   money.Money `json:"unit_price" gorm:"embedded;embeddedPrefix:unit_price_"`
+	Modifiers   string      `json:"modifiers" gorm:"type:jsonb;not null;default:'[]'"`
+	Notes       string      `json:"notes" gorm:"type:varchar(255)"`
+	ComboID     string      `json:"combo_id,omitempty" gorm:"type:varchar(36);index"`
+	ComboItemID string      `json:"combo_item_id,omitempty" gorm:"type:varchar(36)"`
+	ComboSlotID string      `json:"combo_slot_id,omitempty" gorm:"type:varchar(36)"`
+}
+
+// ModifierDTO is also the JSON stored in the modifiers column of the product lines
+type ModifierDTO struct {
+	OptionID   string      `json:"option_id"`
+	GroupName  string      `json:"group_name"`
+	Name       string      `json:"name"`
+	PriceDelta money.Money `json:"price_delta"`
 }
 
 type ProductOrderRequestDTO struct {
-	ProductID string  `json:"product_id"`
-	OrderID   string  `json:"order_id"`
-	Quantity  int     `json:"quantity"`
-	UnitPrice float64 `json:"unit_price"`
+	ProductID   string        `json:"product_id"`
+	ProductName string        `json:"product_name"`
+	Category    string        `json:"category"`
+	OrderID     string        `json:"order_id"`
+	Quantity    int           `json:"quantity"`
+	UnitPrice   money.Money   `json:"unit_price"`
+	Modifiers   []ModifierDTO `json:"modifiers"`
+	Notes       string        `json:"notes"`
+	ComboID     string        `json:"combo_id,omitempty"`
+	ComboItemID string        `json:"combo_item_id,omitempty"`
+	ComboSlotID string        `json:"combo_slot_id,omitempty"`
 }
 
 type ProductOrderResponseDTO struct {
-	ID        string  `json:"id"`
-	ProductID string  `json:"product_id"`
-	OrderID   string  `json:"order_id"`
-	Quantity  int     `json:"quantity"`
-	UnitPrice float64 `json:"unit_price"`
+	ID          string        `json:"id"`
+	ProductID   string        `json:"product_id"`
+	ProductName string        `json:"product_name"`
+	Category    string        `json:"category"`
+	OrderID     string        `json:"order_id"`
+	Quantity    int           `json:"quantity"`
+	UnitPrice   money.Money   `json:"unit_price"`
+	Modifiers   []ModifierDTO `json:"modifiers"`
+	Notes       string        `json:"notes"`
+	ComboID     string        `json:"combo_id,omitempty"`
+	ComboItemID string        `json:"combo_item_id,omitempty"`
+	ComboSlotID string        `json:"combo_slot_id,omitempty"`
 }
 
 type OrderProductInfo struct {
@@ -44,10 +76,17 @@ func ToProductOrderDAO(po entity.ProductOrder) ProductOrderDAO {
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		},
-		ProductID: po.ProductID,
-		OrderID:   po.OrderID,
-		Quantity:  po.Quantity,
-		UnitPrice: po.UnitPrice,
+		ProductID:   po.ProductID,
+		ProductName: po.ProductName,
+		Category:    string(po.Category),
+		OrderID:     po.OrderID,
+		Quantity:    po.Quantity,
+		UnitPrice:   po.UnitPrice,
+		Modifiers:   encodeModifiers(po.Modifiers),
+		Notes:       po.Notes,
+		ComboID:     po.ComboID,
+		ComboItemID: po.ComboItemID,
+		ComboSlotID: po.ComboSlotID,
 	}
 }
 
@@ -63,11 +102,18 @@ func ToListProductOrderDAO(list []entity.ProductOrder) []ProductOrderDAO {
 // Convert DAO to entity
 func FromProductOrderDAO(dao ProductOrderDAO) entity.ProductOrder {
 	return entity.ProductOrder{
-		ID:        dao.ID,
-		ProductID: dao.ProductID,
-		OrderID:   dao.OrderID,
-		Quantity:  dao.Quantity,
-		UnitPrice: dao.UnitPrice,
+		ID:          dao.ID,
+		ProductID:   dao.ProductID,
+		ProductName: dao.ProductName,
+		Category:    productenum.Category(dao.Category),
+		OrderID:     dao.OrderID,
+		Quantity:    dao.Quantity,
+		UnitPrice:   dao.UnitPrice,
+		Modifiers:   decodeModifiers(dao.Modifiers),
+		Notes:       dao.Notes,
+		ComboID:     dao.ComboID,
+		ComboItemID: dao.ComboItemID,
+		ComboSlotID: dao.ComboSlotID,
 	}
 }
 
@@ -83,9 +129,55 @@ func ToListProductOrder(list []ProductOrderDAO) []entity.ProductOrder {
 // Convert request DTO to entity
 func FromRequestDTO(dto ProductOrderRequestDTO) entity.ProductOrder {
 	return entity.ProductOrder{
-		ProductID: dto.ProductID,
-		OrderID:   dto.OrderID,
-		Quantity:  dto.Quantity,
-		UnitPrice: dto.UnitPrice,
+		ProductID:   dto.ProductID,
+		ProductName: dto.ProductName,
+		Category:    productenum.Category(dto.Category),
+		OrderID:     dto.OrderID,
+		Quantity:    dto.Quantity,
+		UnitPrice:   dto.UnitPrice,
+		Modifiers:   FromModifierDTOList(dto.Modifiers),
+		Notes:       dto.Notes,
+		ComboID:     dto.ComboID,
+		ComboItemID: dto.ComboItemID,
+		ComboSlotID: dto.ComboSlotID,
+	}
+}
+
+func ToModifierDTOList(modifiers []entity.Modifier) []ModifierDTO {
+	result := make([]ModifierDTO, 0, len(modifiers))
+	for _, modifier := range modifiers {
+		result = append(result, ModifierDTO{
+			OptionID:   modifier.OptionID,
+			GroupName:  modifier.GroupName,
+			Name:       modifier.Name,
+			PriceDelta: modifier.PriceDelta,
+		})
+	}
+	return result
+}
+
+func FromModifierDTOList(modifiers []ModifierDTO) []entity.Modifier {
+	var result []entity.Modifier
+	for _, modifier := range modifiers {
+		result = append(result, entity.Modifier{
+			OptionID:   modifier.OptionID,
+			GroupName:  modifier.GroupName,
+			Name:       modifier.Name,
+			PriceDelta: modifier.PriceDelta,
+		})
+	}
+	return result
+}
+
+func encodeModifiers(modifiers []entity.Modifier) string {
+	raw, _ := json.Marshal(ToModifierDTOList(modifiers))
+	return string(raw)
+}
+
+func decodeModifiers(raw string) []entity.Modifier {
+	var modifiers []ModifierDTO
+	if json.Unmarshal([]byte(raw), &modifiers) != nil {
+		return nil
 	}
+	return FromModifierDTOList(modifiers)
 }
@@ -1,9 +1,52 @@
 package entity
 
+import (
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+)
+
+// ProductOrder is a line of an order. UnitPrice already includes the price of its modifiers.
+// Lines of a combo carry the combo, the slot they fill and the combo item they belong to, which
+// groups the lines of one combo of the order; their unit price is the share of the combo price
+// allocated to the product. ProductName and Category are copied from the product when the order
+// is placed, so the line reads the same after the product is renamed or archived.
 type ProductOrder struct {
-	ID        string
-	ProductID string
-	OrderID   string
-	Quantity  int
-	UnitPrice float64
+	ID          string
+	ProductID   string
+	ProductName string
+	Category    productenum.Category
+	OrderID     string
+	Quantity    int
+	UnitPrice   money.Money
+	Modifiers   []Modifier
+	Notes       string
+	ComboID     string
+	ComboItemID string
+	ComboSlotID string
+}
+
+// Modifier is an option chosen for the line, copied from the product when the order was placed
+type Modifier struct {
+	OptionID   string
+	GroupName  string
+	Name       string
+	PriceDelta money.Money
+}
+
+// BasePrice is the unit price of the product alone, without the modifiers of the line
+func (po ProductOrder) BasePrice() money.Money {
+	base := po.UnitPrice
+	for _, modifier := range po.Modifiers {
+		base = money.New(base.Amount-modifier.PriceDelta.Amount, base.Currency)
+	}
+	return base
+}
+
+// OptionIDs lists the options chosen for the line, in the order they were stored
+func (po ProductOrder) OptionIDs() []string {
+	var ids []string
+	for _, modifier := range po.Modifiers {
+		ids = append(ids, modifier.OptionID)
+	}
+	return ids
 }
@@ -9,4 +9,6 @@ import (
 type DataSource interface {
 	CreateBulk(ctx context.Context, orders []dto.ProductOrderDAO) (int, error)
 	FindByOrderID(ctx context.Context, orderID string) ([]dto.ProductOrderDAO, error)
+	FindByOrderIDs(ctx context.Context, orderIDs []string) ([]dto.ProductOrderDAO, error)
+	CountByProductID(ctx context.Context, productID string) (int64, error)
 }
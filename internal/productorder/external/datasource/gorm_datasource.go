@@ -3,6 +3,7 @@ package datasource
 import (
 	"context"
 
+	"github.com/fiap-161/tc-golunch-core-service/database"
 	"github.com/fiap-161/tc-golunch-core-service/internal/productorder/dto"
 	"gorm.io/gorm"
 )
@@ -11,6 +12,7 @@ type DB interface {
 	Create(value any) *gorm.DB
 	Where(query any, args ...any) *gorm.DB
 	Find(dest any, conds ...any) *gorm.DB
+	Model(value any) *gorm.DB
 }
 
 type GormDataSource struct {
@@ -23,8 +25,8 @@ func New(db DB) *GormDataSource {
 	}
 }
 
-func (r *GormDataSource) CreateBulk(_ context.Context, orders []dto.ProductOrderDAO) (int, error) {
-	tx := r.db.Create(&orders)
+func (r *GormDataSource) CreateBulk(ctx context.Context, orders []dto.ProductOrderDAO) (int, error) {
+	tx := database.Conn(ctx, r.db).Create(&orders)
 	if tx.Error != nil {
 		return 0, tx.Error
 	}
@@ -32,13 +34,31 @@ func (r *GormDataSource) CreateBulk(_ context.Context, orders []dto.ProductOrder
 	return len(orders), nil
 }
 
-func (r *GormDataSource) FindByOrderID(_ context.Context, orderID string) ([]dto.ProductOrderDAO, error) {
+func (r *GormDataSource) FindByOrderID(ctx context.Context, orderID string) ([]dto.ProductOrderDAO, error) {
 	var orders []dto.ProductOrderDAO
 
-	tx := r.db.Where("order_id = ?", orderID).Find(&orders)
+	tx := database.Conn(ctx, r.db).Where("order_id = ?", orderID).Find(&orders)
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
 
 	return orders, nil
 }
+
+func (r *GormDataSource) FindByOrderIDs(ctx context.Context, orderIDs []string) ([]dto.ProductOrderDAO, error) {
+	var orders []dto.ProductOrderDAO
+
+	tx := database.Conn(ctx, r.db).Where("order_id IN ?", orderIDs).Find(&orders)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	return orders, nil
+}
+
+// CountByProductID counts the order lines of the product, combo selections included
+func (r *GormDataSource) CountByProductID(ctx context.Context, productID string) (int64, error) {
+	var count int64
+	err := database.Conn(ctx, r.db).Model(&dto.ProductOrderDAO{}).Where("product_id = ?", productID).Count(&count).Error
+	return count, err
+}
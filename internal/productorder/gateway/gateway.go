@@ -40,3 +40,21 @@ func (g *Gateway) FindByOrderID(c context.Context, orderId string) ([]entity.Pro
 
 	return productOrder, nil
 }
+
+func (g *Gateway) FindByOrderIDs(c context.Context, orderIDs []string) ([]entity.ProductOrder, error) {
+	listProductOrderFoundDAO, err := g.datasource.FindByOrderIDs(c, orderIDs)
+	if err != nil {
+		return []entity.ProductOrder{}, &apperror.InternalError{Msg: err.Error()}
+	}
+
+	return dto.ToListProductOrder(listProductOrderFoundDAO), nil
+}
+
+func (g *Gateway) CountByProductID(c context.Context, productID string) (int64, error) {
+	count, err := g.datasource.CountByProductID(c, productID)
+	if err != nil {
+		return 0, &apperror.InternalError{Msg: err.Error()}
+	}
+
+	return count, nil
+}
@@ -14,11 +14,18 @@ func Build() *Presenter {
 
 func (p *Presenter) FromEntityToResponseDTO(po entity.ProductOrder) dto.ProductOrderResponseDTO {
 	return dto.ProductOrderResponseDTO{
-		ID:        po.ID,
-		ProductID: po.ProductID,
-		OrderID:   po.OrderID,
-		Quantity:  po.Quantity,
-		UnitPrice: po.UnitPrice,
+		ID:          po.ID,
+		ProductID:   po.ProductID,
+		ProductName: po.ProductName,
+		Category:    string(po.Category),
+		OrderID:     po.OrderID,
+		Quantity:    po.Quantity,
+		UnitPrice:   po.UnitPrice,
+		Modifiers:   dto.ToModifierDTOList(po.Modifiers),
+		Notes:       po.Notes,
+		ComboID:     po.ComboID,
+		ComboItemID: po.ComboItemID,
+		ComboSlotID: po.ComboSlotID,
 	}
 }
 
@@ -30,7 +30,7 @@ func (u *UseCases) CreateBulk(ctx context.Context, productOrders []entity.Produc
 		if po.Quantity <= 0 {
 			return 0, &apperror.ValidationError{Msg: fmt.Sprintf("productOrder[%d]: quantity has to be more than zero", i)}
 		}
-		if po.UnitPrice < 0 {
+		if po.UnitPrice.IsNegative() {
 			return 0, &apperror.ValidationError{Msg: fmt.Sprintf("productOrder[%d]: unitPrice cannot be negative", i)}
 		}
 	}
@@ -51,3 +51,16 @@ func (u *UseCases) FindByOrderID(ctx context.Context, orderID string) ([]entity.
 
 	return productOrderFound, nil
 }
+
+func (u *UseCases) FindByOrderIDs(ctx context.Context, orderIDs []string) ([]entity.ProductOrder, error) {
+	if len(orderIDs) == 0 {
+		return []entity.ProductOrder{}, nil
+	}
+
+	return u.productOrderGateway.FindByOrderIDs(ctx, orderIDs)
+}
+
+// CountByProduct counts the order lines that show the product
+func (u *UseCases) CountByProduct(ctx context.Context, productID string) (int64, error) {
+	return u.productOrderGateway.CountByProductID(ctx, productID)
+}
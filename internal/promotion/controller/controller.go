@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/presenter"
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/usecases"
+)
+
+type Controller struct {
+	promotionUseCase *usecases.UseCases
+}
+
+func Build(promotionUseCase *usecases.UseCases) *Controller {
+	return &Controller{
+		promotionUseCase: promotionUseCase,
+	}
+}
+
+func (c *Controller) Create(ctx context.Context, request dto.PromotionRequestDTO) (dto.PromotionResponseDTO, error) {
+	presenter := presenter.Build()
+
+	promotion, err := dto.FromRequestDTO(request)
+	if err != nil {
+		return dto.PromotionResponseDTO{}, err
+	}
+
+	created, err := c.promotionUseCase.Create(ctx, promotion)
+	if err != nil {
+		return dto.PromotionResponseDTO{}, err
+	}
+
+	return presenter.FromEntityToResponseDTO(created, entity.Usage{}), nil
+}
+
+func (c *Controller) List(ctx context.Context) (dto.PromotionListResponseDTO, error) {
+	presenter := presenter.Build()
+
+	promotions, usage, err := c.promotionUseCase.List(ctx)
+	if err != nil {
+		return dto.PromotionListResponseDTO{}, err
+	}
+
+	return presenter.FromEntityListToListResponseDTO(promotions, usage), nil
+}
+
+func (c *Controller) FindByID(ctx context.Context, id string) (dto.PromotionResponseDTO, error) {
+	presenter := presenter.Build()
+
+	promotion, usage, err := c.promotionUseCase.FindByID(ctx, id)
+	if err != nil {
+		return dto.PromotionResponseDTO{}, err
+	}
+
+	return presenter.FromEntityToResponseDTO(promotion, usage), nil
+}
+
+func (c *Controller) Update(ctx context.Context, id string, request dto.PromotionRequestDTO) (dto.PromotionResponseDTO, error) {
+	presenter := presenter.Build()
+
+	promotion, err := dto.FromRequestDTO(request)
+	if err != nil {
+		return dto.PromotionResponseDTO{}, err
+	}
+
+	updated, err := c.promotionUseCase.Update(ctx, id, promotion)
+	if err != nil {
+		return dto.PromotionResponseDTO{}, err
+	}
+
+	_, usage, err := c.promotionUseCase.FindByID(ctx, updated.ID)
+	if err != nil {
+		return dto.PromotionResponseDTO{}, err
+	}
+	return presenter.FromEntityToResponseDTO(updated, usage), nil
+}
+
+func (c *Controller) Delete(ctx context.Context, id string) error {
+	return c.promotionUseCase.Delete(ctx, id)
+}
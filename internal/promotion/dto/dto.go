@@ -0,0 +1,347 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/entity/enum"
+	coreentity "github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+)
+
+// PromotionRequestDTO creates or replaces a promotion. Leave code empty for a promotion that
+// applies by itself; percent is 1-100; amount is used by FIXED promotions; buy_quantity and
+// get_quantity by BUY_X_GET_Y ones.
+type PromotionRequestDTO struct {
+	Name               string       `json:"name" binding:"required"`
+	Code               string       `json:"code"`
+	Type               string       `json:"type" binding:"required" example:"PERCENTAGE"`
+	Percent            int64        `json:"percent"`
+	Amount             money.Money  `json:"amount"`
+	BuyQuantity        int          `json:"buy_quantity"`
+	GetQuantity        int          `json:"get_quantity"`
+	Categories         []string     `json:"categories"`
+	ProductIDs         []string     `json:"product_ids"`
+	MinSubtotal        money.Money  `json:"min_subtotal"`
+	StartsAt           *time.Time   `json:"starts_at"`
+	EndsAt             *time.Time   `json:"ends_at"`
+	Schedule           *ScheduleDTO `json:"schedule"`
+	MaxUses            int          `json:"max_uses"`
+	MaxUsesPerCustomer int          `json:"max_uses_per_customer"`
+	Stackable          bool         `json:"stackable"`
+	Active             *bool        `json:"active"`
+}
+
+// ScheduleDTO is a daily window such as a happy hour, in the store timezone. Weekdays go from
+// 0 (Sunday) to 6 (Saturday) and none means every day. It is also the JSON stored in the
+// schedule column of promotions.
+type ScheduleDTO struct {
+	Weekdays []int  `json:"weekdays"`
+	Start    string `json:"start" example:"17:00"`
+	End      string `json:"end" example:"19:00"`
+}
+
+type PromotionResponseDTO struct {
+	ID                 string       `json:"id"`
+	Name               string       `json:"name"`
+	Code               string       `json:"code,omitempty"`
+	Type               string       `json:"type"`
+	Percent            int64        `json:"percent,omitempty"`
+	Amount             *money.Money `json:"amount,omitempty"`
+	BuyQuantity        int          `json:"buy_quantity,omitempty"`
+	GetQuantity        int          `json:"get_quantity,omitempty"`
+	Categories         []string     `json:"categories"`
+	ProductIDs         []string     `json:"product_ids"`
+	MinSubtotal        *money.Money `json:"min_subtotal,omitempty"`
+	StartsAt           *time.Time   `json:"starts_at,omitempty"`
+	EndsAt             *time.Time   `json:"ends_at,omitempty"`
+	Schedule           *ScheduleDTO `json:"schedule,omitempty"`
+	MaxUses            int          `json:"max_uses"`
+	MaxUsesPerCustomer int          `json:"max_uses_per_customer"`
+	Stackable          bool         `json:"stackable"`
+	Active             bool         `json:"active"`
+	Uses               int          `json:"uses"`
+}
+
+type PromotionListResponseDTO struct {
+	Total uint                   `json:"total"`
+	List  []PromotionResponseDTO `json:"list"`
+}
+
+type PromotionDAO struct {
+	coreentity.Entity
+	Name               string      `json:"name" gorm:"type:varchar(100)"`
+	Code               string      `json:"code" gorm:"type:varchar(50);index"`
+	Type               string      `json:"type" gorm:"type:varchar(20)"`
+	Percent            int64       `json:"percent"`
+	Amount             money.Money `json:"amount" gorm:"embedded;embeddedPrefix:fixed_"`
+	BuyQuantity        int         `json:"buy_quantity"`
+	GetQuantity        int         `json:"get_quantity"`
+	Categories         string      `json:"categories" gorm:"type:jsonb;not null;default:'[]'"`
+	ProductIDs         string      `json:"product_ids" gorm:"type:jsonb;not null;default:'[]'"`
+	MinSubtotal        money.Money `json:"min_subtotal" gorm:"embedded;embeddedPrefix:min_subtotal_"`
+	StartsAt           *time.Time  `json:"starts_at"`
+	EndsAt             *time.Time  `json:"ends_at"`
+	Schedule           string      `json:"schedule" gorm:"type:jsonb;not null;default:'null'"`
+	MaxUses            int         `json:"max_uses"`
+	MaxUsesPerCustomer int         `json:"max_uses_per_customer"`
+	Stackable          bool        `json:"stackable"`
+	Active             bool        `json:"active" gorm:"index"`
+}
+
+func (PromotionDAO) TableName() string {
+	return "promotions"
+}
+
+type RedemptionDAO struct {
+	ID          string      `gorm:"type:uuid;primaryKey"`
+	PromotionID string      `gorm:"type:uuid;index"`
+	OrderID     string      `gorm:"type:uuid;index"`
+	CustomerID  string      `gorm:"index"`
+	Amount      money.Money `gorm:"embedded;embeddedPrefix:discount_"`
+	CreatedAt   time.Time
+}
+
+func (RedemptionDAO) TableName() string {
+	return "promotion_redemptions"
+}
+
+// UsageDAO is a row of the redemption counts of a promotion
+type UsageDAO struct {
+	PromotionID string
+	Total       int
+	ByCustomer  int
+}
+
+func ToPromotionDAO(promotion entity.Promotion) PromotionDAO {
+	return PromotionDAO{
+		Entity:             promotion.Entity,
+		Name:               promotion.Name,
+		Code:               promotion.Code,
+		Type:               promotion.Type.String(),
+		Percent:            promotion.Percent,
+		Amount:             promotion.Amount,
+		BuyQuantity:        promotion.BuyQuantity,
+		GetQuantity:        promotion.GetQuantity,
+		Categories:         encode(categoryStrings(promotion.Categories)),
+		ProductIDs:         encode(nonNil(promotion.ProductIDs)),
+		MinSubtotal:        promotion.MinSubtotal,
+		StartsAt:           promotion.StartsAt,
+		EndsAt:             promotion.EndsAt,
+		Schedule:           encode(ToScheduleDTO(promotion.Schedule)),
+		MaxUses:            promotion.MaxUses,
+		MaxUsesPerCustomer: promotion.MaxUsesPerCustomer,
+		Stackable:          promotion.Stackable,
+		Active:             promotion.Active,
+	}
+}
+
+func FromPromotionDAO(dao PromotionDAO) entity.Promotion {
+	var categories []string
+	var productIDs []string
+	var schedule *ScheduleDTO
+	_ = json.Unmarshal([]byte(dao.Categories), &categories)
+	_ = json.Unmarshal([]byte(dao.ProductIDs), &productIDs)
+	_ = json.Unmarshal([]byte(dao.Schedule), &schedule)
+
+	// Schedules are validated before they are stored
+	parsedSchedule, _ := FromScheduleDTO(schedule)
+
+	return entity.Promotion{
+		Entity:             dao.Entity,
+		Name:               dao.Name,
+		Code:               dao.Code,
+		Type:               enum.PromotionType(dao.Type),
+		Percent:            dao.Percent,
+		Amount:             dao.Amount,
+		BuyQuantity:        dao.BuyQuantity,
+		GetQuantity:        dao.GetQuantity,
+		Categories:         toCategories(categories),
+		ProductIDs:         productIDs,
+		MinSubtotal:        dao.MinSubtotal,
+		StartsAt:           dao.StartsAt,
+		EndsAt:             dao.EndsAt,
+		Schedule:           parsedSchedule,
+		MaxUses:            dao.MaxUses,
+		MaxUsesPerCustomer: dao.MaxUsesPerCustomer,
+		Stackable:          dao.Stackable,
+		Active:             dao.Active,
+	}
+}
+
+func EntityListFromDAOList(daoList []PromotionDAO) []entity.Promotion {
+	promotions := make([]entity.Promotion, 0, len(daoList))
+	for _, dao := range daoList {
+		promotions = append(promotions, FromPromotionDAO(dao))
+	}
+	return promotions
+}
+
+// FromRequestDTO builds the promotion of a request. A promotion is active unless told otherwise
+// and amounts sent without a currency are in the default one.
+func FromRequestDTO(request PromotionRequestDTO) (entity.Promotion, error) {
+	schedule, err := FromScheduleDTO(request.Schedule)
+	if err != nil {
+		return entity.Promotion{}, err
+	}
+
+	active := true
+	if request.Active != nil {
+		active = *request.Active
+	}
+
+	return entity.Promotion{
+		Name:               request.Name,
+		Code:               entity.NormalizeCode(request.Code),
+		Type:               enum.PromotionType(strings.ToUpper(request.Type)),
+		Percent:            request.Percent,
+		Amount:             withCurrency(request.Amount),
+		BuyQuantity:        request.BuyQuantity,
+		GetQuantity:        request.GetQuantity,
+		Categories:         toCategories(request.Categories),
+		ProductIDs:         request.ProductIDs,
+		MinSubtotal:        withCurrency(request.MinSubtotal),
+		StartsAt:           request.StartsAt,
+		EndsAt:             request.EndsAt,
+		Schedule:           schedule,
+		MaxUses:            request.MaxUses,
+		MaxUsesPerCustomer: request.MaxUsesPerCustomer,
+		Stackable:          request.Stackable,
+		Active:             active,
+	}, nil
+}
+
+func ToResponseDTO(promotion entity.Promotion, usage entity.Usage) PromotionResponseDTO {
+	response := PromotionResponseDTO{
+		ID:                 promotion.ID,
+		Name:               promotion.Name,
+		Code:               promotion.Code,
+		Type:               promotion.Type.String(),
+		Percent:            promotion.Percent,
+		BuyQuantity:        promotion.BuyQuantity,
+		GetQuantity:        promotion.GetQuantity,
+		Categories:         categoryStrings(promotion.Categories),
+		ProductIDs:         nonNil(promotion.ProductIDs),
+		StartsAt:           promotion.StartsAt,
+		EndsAt:             promotion.EndsAt,
+		Schedule:           ToScheduleDTO(promotion.Schedule),
+		MaxUses:            promotion.MaxUses,
+		MaxUsesPerCustomer: promotion.MaxUsesPerCustomer,
+		Stackable:          promotion.Stackable,
+		Active:             promotion.Active,
+		Uses:               usage.Total,
+	}
+	if promotion.Type == enum.PromotionTypeFixed {
+		response.Amount = &promotion.Amount
+	}
+	if promotion.MinSubtotal.IsPositive() {
+		response.MinSubtotal = &promotion.MinSubtotal
+	}
+	return response
+}
+
+func ToRedemptionDAO(redemption entity.Redemption) RedemptionDAO {
+	return RedemptionDAO{
+		ID:          redemption.ID,
+		PromotionID: redemption.PromotionID,
+		OrderID:     redemption.OrderID,
+		CustomerID:  redemption.CustomerID,
+		Amount:      redemption.Amount,
+		CreatedAt:   redemption.CreatedAt,
+	}
+}
+
+func FromUsageDAOList(rows []UsageDAO) map[string]entity.Usage {
+	usage := make(map[string]entity.Usage, len(rows))
+	for _, row := range rows {
+		usage[row.PromotionID] = entity.Usage{Total: row.Total, ByCustomer: row.ByCustomer}
+	}
+	return usage
+}
+
+func ToScheduleDTO(schedule *entity.Schedule) *ScheduleDTO {
+	if schedule == nil {
+		return nil
+	}
+	weekdays := make([]int, 0, len(schedule.Weekdays))
+	for _, weekday := range schedule.Weekdays {
+		weekdays = append(weekdays, int(weekday))
+	}
+	return &ScheduleDTO{
+		Weekdays: weekdays,
+		Start:    formatMinute(schedule.StartMinute),
+		End:      formatMinute(schedule.EndMinute),
+	}
+}
+
+func FromScheduleDTO(schedule *ScheduleDTO) (*entity.Schedule, error) {
+	if schedule == nil {
+		return nil, nil
+	}
+	start, err := parseMinute(schedule.Start)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseMinute(schedule.End)
+	if err != nil {
+		return nil, err
+	}
+	var weekdays []time.Weekday
+	for _, weekday := range schedule.Weekdays {
+		weekdays = append(weekdays, time.Weekday(weekday))
+	}
+	return &entity.Schedule{Weekdays: weekdays, StartMinute: start, EndMinute: end}, nil
+}
+
+// parseMinute reads a "15:04" time of day as minutes since midnight
+func parseMinute(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, &apperror.ValidationError{Msg: fmt.Sprintf("invalid time of day %q, expected HH:MM", value)}
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+func formatMinute(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+func withCurrency(amount money.Money) money.Money {
+	if amount.Currency == "" {
+		amount.Currency = money.DefaultCurrency
+	}
+	return amount
+}
+
+func toCategories(values []string) []productenum.Category {
+	var categories []productenum.Category
+	for _, value := range values {
+		categories = append(categories, productenum.Category(strings.ToUpper(value)))
+	}
+	return categories
+}
+
+func categoryStrings(categories []productenum.Category) []string {
+	result := make([]string, 0, len(categories))
+	for _, category := range categories {
+		result = append(result, string(category))
+	}
+	return result
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func encode(value any) string {
+	raw, _ := json.Marshal(value)
+	return string(raw)
+}
@@ -0,0 +1,141 @@
+package entity
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+)
+
+// Basket is an order being priced. At is the time of the order in the store timezone.
+type Basket struct {
+	CustomerID string
+	Codes      []string
+	Lines      []Line
+	At         time.Time
+}
+
+type Line struct {
+	ProductID string
+	Category  productenum.Category
+	Quantity  int
+	UnitPrice money.Money
+}
+
+// Usage is how many times a promotion was redeemed overall and by the customer of the basket
+type Usage struct {
+	Total      int
+	ByCustomer int
+}
+
+// Discount is a promotion applied to an order
+type Discount struct {
+	PromotionID string
+	Code        string
+	Name        string
+	Amount      money.Money
+}
+
+func (b Basket) Subtotal() (money.Money, error) {
+	var subtotal money.Money
+	for _, line := range b.Lines {
+		var err error
+		subtotal, err = subtotal.Add(line.UnitPrice.Multiply(int64(line.Quantity)))
+		if err != nil {
+			return money.Money{}, err
+		}
+	}
+	return subtotal, nil
+}
+
+// Evaluate picks the discounts of the basket: every automatic promotion that applies plus the
+// coupons entered. A coupon that cannot be used is an error telling the customer why, while an
+// automatic promotion that does not apply is just left out. Stackable promotions add up and a
+// promotion that is not stackable stands alone; whichever gives the larger discount wins. The
+// discounts never add up to more than the subtotal.
+func Evaluate(basket Basket, promotions []Promotion, usage map[string]Usage) ([]Discount, error) {
+	subtotal, err := basket.Subtotal()
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make(map[string]bool, len(basket.Codes))
+	for _, code := range basket.Codes {
+		codes[NormalizeCode(code)] = true
+	}
+	for code := range codes {
+		if !slices.ContainsFunc(promotions, func(p Promotion) bool { return p.Code == code }) {
+			return nil, &apperror.ValidationError{Msg: fmt.Sprintf("Coupon %s is not valid", code)}
+		}
+	}
+
+	var stackable, alone []Discount
+	for _, promotion := range promotions {
+		if promotion.IsCoupon() && !codes[promotion.Code] {
+			continue
+		}
+
+		if err := promotion.check(basket, subtotal, usage[promotion.ID]); err != nil {
+			if promotion.IsCoupon() {
+				return nil, err
+			}
+			continue
+		}
+
+		amount := promotion.DiscountOn(basket.Lines, subtotal.Currency)
+		if amount.IsZero() {
+			if promotion.IsCoupon() {
+				return nil, promotion.unavailable("does not apply to the items of this order")
+			}
+			continue
+		}
+
+		discount := Discount{
+			PromotionID: promotion.ID,
+			Code:        promotion.Code,
+			Name:        promotion.Name,
+			Amount:      amount,
+		}
+		if promotion.Stackable {
+			stackable = append(stackable, discount)
+		} else {
+			alone = append(alone, discount)
+		}
+	}
+
+	best, bestTotal := stackable, total(stackable)
+	for _, discount := range alone {
+		if discount.Amount.Amount > bestTotal {
+			best, bestTotal = []Discount{discount}, discount.Amount.Amount
+		}
+	}
+
+	slices.SortStableFunc(best, func(a, b Discount) int { return cmp.Compare(b.Amount.Amount, a.Amount.Amount) })
+	return capped(best, subtotal.Amount), nil
+}
+
+func total(discounts []Discount) int64 {
+	var sum int64
+	for _, discount := range discounts {
+		sum += discount.Amount.Amount
+	}
+	return sum
+}
+
+// capped trims the discounts so that together they take at most limit off
+func capped(discounts []Discount, limit int64) []Discount {
+	var result []Discount
+	for _, discount := range discounts {
+		if limit <= 0 {
+			break
+		}
+		discount.Amount.Amount = min(discount.Amount.Amount, limit)
+		limit -= discount.Amount.Amount
+		result = append(result, discount)
+	}
+	return result
+}
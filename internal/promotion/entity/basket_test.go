@@ -0,0 +1,155 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/entity/enum"
+	coreentity "github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+	"github.com/stretchr/testify/assert"
+)
+
+// Friday 2026-03-13 18:30
+var friday = time.Date(2026, time.March, 13, 18, 30, 0, 0, time.UTC)
+
+func basket(codes ...string) Basket {
+	return Basket{
+		CustomerID: "customer-1",
+		Codes:      codes,
+		At:         friday,
+		Lines: []Line{
+			{ProductID: "burger", Category: productenum.Meal, Quantity: 2, UnitPrice: money.FromCents(3000)},
+			{ProductID: "soda", Category: productenum.Drink, Quantity: 3, UnitPrice: money.FromCents(600)},
+		},
+	}
+}
+
+func promotion(id string, p Promotion) Promotion {
+	p.Entity = coreentity.Entity{ID: id}
+	p.Name = id
+	p.Active = true
+	return p
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Run("Given a percentage coupon, when the basket is evaluated, then the percentage of the subtotal is taken off", func(t *testing.T) {
+		promotions := []Promotion{promotion("ten", Promotion{Code: "TEN", Type: enum.PromotionTypePercentage, Percent: 10})}
+
+		discounts, err := Evaluate(basket("ten"), promotions, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Discount{{PromotionID: "ten", Code: "TEN", Name: "ten", Amount: money.FromCents(780)}}, discounts)
+	})
+
+	t.Run("Given a coupon that was not entered, when the basket is evaluated, then it is not applied", func(t *testing.T) {
+		promotions := []Promotion{promotion("ten", Promotion{Code: "TEN", Type: enum.PromotionTypePercentage, Percent: 10})}
+
+		discounts, err := Evaluate(basket(), promotions, nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, discounts)
+	})
+
+	t.Run("Given a category discount, when the basket is evaluated, then only the lines of the category are discounted", func(t *testing.T) {
+		promotions := []Promotion{promotion("drinks", Promotion{Type: enum.PromotionTypePercentage, Percent: 50, Categories: []productenum.Category{productenum.Drink}})}
+
+		discounts, err := Evaluate(basket(), promotions, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, money.FromCents(900), discounts[0].Amount)
+	})
+
+	t.Run("Given buy 2 get 1, when the basket is evaluated, then the cheapest unit of every three is free", func(t *testing.T) {
+		promotions := []Promotion{promotion("b2g1", Promotion{Type: enum.PromotionTypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1})}
+
+		discounts, err := Evaluate(basket(), promotions, nil)
+
+		assert.NoError(t, err)
+		// 3000 3000 600 | 600 600: only the first group is complete
+		assert.Equal(t, money.FromCents(600), discounts[0].Amount)
+	})
+
+	t.Run("Given a happy hour, when the basket is evaluated inside and outside of it, then it applies only inside", func(t *testing.T) {
+		happyHour := promotion("happy", Promotion{
+			Type:     enum.PromotionTypeFixed,
+			Amount:   money.FromCents(500),
+			Schedule: &Schedule{Weekdays: []time.Weekday{time.Friday}, StartMinute: 18 * 60, EndMinute: 20 * 60},
+		})
+
+		inside, err := Evaluate(basket(), []Promotion{happyHour}, nil)
+		assert.NoError(t, err)
+		assert.Len(t, inside, 1)
+
+		late := basket()
+		late.At = friday.Add(2 * time.Hour)
+		outside, err := Evaluate(late, []Promotion{happyHour}, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, outside)
+	})
+
+	t.Run("Given stackable and standalone promotions, when the basket is evaluated, then the larger of the two options wins", func(t *testing.T) {
+		promotions := []Promotion{
+			promotion("five", Promotion{Type: enum.PromotionTypeFixed, Amount: money.FromCents(500), Stackable: true}),
+			promotion("drinks", Promotion{Type: enum.PromotionTypePercentage, Percent: 50, Categories: []productenum.Category{productenum.Drink}, Stackable: true}),
+			promotion("big", Promotion{Code: "BIG", Type: enum.PromotionTypeFixed, Amount: money.FromCents(1500)}),
+		}
+
+		discounts, err := Evaluate(basket("big"), promotions, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"big"}, []string{discounts[0].PromotionID})
+
+		promotions[2].Amount = money.FromCents(1000)
+		discounts, err = Evaluate(basket("big"), promotions, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"drinks", "five"}, []string{discounts[0].PromotionID, discounts[1].PromotionID})
+	})
+
+	t.Run("Given discounts larger than the subtotal, when the basket is evaluated, then they are capped at the subtotal", func(t *testing.T) {
+		promotions := []Promotion{
+			promotion("a", Promotion{Type: enum.PromotionTypePercentage, Percent: 80, Stackable: true}),
+			promotion("b", Promotion{Type: enum.PromotionTypePercentage, Percent: 50, Stackable: true}),
+		}
+
+		discounts, err := Evaluate(basket(), promotions, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, money.FromCents(6240), discounts[0].Amount)
+		assert.Equal(t, money.FromCents(7800-6240), discounts[1].Amount)
+	})
+
+	tests := []struct {
+		name      string
+		basket    Basket
+		promotion Promotion
+		usage     Usage
+	}{
+		{name: "Given an unknown coupon, when the basket is evaluated, then it is rejected", basket: basket("NOPE"), promotion: promotion("ten", Promotion{Code: "TEN", Type: enum.PromotionTypePercentage, Percent: 10})},
+		{name: "Given an expired coupon, when the basket is evaluated, then it is rejected", basket: basket("TEN"), promotion: promotion("ten", Promotion{Code: "TEN", Type: enum.PromotionTypePercentage, Percent: 10, EndsAt: &friday})},
+		{name: "Given a coupon used up globally, when the basket is evaluated, then it is rejected", basket: basket("TEN"), promotion: promotion("ten", Promotion{Code: "TEN", Type: enum.PromotionTypePercentage, Percent: 10, MaxUses: 100}), usage: Usage{Total: 100}},
+		{name: "Given a coupon the customer already used, when the basket is evaluated, then it is rejected", basket: basket("TEN"), promotion: promotion("ten", Promotion{Code: "TEN", Type: enum.PromotionTypePercentage, Percent: 10, MaxUsesPerCustomer: 1}), usage: Usage{Total: 5, ByCustomer: 1}},
+		{name: "Given a coupon for products not in the basket, when the basket is evaluated, then it is rejected", basket: basket("TEN"), promotion: promotion("ten", Promotion{Code: "TEN", Type: enum.PromotionTypePercentage, Percent: 10, Categories: []productenum.Category{productenum.Dessert}})},
+		{name: "Given a coupon with a minimum subtotal not reached, when the basket is evaluated, then it is rejected", basket: basket("TEN"), promotion: promotion("ten", Promotion{Code: "TEN", Type: enum.PromotionTypePercentage, Percent: 10, MinSubtotal: money.FromCents(10000)})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Evaluate(tt.basket, []Promotion{tt.promotion}, map[string]Usage{tt.promotion.ID: tt.usage})
+
+			var validationErr *apperror.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+		})
+	}
+}
+
+func TestScheduleContains(t *testing.T) {
+	t.Run("Given a window crossing midnight, when times on both sides are checked, then both are inside", func(t *testing.T) {
+		late := Schedule{StartMinute: 22 * 60, EndMinute: 2 * 60}
+
+		assert.True(t, late.Contains(time.Date(2026, time.March, 13, 23, 0, 0, 0, time.UTC)))
+		assert.True(t, late.Contains(time.Date(2026, time.March, 14, 1, 59, 0, 0, time.UTC)))
+		assert.False(t, late.Contains(time.Date(2026, time.March, 14, 2, 0, 0, 0, time.UTC)))
+	})
+}
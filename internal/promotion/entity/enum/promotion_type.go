@@ -0,0 +1,27 @@
+package enum
+
+type PromotionType string
+
+const (
+	// PromotionTypePercentage takes a percentage off the eligible lines
+	PromotionTypePercentage PromotionType = "PERCENTAGE"
+	// PromotionTypeFixed takes a fixed amount off the eligible lines
+	PromotionTypeFixed PromotionType = "FIXED"
+	// PromotionTypeBuyXGetY discounts the cheapest Y of every X+Y eligible units
+	PromotionTypeBuyXGetY PromotionType = "BUY_X_GET_Y"
+)
+
+var TypeMapper = map[string]PromotionType{
+	PromotionTypePercentage.String(): PromotionTypePercentage,
+	PromotionTypeFixed.String():      PromotionTypeFixed,
+	PromotionTypeBuyXGetY.String():   PromotionTypeBuyXGetY,
+}
+
+func (t PromotionType) String() string {
+	return string(t)
+}
+
+func (t PromotionType) IsValid() bool {
+	_, ok := TypeMapper[t.String()]
+	return ok
+}
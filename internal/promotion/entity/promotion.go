@@ -0,0 +1,233 @@
+package entity
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/entity/enum"
+	coreentity "github.com/fiap-161/tc-golunch-core-service/internal/shared/entity"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+	"github.com/google/uuid"
+)
+
+// Promotion is a discount on orders. A promotion with a code is a coupon the customer has to
+// enter; one without a code applies by itself to every order it matches. Categories and
+// ProductIDs narrow the lines it applies to, all lines when both are empty.
+type Promotion struct {
+	coreentity.Entity
+	Name string
+	Code string
+	Type enum.PromotionType
+	// Percent is taken off the eligible lines by PERCENTAGE promotions and off the units given
+	// away by BUY_X_GET_Y ones, which give them for free when it is zero
+	Percent int64
+	// Amount is taken off the eligible lines by FIXED promotions
+	Amount             money.Money
+	BuyQuantity        int
+	GetQuantity        int
+	Categories         []productenum.Category
+	ProductIDs         []string
+	MinSubtotal        money.Money
+	StartsAt           *time.Time
+	EndsAt             *time.Time
+	Schedule           *Schedule
+	MaxUses            int
+	MaxUsesPerCustomer int
+	Stackable          bool
+	Active             bool
+}
+
+// Schedule limits a promotion to some hours of some weekdays, such as a happy hour. Minutes are
+// counted from midnight in the store timezone and a window ending before it starts crosses
+// midnight. No weekdays means every day.
+type Schedule struct {
+	Weekdays    []time.Weekday
+	StartMinute int
+	EndMinute   int
+}
+
+const minutesPerDay = 24 * 60
+
+func (p Promotion) Build() Promotion {
+	now := time.Now()
+	p.Entity = coreentity.Entity{
+		ID:        uuid.NewString(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	p.Code = NormalizeCode(p.Code)
+	return p
+}
+
+// NormalizeCode makes coupon codes case insensitive
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (p Promotion) IsCoupon() bool {
+	return p.Code != ""
+}
+
+func (p Promotion) Validate() error {
+	if p.Name == "" {
+		return &apperror.ValidationError{Msg: "Name is required"}
+	}
+
+	switch p.Type {
+	case enum.PromotionTypePercentage:
+		if p.Percent <= 0 || p.Percent > 100 {
+			return &apperror.ValidationError{Msg: "Percent must be between 1 and 100"}
+		}
+	case enum.PromotionTypeFixed:
+		if !p.Amount.IsPositive() {
+			return &apperror.ValidationError{Msg: "Amount must be positive"}
+		}
+	case enum.PromotionTypeBuyXGetY:
+		if p.BuyQuantity <= 0 || p.GetQuantity <= 0 {
+			return &apperror.ValidationError{Msg: "Buy and get quantities must be greater than zero"}
+		}
+		if p.Percent < 0 || p.Percent > 100 {
+			return &apperror.ValidationError{Msg: "Percent must be between 0 and 100"}
+		}
+	default:
+		return &apperror.ValidationError{Msg: fmt.Sprintf("Invalid promotion type %s", p.Type)}
+	}
+
+	if p.MinSubtotal.IsNegative() {
+		return &apperror.ValidationError{Msg: "Minimum subtotal cannot be negative"}
+	}
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return &apperror.ValidationError{Msg: "Promotion must end after it starts"}
+	}
+	if p.MaxUses < 0 || p.MaxUsesPerCustomer < 0 {
+		return &apperror.ValidationError{Msg: "Usage limits cannot be negative"}
+	}
+	if p.Schedule != nil {
+		return p.Schedule.Validate()
+	}
+	return nil
+}
+
+func (s Schedule) Validate() error {
+	if s.StartMinute < 0 || s.StartMinute >= minutesPerDay || s.EndMinute < 0 || s.EndMinute >= minutesPerDay {
+		return &apperror.ValidationError{Msg: "Schedule times must be between 00:00 and 23:59"}
+	}
+	if s.StartMinute == s.EndMinute {
+		return &apperror.ValidationError{Msg: "Schedule must end at a different time than it starts"}
+	}
+	for _, weekday := range s.Weekdays {
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return &apperror.ValidationError{Msg: fmt.Sprintf("Invalid weekday %d", weekday)}
+		}
+	}
+	return nil
+}
+
+// Contains reports whether at falls in the window. at must already be in the store timezone.
+func (s Schedule) Contains(at time.Time) bool {
+	if len(s.Weekdays) > 0 && !slices.Contains(s.Weekdays, at.Weekday()) {
+		return false
+	}
+
+	minute := at.Hour()*60 + at.Minute()
+	if s.StartMinute < s.EndMinute {
+		return minute >= s.StartMinute && minute < s.EndMinute
+	}
+	return minute >= s.StartMinute || minute < s.EndMinute
+}
+
+// covers reports whether the promotion applies to the line
+func (p Promotion) covers(line Line) bool {
+	if len(p.Categories) == 0 && len(p.ProductIDs) == 0 {
+		return true
+	}
+	return slices.Contains(p.ProductIDs, line.ProductID) || slices.Contains(p.Categories, line.Category)
+}
+
+// check tells why the promotion cannot be used on the basket, or nil when it can
+func (p Promotion) check(basket Basket, subtotal money.Money, usage Usage) error {
+	switch {
+	case !p.Active:
+		return p.unavailable("is not active")
+	case p.StartsAt != nil && basket.At.Before(*p.StartsAt):
+		return p.unavailable("has not started yet")
+	case p.EndsAt != nil && !basket.At.Before(*p.EndsAt):
+		return p.unavailable("has ended")
+	case p.Schedule != nil && !p.Schedule.Contains(basket.At):
+		return p.unavailable("is not available at this time")
+	}
+	if err := p.CheckLimits(basket.CustomerID, usage); err != nil {
+		return err
+	}
+
+	switch {
+	case p.Type == enum.PromotionTypeFixed && p.Amount.Currency != subtotal.Currency:
+		return p.unavailable(fmt.Sprintf("is not offered in %s", subtotal.Currency))
+	case p.MinSubtotal.IsPositive() && (p.MinSubtotal.Currency != subtotal.Currency || subtotal.Amount < p.MinSubtotal.Amount):
+		return p.unavailable(fmt.Sprintf("requires a subtotal of at least %s", p.MinSubtotal))
+	}
+	return nil
+}
+
+func (p Promotion) unavailable(reason string) error {
+	if p.IsCoupon() {
+		return &apperror.ValidationError{Msg: fmt.Sprintf("Coupon %s %s", p.Code, reason)}
+	}
+	return &apperror.ValidationError{Msg: fmt.Sprintf("Promotion %s %s", p.Name, reason)}
+}
+
+// DiscountOn computes what the promotion takes off the lines it covers, at most their total
+func (p Promotion) DiscountOn(lines []Line, currency money.Currency) money.Money {
+	var eligible int64
+	var units []int64
+	for _, line := range lines {
+		if !p.covers(line) {
+			continue
+		}
+		eligible += line.UnitPrice.Amount * int64(line.Quantity)
+		for range line.Quantity {
+			units = append(units, line.UnitPrice.Amount)
+		}
+	}
+
+	var amount int64
+	switch p.Type {
+	case enum.PromotionTypePercentage:
+		amount = eligible * p.Percent / 100
+	case enum.PromotionTypeFixed:
+		amount = min(p.Amount.Amount, eligible)
+	case enum.PromotionTypeBuyXGetY:
+		percent := p.Percent
+		if percent == 0 {
+			percent = 100
+		}
+		// Units are grouped from the most expensive down and the cheapest of every group go
+		group := p.BuyQuantity + p.GetQuantity
+		slices.SortFunc(units, func(a, b int64) int { return cmp.Compare(b, a) })
+		for start := 0; start+group <= len(units); start += group {
+			for _, unit := range units[start+p.BuyQuantity : start+group] {
+				amount += unit * percent / 100
+			}
+		}
+	}
+
+	return money.New(max(amount, 0), currency)
+}
+
+// CheckLimits tells whether the usage limits of the promotion leave room for one more use by customerID
+func (p Promotion) CheckLimits(customerID string, usage Usage) error {
+	switch {
+	case p.MaxUses > 0 && usage.Total >= p.MaxUses:
+		return p.unavailable("has reached its usage limit")
+	case p.MaxUsesPerCustomer > 0 && customerID == "":
+		return p.unavailable("requires an identified customer")
+	case p.MaxUsesPerCustomer > 0 && usage.ByCustomer >= p.MaxUsesPerCustomer:
+		return p.unavailable("was already used the maximum number of times")
+	}
+	return nil
+}
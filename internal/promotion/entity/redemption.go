@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/money"
+	"github.com/google/uuid"
+)
+
+// Redemption records a promotion used by an order, which is what usage limits count
+type Redemption struct {
+	ID          string
+	PromotionID string
+	OrderID     string
+	CustomerID  string
+	Amount      money.Money
+	CreatedAt   time.Time
+}
+
+func NewRedemption(orderID, customerID string, discount Discount) Redemption {
+	return Redemption{
+		ID:          uuid.NewString(),
+		PromotionID: discount.PromotionID,
+		OrderID:     orderID,
+		CustomerID:  customerID,
+		Amount:      discount.Amount,
+		CreatedAt:   time.Now(),
+	}
+}
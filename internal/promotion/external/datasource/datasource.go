@@ -0,0 +1,22 @@
+package datasource
+
+import (
+	"context"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/dto"
+)
+
+type DataSource interface {
+	Create(ctx context.Context, promotion dto.PromotionDAO) (dto.PromotionDAO, error)
+	List(ctx context.Context) ([]dto.PromotionDAO, error)
+	FindByID(ctx context.Context, id string) (dto.PromotionDAO, error)
+	FindByCode(ctx context.Context, code string) (dto.PromotionDAO, error)
+	FindApplicable(ctx context.Context, codes []string) ([]dto.PromotionDAO, error)
+	LockByIDs(ctx context.Context, ids []string) ([]dto.PromotionDAO, error)
+	Update(ctx context.Context, promotion dto.PromotionDAO) (dto.PromotionDAO, error)
+	Delete(ctx context.Context, id string) error
+	CountByCategory(ctx context.Context, category string) (int64, error)
+	Usage(ctx context.Context, promotionIDs []string, customerID string) ([]dto.UsageDAO, error)
+	CreateRedemptions(ctx context.Context, redemptions []dto.RedemptionDAO) error
+	DeleteRedemptionsByOrderID(ctx context.Context, orderID string) error
+}
@@ -0,0 +1,159 @@
+package datasource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/fiap-161/tc-golunch-core-service/database"
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/dto"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DB interface defines the database operations needed
+type DB interface {
+	Create(value any) *gorm.DB
+	Where(query any, args ...any) *gorm.DB
+	First(dest any, conds ...any) *gorm.DB
+	Find(dest any, conds ...any) *gorm.DB
+	Save(value any) *gorm.DB
+	Delete(value any, conds ...any) *gorm.DB
+	Order(value any) *gorm.DB
+	Raw(sql string, values ...any) *gorm.DB
+	Clauses(conds ...clause.Expression) *gorm.DB
+	Model(value any) *gorm.DB
+}
+
+type GormDataSource struct {
+	db DB
+}
+
+func New(db DB) DataSource {
+	return &GormDataSource{
+		db: db,
+	}
+}
+
+func (g *GormDataSource) Create(ctx context.Context, promotion dto.PromotionDAO) (dto.PromotionDAO, error) {
+	if err := database.Conn(ctx, g.db).Create(&promotion).Error; err != nil {
+		return dto.PromotionDAO{}, err
+	}
+	return promotion, nil
+}
+
+func (g *GormDataSource) List(ctx context.Context) ([]dto.PromotionDAO, error) {
+	var promotions []dto.PromotionDAO
+	if err := database.Conn(ctx, g.db).Order("created_at DESC").Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+func (g *GormDataSource) FindByID(ctx context.Context, id string) (dto.PromotionDAO, error) {
+	var promotion dto.PromotionDAO
+	if err := database.Conn(ctx, g.db).First(&promotion, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.PromotionDAO{}, &apperror.NotFoundError{Msg: "Promotion not found"}
+		}
+		return dto.PromotionDAO{}, err
+	}
+	return promotion, nil
+}
+
+func (g *GormDataSource) FindByCode(ctx context.Context, code string) (dto.PromotionDAO, error) {
+	var promotion dto.PromotionDAO
+	if err := database.Conn(ctx, g.db).First(&promotion, "code = ?", code).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.PromotionDAO{}, &apperror.NotFoundError{Msg: "Promotion not found"}
+		}
+		return dto.PromotionDAO{}, err
+	}
+	return promotion, nil
+}
+
+// FindApplicable returns the promotions that apply by themselves and the coupons of codes,
+// active or not so the customer can be told why a coupon was refused
+func (g *GormDataSource) FindApplicable(ctx context.Context, codes []string) ([]dto.PromotionDAO, error) {
+	var promotions []dto.PromotionDAO
+
+	query := database.Conn(ctx, g.db).Where("code = '' AND active = ?", true)
+	if len(codes) > 0 {
+		query = query.Or("code IN ?", codes)
+	}
+	if err := query.Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+// LockByIDs reads the promotions locking their rows until the transaction ends, so concurrent
+// orders check and record their redemptions one at a time
+func (g *GormDataSource) LockByIDs(ctx context.Context, ids []string) ([]dto.PromotionDAO, error) {
+	var promotions []dto.PromotionDAO
+	err := database.Conn(ctx, g.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", ids).
+		Order("id").
+		Find(&promotions).Error
+	if err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+func (g *GormDataSource) Update(ctx context.Context, promotion dto.PromotionDAO) (dto.PromotionDAO, error) {
+	if err := database.Conn(ctx, g.db).Save(&promotion).Error; err != nil {
+		return dto.PromotionDAO{}, err
+	}
+	return promotion, nil
+}
+
+func (g *GormDataSource) Delete(ctx context.Context, id string) error {
+	tx := database.Conn(ctx, g.db).Delete(&dto.PromotionDAO{}, "id = ?", id)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return &apperror.NotFoundError{Msg: "Promotion not found"}
+	}
+	return nil
+}
+
+// CountByCategory counts the promotions narrowed to category
+func (g *GormDataSource) CountByCategory(ctx context.Context, category string) (int64, error) {
+	categories, err := json.Marshal([]string{category})
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err = database.Conn(ctx, g.db).Model(&dto.PromotionDAO{}).Where("categories @> ?::jsonb", string(categories)).Count(&count).Error
+	return count, err
+}
+
+func (g *GormDataSource) Usage(ctx context.Context, promotionIDs []string, customerID string) ([]dto.UsageDAO, error) {
+	var rows []dto.UsageDAO
+	err := database.Conn(ctx, g.db).Raw(`
+		SELECT promotion_id,
+		       COUNT(*) AS total,
+		       COUNT(*) FILTER (WHERE ? <> '' AND customer_id = ?) AS by_customer
+		FROM promotion_redemptions
+		WHERE promotion_id IN ?
+		GROUP BY promotion_id`,
+		customerID, customerID, promotionIDs,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (g *GormDataSource) CreateRedemptions(ctx context.Context, redemptions []dto.RedemptionDAO) error {
+	return database.Conn(ctx, g.db).Create(&redemptions).Error
+}
+
+func (g *GormDataSource) DeleteRedemptionsByOrderID(ctx context.Context, orderID string) error {
+	return database.Conn(ctx, g.db).Delete(&dto.RedemptionDAO{}, "order_id = ?", orderID).Error
+}
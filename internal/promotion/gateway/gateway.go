@@ -0,0 +1,130 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/external/datasource"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+)
+
+type Gateway struct {
+	datasource datasource.DataSource
+}
+
+func Build(datasource datasource.DataSource) *Gateway {
+	return &Gateway{
+		datasource: datasource,
+	}
+}
+
+func (g *Gateway) Create(ctx context.Context, promotion entity.Promotion) (entity.Promotion, error) {
+	created, err := g.datasource.Create(ctx, dto.ToPromotionDAO(promotion))
+	if err != nil {
+		return entity.Promotion{}, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.FromPromotionDAO(created), nil
+}
+
+func (g *Gateway) List(ctx context.Context) ([]entity.Promotion, error) {
+	found, err := g.datasource.List(ctx)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.EntityListFromDAOList(found), nil
+}
+
+func (g *Gateway) FindByID(ctx context.Context, id string) (entity.Promotion, error) {
+	found, err := g.datasource.FindByID(ctx, id)
+	if err != nil {
+		return entity.Promotion{}, passNotFound(err)
+	}
+	return dto.FromPromotionDAO(found), nil
+}
+
+func (g *Gateway) FindByCode(ctx context.Context, code string) (entity.Promotion, error) {
+	found, err := g.datasource.FindByCode(ctx, code)
+	if err != nil {
+		return entity.Promotion{}, passNotFound(err)
+	}
+	return dto.FromPromotionDAO(found), nil
+}
+
+func (g *Gateway) FindApplicable(ctx context.Context, codes []string) ([]entity.Promotion, error) {
+	found, err := g.datasource.FindApplicable(ctx, codes)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.EntityListFromDAOList(found), nil
+}
+
+func (g *Gateway) LockByIDs(ctx context.Context, ids []string) ([]entity.Promotion, error) {
+	found, err := g.datasource.LockByIDs(ctx, ids)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.EntityListFromDAOList(found), nil
+}
+
+func (g *Gateway) Update(ctx context.Context, promotion entity.Promotion) (entity.Promotion, error) {
+	updated, err := g.datasource.Update(ctx, dto.ToPromotionDAO(promotion))
+	if err != nil {
+		return entity.Promotion{}, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.FromPromotionDAO(updated), nil
+}
+
+func (g *Gateway) Delete(ctx context.Context, id string) error {
+	if err := g.datasource.Delete(ctx, id); err != nil {
+		return passNotFound(err)
+	}
+	return nil
+}
+
+func (g *Gateway) CountByCategory(ctx context.Context, category productenum.Category) (int64, error) {
+	count, err := g.datasource.CountByCategory(ctx, string(category))
+	if err != nil {
+		return 0, &apperror.InternalError{Msg: err.Error()}
+	}
+	return count, nil
+}
+
+func (g *Gateway) Usage(ctx context.Context, promotionIDs []string, customerID string) (map[string]entity.Usage, error) {
+	if len(promotionIDs) == 0 {
+		return map[string]entity.Usage{}, nil
+	}
+	rows, err := g.datasource.Usage(ctx, promotionIDs, customerID)
+	if err != nil {
+		return nil, &apperror.InternalError{Msg: err.Error()}
+	}
+	return dto.FromUsageDAOList(rows), nil
+}
+
+func (g *Gateway) CreateRedemptions(ctx context.Context, redemptions []entity.Redemption) error {
+	daoList := make([]dto.RedemptionDAO, 0, len(redemptions))
+	for _, redemption := range redemptions {
+		daoList = append(daoList, dto.ToRedemptionDAO(redemption))
+	}
+	if err := g.datasource.CreateRedemptions(ctx, daoList); err != nil {
+		return &apperror.InternalError{Msg: err.Error()}
+	}
+	return nil
+}
+
+func (g *Gateway) DeleteRedemptionsByOrderID(ctx context.Context, orderID string) error {
+	if err := g.datasource.DeleteRedemptionsByOrderID(ctx, orderID); err != nil {
+		return &apperror.InternalError{Msg: err.Error()}
+	}
+	return nil
+}
+
+func passNotFound(err error) error {
+	var notFoundErr *apperror.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return notFoundErr
+	}
+	return &apperror.InternalError{Msg: err.Error()}
+}
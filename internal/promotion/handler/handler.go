@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/controller"
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/dto"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/fiap-161/tc-golunch-core-service/internal/shared/helper"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	controller *controller.Controller
+}
+
+func New(controller *controller.Controller) *Handler {
+	return &Handler{controller: controller}
+}
+
+// Create Promotion godoc
+// @Summary      Create Promotion
+// @Description  Create a promotion: PERCENTAGE or FIXED discounts, or BUY_X_GET_Y. A promotion with a code is a coupon customers enter at checkout; one without a code applies to every order it matches. Categories and product_ids narrow the items it applies to, dates and schedule when it applies, max_uses and max_uses_per_customer how often. Promotions that are not stackable are never combined with others.
+// @Tags         Promotion Domain
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.PromotionRequestDTO true "Promotion to create"
+// @Success      201  {object}  dto.PromotionResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Failure      409  {object}  errors.ErrorDTO
+// @Router       /admin/promotion [post]
+func (h *Handler) Create(c *gin.Context) {
+	var request dto.PromotionRequestDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "Invalid request body",
+			MessageError: err.Error(),
+		})
+		return
+	}
+
+	created, err := h.controller.Create(context.Background(), request)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// List Promotions godoc
+// @Summary      List Promotions
+// @Description  List every promotion, active or not, with how many times it was used
+// @Tags         Promotion Domain
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  dto.PromotionListResponseDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/promotion [get]
+func (h *Handler) List(c *gin.Context) {
+	promotions, err := h.controller.List(context.Background())
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, promotions)
+}
+
+// GetByID Get Promotion godoc
+// @Summary      Get Promotion
+// @Description  Get a promotion with how many times it was used
+// @Tags         Promotion Domain
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      string  true  "Promotion ID"
+// @Success      200  {object}  dto.PromotionResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/promotion/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	promotion, err := h.controller.FindByID(context.Background(), c.Param("id"))
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, promotion)
+}
+
+// Update Promotion godoc
+// @Summary      Update Promotion
+// @Description  Replace a promotion. Uses already made keep counting towards its limits.
+// @Tags         Promotion Domain
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                   true  "Promotion ID"
+// @Param        request  body      dto.PromotionRequestDTO  true  "Promotion data"
+// @Success      200  {object}  dto.PromotionResponseDTO
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Failure      409  {object}  errors.ErrorDTO
+// @Router       /admin/promotion/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	var request dto.PromotionRequestDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, apperror.ErrorDTO{
+			Message:      "Invalid request body",
+			MessageError: err.Error(),
+		})
+		return
+	}
+
+	updated, err := h.controller.Update(context.Background(), c.Param("id"), request)
+	if err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// Delete Promotion godoc
+// @Summary      Delete Promotion
+// @Description  Delete a promotion. Orders already placed keep their discounts; to stop a promotion while keeping its history, update it as inactive instead.
+// @Tags         Promotion Domain
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Promotion ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  errors.ErrorDTO
+// @Failure      401  {object}  errors.ErrorDTO
+// @Router       /admin/promotion/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	if err := h.controller.Delete(context.Background(), c.Param("id")); err != nil {
+		helper.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
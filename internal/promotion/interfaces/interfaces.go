@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+
+	productenum "github.com/fiap-161/tc-golunch-core-service/internal/product/entity/enum"
+)
+
+type CategoryService interface {
+	Check(ctx context.Context, codes []productenum.Category) error
+}
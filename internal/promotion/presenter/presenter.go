@@ -0,0 +1,29 @@
+package presenter
+
+import (
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/dto"
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/entity"
+)
+
+type Presenter struct {
+}
+
+func Build() *Presenter {
+	return &Presenter{}
+}
+
+func (p *Presenter) FromEntityToResponseDTO(promotion entity.Promotion, usage entity.Usage) dto.PromotionResponseDTO {
+	return dto.ToResponseDTO(promotion, usage)
+}
+
+func (p *Presenter) FromEntityListToListResponseDTO(promotions []entity.Promotion, usage map[string]entity.Usage) dto.PromotionListResponseDTO {
+	list := make([]dto.PromotionResponseDTO, 0, len(promotions))
+	for _, promotion := range promotions {
+		list = append(list, p.FromEntityToResponseDTO(promotion, usage[promotion.ID]))
+	}
+
+	return dto.PromotionListResponseDTO{
+		Total: uint(len(list)),
+		List:  list,
+	}
+}
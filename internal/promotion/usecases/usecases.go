@@ -0,0 +1,205 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/entity"
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/gateway"
+	"github.com/fiap-161/tc-golunch-core-service/internal/promotion/interfaces"
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/google/uuid"
+)
+
+type UseCases struct {
+	promotionGateway gateway.Gateway
+	categoryService  interfaces.CategoryService
+	location         *time.Location
+}
+
+// Build wires the promotion use cases. location is the store timezone happy hours are read in.
+func Build(promotionGateway gateway.Gateway, categoryService interfaces.CategoryService, location *time.Location) *UseCases {
+	return &UseCases{
+		promotionGateway: promotionGateway,
+		categoryService:  categoryService,
+		location:         location,
+	}
+}
+
+func (u *UseCases) Create(ctx context.Context, promotion entity.Promotion) (entity.Promotion, error) {
+	promotion = promotion.Build()
+	if err := u.validate(ctx, promotion); err != nil {
+		return entity.Promotion{}, err
+	}
+
+	return u.promotionGateway.Create(ctx, promotion)
+}
+
+// List returns every promotion with how many times it was redeemed
+func (u *UseCases) List(ctx context.Context) ([]entity.Promotion, map[string]entity.Usage, error) {
+	promotions, err := u.promotionGateway.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	usage, err := u.promotionGateway.Usage(ctx, ids(promotions), "")
+	if err != nil {
+		return nil, nil, err
+	}
+	return promotions, usage, nil
+}
+
+func (u *UseCases) FindByID(ctx context.Context, id string) (entity.Promotion, entity.Usage, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return entity.Promotion{}, entity.Usage{}, &apperror.ValidationError{Msg: "Invalid UUID format for promotion ID"}
+	}
+
+	promotion, err := u.promotionGateway.FindByID(ctx, id)
+	if err != nil {
+		return entity.Promotion{}, entity.Usage{}, err
+	}
+
+	usage, err := u.promotionGateway.Usage(ctx, []string{id}, "")
+	if err != nil {
+		return entity.Promotion{}, entity.Usage{}, err
+	}
+	return promotion, usage[id], nil
+}
+
+// Update replaces the promotion. Redemptions already made keep counting towards its limits.
+func (u *UseCases) Update(ctx context.Context, id string, promotion entity.Promotion) (entity.Promotion, error) {
+	existing, _, err := u.FindByID(ctx, id)
+	if err != nil {
+		return entity.Promotion{}, err
+	}
+
+	promotion.Entity = existing.Entity
+	promotion.UpdatedAt = time.Now()
+	promotion.Code = entity.NormalizeCode(promotion.Code)
+	if err := u.validate(ctx, promotion); err != nil {
+		return entity.Promotion{}, err
+	}
+
+	return u.promotionGateway.Update(ctx, promotion)
+}
+
+func (u *UseCases) Delete(ctx context.Context, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return &apperror.ValidationError{Msg: "Invalid UUID format for promotion ID"}
+	}
+
+	return u.promotionGateway.Delete(ctx, id)
+}
+
+// Evaluate returns the discounts the basket gets right now, see entity.Evaluate
+func (u *UseCases) Evaluate(ctx context.Context, basket entity.Basket) ([]entity.Discount, error) {
+	codes := make([]string, 0, len(basket.Codes))
+	for _, code := range basket.Codes {
+		codes = append(codes, entity.NormalizeCode(code))
+	}
+
+	promotions, err := u.promotionGateway.FindApplicable(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+	if len(promotions) == 0 && len(codes) == 0 {
+		return nil, nil
+	}
+
+	usage, err := u.promotionGateway.Usage(ctx, ids(promotions), basket.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	basket.Codes = codes
+	basket.At = basket.At.In(u.location)
+	return entity.Evaluate(basket, promotions, usage)
+}
+
+// Redeem records the discounts of an order. It runs in the transaction creating the order and
+// checks the usage limits again with the promotions locked, so two orders racing for the last
+// use of a coupon cannot both get it.
+func (u *UseCases) Redeem(ctx context.Context, orderID, customerID string, discounts []entity.Discount) error {
+	if len(discounts) == 0 {
+		return nil
+	}
+
+	promotionIDs := make([]string, 0, len(discounts))
+	for _, discount := range discounts {
+		promotionIDs = append(promotionIDs, discount.PromotionID)
+	}
+
+	promotions, err := u.promotionGateway.LockByIDs(ctx, promotionIDs)
+	if err != nil {
+		return err
+	}
+	usage, err := u.promotionGateway.Usage(ctx, promotionIDs, customerID)
+	if err != nil {
+		return err
+	}
+
+	redemptions := make([]entity.Redemption, 0, len(discounts))
+	for _, discount := range discounts {
+		var promotion *entity.Promotion
+		for i := range promotions {
+			if promotions[i].ID == discount.PromotionID {
+				promotion = &promotions[i]
+			}
+		}
+		if promotion == nil {
+			return &apperror.ConflictError{Msg: fmt.Sprintf("Promotion %s was removed, please review the order", discount.Name)}
+		}
+		if err := promotion.CheckLimits(customerID, usage[promotion.ID]); err != nil {
+			var validationErr *apperror.ValidationError
+			if errors.As(err, &validationErr) {
+				return &apperror.ConflictError{Msg: validationErr.Msg}
+			}
+			return err
+		}
+		redemptions = append(redemptions, entity.NewRedemption(orderID, customerID, discount))
+	}
+
+	return u.promotionGateway.CreateRedemptions(ctx, redemptions)
+}
+
+// Release gives back the uses of the promotions redeemed by an order that was cancelled or expired
+func (u *UseCases) Release(ctx context.Context, orderID string) error {
+	return u.promotionGateway.DeleteRedemptionsByOrderID(ctx, orderID)
+}
+
+// validate checks the promotion itself, that its categories exist and that no other promotion
+// uses its code
+func (u *UseCases) validate(ctx context.Context, promotion entity.Promotion) error {
+	if err := promotion.Validate(); err != nil {
+		return err
+	}
+	if err := u.categoryService.Check(ctx, promotion.Categories); err != nil {
+		return err
+	}
+	if !promotion.IsCoupon() {
+		return nil
+	}
+
+	existing, err := u.promotionGateway.FindByCode(ctx, promotion.Code)
+	var notFoundErr *apperror.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != promotion.ID {
+		return &apperror.ConflictError{Msg: fmt.Sprintf("Coupon code %s is already in use", promotion.Code)}
+	}
+	return nil
+}
+
+func ids(promotions []entity.Promotion) []string {
+	result := make([]string, 0, len(promotions))
+	for _, promotion := range promotions {
+		result = append(result, promotion.ID)
+	}
+	return result
+}
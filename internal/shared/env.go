@@ -0,0 +1,50 @@
+package shared
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// DurationFromEnv reads a duration such as "15m" from the environment variable key.
+// The fallback is returned when the variable is unset or cannot be parsed.
+func DurationFromEnv(key string, fallback time.Duration) time.Duration {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+
+	value, err := time.ParseDuration(raw)
+	if err != nil || value <= 0 {
+		log.Printf("Invalid duration %q for %s, using %s", raw, key, fallback)
+		return fallback
+	}
+
+	return value
+}
+
+// IntFromEnv reads an integer from the environment variable key.
+// The fallback is returned when the variable is unset or cannot be parsed.
+func IntFromEnv(key string, fallback int) int {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		log.Printf("Invalid integer %q for %s, using %d", raw, key, fallback)
+		return fallback
+	}
+
+	return value
+}
+
+// StringFromEnv reads the environment variable key, returning fallback when it is unset
+func StringFromEnv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
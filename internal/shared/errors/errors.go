@@ -1,5 +1,7 @@
 package errors
 
+import "fmt"
+
 type ErrorDTO struct {
 	Message      string `json:"message"`
 	MessageError string `json:"message_error"`
@@ -36,3 +38,22 @@ type NotFoundError struct {
 func (e *NotFoundError) Error() string {
 	return e.Msg
 }
+
+// ConflictError reports a write that clashes with the current state of a resource, such as a
+// stale version or a value that has to be unique
+type ConflictError struct {
+	Msg string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Msg
+}
+
+type InvalidTransitionError struct {
+	From string
+	To   string
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("cannot change status from %s to %s", e.From, e.To)
+}
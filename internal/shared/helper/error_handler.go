@@ -22,6 +22,12 @@ func HandleError(c *gin.Context, err error) {
 	case *apperror.NotFoundError:
 		status = http.StatusBadRequest
 		message = "Invalid resource"
+	case *apperror.ConflictError:
+		status = http.StatusConflict
+		message = "Conflict"
+	case *apperror.InvalidTransitionError:
+		status = http.StatusConflict
+		message = "Invalid status transition"
 	}
 
 	c.JSON(status, apperror.ErrorDTO{
@@ -0,0 +1,34 @@
+package helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+)
+
+// ETag formats the version of a resource as an entity tag
+func ETag(version uint) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// IfMatchVersion reads the version expected by the If-Match header. It returns nil when the
+// header is absent or "*", in which case the update applies to whatever version is current.
+func IfMatchVersion(c *gin.Context) (*uint, error) {
+	raw := strings.TrimSpace(c.GetHeader("If-Match"))
+	if raw == "" || raw == "*" {
+		return nil, nil
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(raw, "W/"), `"`)
+	version, err := strconv.ParseUint(tag, 10, 32)
+	if err != nil {
+		return nil, &apperror.ValidationError{Msg: fmt.Sprintf("invalid If-Match header %s", raw)}
+	}
+
+	expected := uint(version)
+	return &expected, nil
+}
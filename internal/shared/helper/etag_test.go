@@ -0,0 +1,45 @@
+package helper
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	version := func(v uint) *uint { return &v }
+
+	tests := []struct {
+		name    string
+		header  string
+		want    *uint
+		wantErr bool
+	}{
+		{name: "Given no If-Match header, when it is read, then no version is expected", header: "", want: nil},
+		{name: "Given a wildcard, when it is read, then no version is expected", header: "*", want: nil},
+		{name: "Given an ETag of this service, when it is read, then its version is expected", header: ETag(3), want: version(3)},
+		{name: "Given a weak ETag, when it is read, then its version is expected", header: `W/"7"`, want: version(7)},
+		{name: "Given a foreign ETag, when it is read, then it is rejected", header: `"abc"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("PUT", "/", nil)
+			if tt.header != "" {
+				c.Request.Header.Set("If-Match", tt.header)
+			}
+
+			got, err := IfMatchVersion(c)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -26,6 +26,11 @@ type PaymentResponse struct {
 	QRCode  string `json:"qr_code,omitempty"`
 }
 
+type PaymentCancelRequest struct {
+	OrderID string `json:"order_id"`
+	Reason  string `json:"reason"`
+}
+
 type OrderUpdateRequest struct {
 	Status string `json:"status"`
 }
@@ -113,3 +118,34 @@ func (c *PaymentServiceClient) GetPaymentStatus(ctx context.Context, paymentID s
 
 	return &paymentResp, nil
 }
+
+// CancelPayment voids the pending charge of an order. A missing payment is not an error.
+func (c *PaymentServiceClient) CancelPayment(ctx context.Context, orderID, reason string) error {
+	payload := PaymentCancelRequest{OrderID: orderID, Reason: reason}
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cancel request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/payment/cancel", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Add service authentication
+	c.addServiceAuth(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("payment service returned status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,225 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+)
+
+type Currency string
+
+const (
+	BRL Currency = "BRL"
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+)
+
+// DefaultCurrency is assumed for amounts given without a currency, such as prices
+// stored before currencies were tracked or plain numbers in requests
+const DefaultCurrency = BRL
+
+// All supported currencies have two decimal places
+const (
+	minorDigits   = 2
+	minorPerMajor = 100
+)
+
+func (c Currency) IsValid() bool {
+	switch c {
+	case BRL, USD, EUR:
+		return true
+	}
+	return false
+}
+
+// Money is an amount in minor units (cents) of a currency. Arithmetic is done on the
+// integer amount so totals never drift the way float64 sums do.
+type Money struct {
+	Amount   int64    `gorm:"column:amount;not null;default:0"`
+	Currency Currency `gorm:"column:currency;type:varchar(3);not null;default:'BRL'"`
+}
+
+func New(amount int64, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// FromCents builds an amount in the default currency
+func FromCents(cents int64) Money {
+	return New(cents, DefaultCurrency)
+}
+
+// Parse reads a decimal amount such as "25.90" or "-3.5" without going through float64.
+// More than two decimal places is rejected rather than rounded.
+func Parse(value string, currency Currency) (Money, error) {
+	if !currency.IsValid() {
+		return Money{}, &apperror.ValidationError{Msg: fmt.Sprintf("unsupported currency %q", currency)}
+	}
+
+	raw := strings.TrimSpace(value)
+	negative := strings.HasPrefix(raw, "-")
+	raw = strings.TrimPrefix(strings.TrimPrefix(raw, "-"), "+")
+
+	units, fraction, hasFraction := strings.Cut(raw, ".")
+	if units == "" || !isDigits(units) || (hasFraction && (fraction == "" || !isDigits(fraction))) {
+		return Money{}, &apperror.ValidationError{Msg: fmt.Sprintf("invalid amount %q", value)}
+	}
+	if len(fraction) > minorDigits {
+		return Money{}, &apperror.ValidationError{Msg: fmt.Sprintf("amount %q has more than %d decimal places", value, minorDigits)}
+	}
+
+	fraction += strings.Repeat("0", minorDigits-len(fraction))
+	amount, err := strconv.ParseInt(units+fraction, 10, 64)
+	if err != nil {
+		return Money{}, &apperror.ValidationError{Msg: fmt.Sprintf("amount %q is out of range", value)}
+	}
+	if negative {
+		amount = -amount
+	}
+	return New(amount, currency), nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// currency treats the zero value as the default currency
+func (m Money) currency() Currency {
+	if m.Currency == "" {
+		return DefaultCurrency
+	}
+	return m.Currency
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+func (m Money) Equal(other Money) bool {
+	return m.Amount == other.Amount && m.currency() == other.currency()
+}
+
+// Add sums two amounts of the same currency. A zero amount takes the currency of the other side,
+// so a total can start from the zero value.
+func (m Money) Add(other Money) (Money, error) {
+	switch {
+	case m.IsZero() && m.Currency == "":
+		return New(other.Amount, other.currency()), nil
+	case other.IsZero() && other.Currency == "":
+		return New(m.Amount, m.currency()), nil
+	case m.currency() != other.currency():
+		return Money{}, &apperror.ValidationError{Msg: fmt.Sprintf("cannot add %s to %s", other.currency(), m.currency())}
+	}
+	return New(m.Amount+other.Amount, m.currency()), nil
+}
+
+func (m Money) Multiply(quantity int64) Money {
+	return New(m.Amount*quantity, m.currency())
+}
+
+// String formats the amount as a decimal with two places, such as "25.90"
+func (m Money) String() string {
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/minorPerMajor, amount%minorPerMajor)
+}
+
+type moneyJSON struct {
+	Amount   string   `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+// MarshalJSON writes {"amount":"25.90","currency":"BRL"}, the amount as an exact decimal string
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.String(), Currency: m.currency()})
+}
+
+// UnmarshalJSON accepts the object written by MarshalJSON, or a bare number or string
+// such as 25.90 or "25.90" in the default currency. Numbers are read from their literal
+// text, never through float64.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var raw moneyJSON
+	switch {
+	case bytes.HasPrefix(data, []byte("{")):
+		var object struct {
+			Amount   json.RawMessage `json:"amount"`
+			Currency Currency        `json:"currency"`
+		}
+		if err := json.Unmarshal(data, &object); err != nil {
+			return err
+		}
+		amount, err := literal(object.Amount)
+		if err != nil {
+			return err
+		}
+		raw = moneyJSON{Amount: amount, Currency: object.Currency}
+	default:
+		amount, err := literal(data)
+		if err != nil {
+			return err
+		}
+		raw = moneyJSON{Amount: amount}
+	}
+
+	if raw.Currency == "" {
+		raw.Currency = DefaultCurrency
+	}
+	parsed, err := Parse(raw.Amount, raw.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// literal returns the text of a JSON number or string
+func literal(data json.RawMessage) (string, error) {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return "", err
+		}
+		return text, nil
+	}
+
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return "", &apperror.ValidationError{Msg: fmt.Sprintf("invalid amount %s", data)}
+	}
+	return number.String(), nil
+}
+
+// UnmarshalParam reads query string and form values, such as ?min_price=10.50, in the default currency
+func (m *Money) UnmarshalParam(param string) error {
+	parsed, err := Parse(param, DefaultCurrency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
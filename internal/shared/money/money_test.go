@@ -0,0 +1,109 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	apperror "github.com/fiap-161/tc-golunch-core-service/internal/shared/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected Money
+	}{
+		{name: "Given an amount with two decimals, when it is parsed, then it is read in cents", value: "25.90", expected: New(2590, BRL)},
+		{name: "Given an amount with one decimal, when it is parsed, then the missing cent is zero", value: "25.9", expected: New(2590, BRL)},
+		{name: "Given a whole amount, when it is parsed, then it is read in cents", value: "7", expected: New(700, BRL)},
+		{name: "Given a negative amount, when it is parsed, then it keeps the sign", value: "-0.05", expected: New(-5, BRL)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := Parse(tt.value, BRL)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, parsed)
+		})
+	}
+
+	invalid := []struct {
+		name     string
+		value    string
+		currency Currency
+	}{
+		{name: "Given more than two decimals, when it is parsed, then it is rejected", value: "1.005", currency: BRL},
+		{name: "Given a value that is not a number, when it is parsed, then it is rejected", value: "ten", currency: BRL},
+		{name: "Given a missing integer part, when it is parsed, then it is rejected", value: ".5", currency: BRL},
+		{name: "Given an unknown currency, when it is parsed, then it is rejected", value: "1.00", currency: "XYZ"},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.value, tt.currency)
+
+			var validationErr *apperror.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+		})
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	t.Run("Given amounts that drift as float64, when they are summed, then the total is exact", func(t *testing.T) {
+		total := Money{}
+		for i := 0; i < 10; i++ {
+			var err error
+			total, err = total.Add(FromCents(10))
+			assert.NoError(t, err)
+		}
+
+		assert.Equal(t, New(100, BRL), total)
+		assert.Equal(t, "1.00", total.String())
+	})
+
+	t.Run("Given a unit price, when it is multiplied by a quantity, then the line total is exact", func(t *testing.T) {
+		assert.Equal(t, "77.70", FromCents(2590).Multiply(3).String())
+	})
+
+	t.Run("Given amounts in different currencies, when they are summed, then it is rejected", func(t *testing.T) {
+		_, err := New(100, BRL).Add(New(100, USD))
+
+		var validationErr *apperror.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("Given a zero value, when it is compared to a zero amount in the default currency, then they are equal", func(t *testing.T) {
+		assert.True(t, Money{}.Equal(FromCents(0)))
+	})
+}
+
+func TestJSON(t *testing.T) {
+	t.Run("Given an amount, when it is marshalled, then the decimal is written exactly with its currency", func(t *testing.T) {
+		data, err := json.Marshal(New(-1005, USD))
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"amount":"-10.05","currency":"USD"}`, string(data))
+	})
+
+	tests := []struct {
+		name     string
+		data     string
+		expected Money
+	}{
+		{name: "Given the marshalled object, when it is unmarshalled, then it round trips", data: `{"amount":"25.90","currency":"EUR"}`, expected: New(2590, EUR)},
+		{name: "Given a bare number, when it is unmarshalled, then it is read from its literal in the default currency", data: `0.29`, expected: New(29, BRL)},
+		{name: "Given a bare string, when it is unmarshalled, then it is read in the default currency", data: `"12.5"`, expected: New(1250, BRL)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parsed Money
+			err := json.Unmarshal([]byte(tt.data), &parsed)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, parsed)
+		})
+	}
+}